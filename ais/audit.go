@@ -0,0 +1,461 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Audit log: AIS gateways record who did what (user, action, bucket, object, HTTP status)
+// as JSON lines in the "audit.log" file under the node's log directory; the file is
+// rotated upon exceeding the configured size (see cmn.AuditConf).
+// In addition, a fixed number of the most recent events are kept in memory
+// to be queried via GET(what=audit).
+// - cluster and bucket classes: modifying operations only (i.e., not GET or HEAD);
+// - object class: all operations (optionally, sampled); note that data plane requests
+//   are normally redirected to targets, and the recorded status is then the redirect.
+
+const (
+	auditLogName  = "audit.log"
+	auditRingSize = 1024
+	auditMaxPeek  = 64 * cos.KiB // (action message)
+
+	dfltAuditMaxSize  = 64 * cos.MiB
+	dfltAuditMaxFiles = 4
+	dfltAuditLimit    = 100
+
+	hdrForwardedFor = "X-Forwarded-For" // (httputil.ReverseProxy)
+)
+
+type (
+	auditor struct {
+		p    *proxy
+		fh   *os.File
+		ring []*apc.AuditEvent // circular buffer of the most recent events
+		next int
+		size int64
+		mu   sync.Mutex
+		nips nodeIPs
+	}
+	// node addresses (hostnames) resolved to IPs once per Smap version: IP => node IDs
+	// (a given IP may be shared, e.g. when running nodes on the same host)
+	nodeIPs struct {
+		m    map[string][]string
+		sver int64
+		mu   sync.Mutex
+	}
+	// captures response status
+	auditWriter struct {
+		http.ResponseWriter
+		status    int
+		forwarded bool // to the primary, to be recorded there (see forwardCP)
+	}
+)
+
+// interface guard
+var _ http.Flusher = (*auditWriter)(nil)
+
+func (a *auditor) init(p *proxy) {
+	a.p = p
+	a.ring = make([]*apc.AuditEvent, 0, auditRingSize)
+}
+
+// wrap (public) http handler; class "" - bucket or object, depending on the URL path
+func (a *auditor) wrap(class string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		config := cmn.GCO.Get()
+		if !config.Audit.Enabled || a.intra(r) {
+			h(w, r)
+			return
+		}
+		ev := a.prep(&config.Audit, class, r)
+		if ev == nil {
+			h(w, r)
+			return
+		}
+		aw := &auditWriter{ResponseWriter: w}
+		h(aw, r)
+		if aw.forwarded {
+			return
+		}
+		ev.Status = cos.NonZero(aw.status, http.StatusOK)
+		if cmn.Rom.AuthEnabled() {
+			ev.User = a.p.userID(r.Header) // (after the handler - see s3Auth)
+		}
+		a.add(ev, config)
+	}
+}
+
+// intra-cluster request (not audited): the caller is a node in the current Smap that
+// connects via intra-cluster network (see isIntraNet); when the latter is not configured
+// (single network) - from one of the caller's own (resolved) addresses
+func (a *auditor) intra(r *http.Request) bool {
+	callerID := r.Header.Get(apc.HdrCallerID)
+	if callerID == "" {
+		return false
+	}
+	smap := a.p.owner.smap.get()
+	if smap == nil || smap.GetNode(callerID) == nil || !isIntraNet(r) {
+		return false
+	}
+	if config := cmn.GCO.Get(); config.HostNet.UseIntraControl || config.HostNet.UseIntraData {
+		return true
+	}
+	return slices.Contains(a.nips.get(smap, remoteIP(r.RemoteAddr)), callerID)
+}
+
+func remoteIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// client address: the remote address of the connection or, when the latter is a trusted
+// proxy (configured, or another AIS gateway - see forwardCP), the rightmost
+// untrusted "X-Forwarded-For" address
+func (a *auditor) client(conf *cmn.AuditConf, r *http.Request) string {
+	fwd := r.Header.Values(hdrForwardedFor)
+	if len(fwd) == 0 || !a.trusted(conf, remoteIP(r.RemoteAddr)) {
+		return r.RemoteAddr
+	}
+	addrs := strings.Split(strings.Join(fwd, ","), ",")
+	for i := len(addrs) - 1; i >= 0; i-- {
+		addr := strings.TrimSpace(addrs[i])
+		if addr == "" {
+			continue
+		}
+		if i == 0 || !a.trusted(conf, addr) {
+			return addr
+		}
+	}
+	return r.RemoteAddr
+}
+
+func (a *auditor) trusted(conf *cmn.AuditConf, ip string) bool {
+	if conf.IsTrustedProxy(ip) {
+		return true
+	}
+	smap := a.p.owner.smap.get()
+	if smap == nil {
+		return false
+	}
+	for _, sid := range a.nips.get(smap, ip) {
+		if smap.GetProxy(sid) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+/////////////
+// nodeIPs //
+/////////////
+
+func (n *nodeIPs) get(smap *smapX, ip string) []string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil
+	}
+	n.mu.Lock()
+	if n.m == nil || n.sver != smap.Version {
+		n.resolve(smap)
+	}
+	sids := n.m[parsed.String()]
+	n.mu.Unlock()
+	return sids
+}
+
+// is called under lock
+func (n *nodeIPs) resolve(smap *smapX) {
+	n.m = make(map[string][]string, len(smap.Pmap)+len(smap.Tmap))
+	n.sver = smap.Version
+	for _, nm := range []meta.NodeMap{smap.Pmap, smap.Tmap} {
+		for sid, si := range nm {
+			for _, hostname := range []string{si.PubNet.Hostname, si.ControlNet.Hostname, si.DataNet.Hostname} {
+				n.add(sid, hostname)
+			}
+		}
+	}
+}
+
+func (n *nodeIPs) add(sid, hostname string) {
+	if hostname == "" {
+		return
+	}
+	var ips []net.IP
+	if ip := net.ParseIP(hostname); ip != nil {
+		ips = []net.IP{ip}
+	} else {
+		var err error
+		if ips, err = net.LookupIP(hostname); err != nil {
+			nlog.Warningln("audit: failed to resolve", meta.Pname(sid), "address:", err)
+			return
+		}
+	}
+	for _, ip := range ips {
+		k := ip.String()
+		if !slices.Contains(n.m[k], sid) {
+			n.m[k] = append(n.m[k], sid)
+		}
+	}
+}
+
+// returns nil when not audited
+func (a *auditor) prep(conf *cmn.AuditConf, class string, r *http.Request) *apc.AuditEvent {
+	var (
+		bucket, object, provider string
+		items                    = strings.SplitN(strings.Trim(r.URL.Path, "/"), "/", 4)
+	)
+	switch {
+	case len(items) > 1 && items[0] == apc.Version: // /v1/<buckets|objects>/<bucket>[/<object>]
+		if len(items) > 2 {
+			bucket = items[2]
+			if len(items) > 3 {
+				object = items[3]
+			}
+		}
+		provider = r.URL.Query().Get(apc.QparamProvider)
+	default: // s3, "easy URL", ht://
+		rest := items[1:]
+		switch items[0] {
+		case apc.S3:
+		case apc.GSScheme, apc.AZScheme, apc.AISScheme:
+			provider = items[0]
+		default:
+			rest = items // root
+		}
+		rest = strings.SplitN(strings.Join(rest, "/"), "/", 2)
+		bucket = rest[0]
+		if len(rest) > 1 {
+			object = rest[1]
+		}
+		if class == "" {
+			class = apc.AuditBucket
+			if object != "" {
+				class = apc.AuditObject
+			}
+		}
+	}
+	if !conf.IsSet(class) {
+		return nil
+	}
+	if class == apc.AuditObject {
+		if conf.ObjSamplePct > 0 && conf.ObjSamplePct < 100 && rand.IntN(100) >= conf.ObjSamplePct {
+			return nil
+		}
+	} else if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return nil
+	}
+	if bucket != "" && provider != "" {
+		bucket = apc.ToScheme(apc.NormalizeProvider(provider)) + apc.BckProviderSeparator + bucket
+	}
+	ev := &apc.AuditEvent{
+		Time:   time.Now(),
+		Client: a.client(conf, r),
+		Node:   a.p.SID(),
+		Class:  class,
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Bucket: bucket,
+		Object: object,
+	}
+	if class != apc.AuditObject {
+		ev.Action = peekAction(r)
+	}
+	return ev
+}
+
+// read (and restore) the request body to get the action message, if any
+func peekAction(r *http.Request) string {
+	if r.ContentLength <= 0 || r.ContentLength > auditMaxPeek {
+		return ""
+	}
+	b, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return ""
+	}
+	var msg struct {
+		Action string `json:"action"`
+	}
+	if jsoniter.Unmarshal(b, &msg) != nil {
+		return ""
+	}
+	return msg.Action
+}
+
+func (a *auditor) add(ev *apc.AuditEvent, config *cmn.Config) {
+	b, err := jsoniter.Marshal(ev)
+	if err != nil {
+		nlog.Errorln("audit:", err)
+		return
+	}
+	b = append(b, '\n')
+
+	a.mu.Lock()
+	if len(a.ring) < auditRingSize {
+		a.ring = append(a.ring, ev)
+	} else {
+		a.ring[a.next] = ev
+	}
+	a.next = (a.next + 1) % auditRingSize
+	err = a.write(b, config)
+	a.mu.Unlock()
+
+	if err != nil {
+		nlog.Errorln("audit:", err)
+	}
+}
+
+// must be called under lock
+func (a *auditor) write(b []byte, config *cmn.Config) (err error) {
+	var (
+		conf  = &config.Audit
+		fqn   = filepath.Join(config.LogDir, auditLogName)
+		limit = int64(conf.MaxSize)
+	)
+	if limit == 0 {
+		limit = dfltAuditMaxSize
+	}
+	if a.fh != nil && a.size+int64(len(b)) > limit {
+		a.fh.Close()
+		a.fh = nil
+		a.rotate(fqn, cos.NonZero(conf.MaxFiles, dfltAuditMaxFiles))
+	}
+	if a.fh == nil {
+		if a.fh, err = os.OpenFile(fqn, os.O_CREATE|os.O_WRONLY|os.O_APPEND, cos.PermRWR); err != nil {
+			return err
+		}
+		finfo, err := a.fh.Stat()
+		if err != nil {
+			return err
+		}
+		a.size = finfo.Size()
+	}
+	n, err := a.fh.Write(b)
+	a.size += int64(n)
+	return err
+}
+
+// audit.log => audit.log.1 => ... => audit.log.<max-files>
+func (*auditor) rotate(fqn string, maxFiles int) {
+	os.Remove(fqn + "." + strconv.Itoa(maxFiles))
+	for i := maxFiles - 1; i > 0; i-- {
+		os.Rename(fqn+"."+strconv.Itoa(i), fqn+"."+strconv.Itoa(i+1))
+	}
+	if err := os.Rename(fqn, fqn+".1"); err != nil {
+		nlog.Errorln("audit:", err)
+	}
+}
+
+// most recent first
+func (a *auditor) recent(limit int) []*apc.AuditEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	l := len(a.ring)
+	out := make([]*apc.AuditEvent, 0, min(l, limit))
+	for i := 1; i <= l && len(out) < limit; i++ {
+		out = append(out, a.ring[(a.next-i+l)%l])
+	}
+	return out
+}
+
+/////////////////
+// auditWriter //
+/////////////////
+
+func (aw *auditWriter) WriteHeader(status int) {
+	if aw.status == 0 {
+		aw.status = status
+	}
+	aw.ResponseWriter.WriteHeader(status)
+}
+
+func (aw *auditWriter) Write(b []byte) (int, error) {
+	if aw.status == 0 {
+		aw.status = http.StatusOK
+	}
+	return aw.ResponseWriter.Write(b)
+}
+
+// (http.ResponseController)
+func (aw *auditWriter) Unwrap() http.ResponseWriter { return aw.ResponseWriter }
+
+// (http.Flusher, e.g. via httputil.ReverseProxy)
+func (aw *auditWriter) Flush() {
+	if aw.status == 0 {
+		aw.status = http.StatusOK
+	}
+	if f, ok := aw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+//
+// GET(what=audit)
+//
+
+func auditLimit(query url.Values) int {
+	if limit, err := strconv.Atoi(query.Get(apc.QparamAuditLimit)); err == nil && limit > 0 {
+		return limit
+	}
+	return dfltAuditLimit
+}
+
+// all gateways: merge the most recent events
+func (p *proxy) qcluAudit(w http.ResponseWriter, r *http.Request, what string, query url.Values) {
+	var (
+		limit = auditLimit(query)
+		out   = p.audit.recent(limit)
+		args  = allocBcArgs()
+	)
+	args.req = cmn.HreqArgs{Method: http.MethodGet, Path: apc.URLPathDae.S, Query: query}
+	args.timeout = cmn.GCO.Get().Client.Timeout.D()
+	args.to = core.Proxies
+	args.cresv = cresAudit{}
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			err := res.toErr()
+			freeBcastRes(results)
+			p.writeErr(w, r, fmt.Errorf("audit: %v", err))
+			return
+		}
+		out = append(out, *res.v.(*[]*apc.AuditEvent)...)
+	}
+	freeBcastRes(results)
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Time.After(out[j].Time) })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	p.writeJSON(w, r, out, what)
+}
@@ -0,0 +1,191 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	jsoniter "github.com/json-iterator/go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit log", func() {
+	var (
+		logDir  string
+		a       *auditor
+		created = func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusCreated) }
+		call    = func(h http.HandlerFunc, method, path string, body []byte) {
+			req := httptest.NewRequest(method, path, bytes.NewReader(body))
+			h(httptest.NewRecorder(), req)
+		}
+		setConf = func(conf cmn.AuditConf) {
+			config := cmn.GCO.BeginUpdate()
+			config.LogDir = logDir
+			config.Audit = conf
+			cmn.GCO.CommitUpdate(config)
+		}
+	)
+
+	BeforeEach(func() {
+		logDir = GinkgoT().TempDir()
+		p := &proxy{}
+		p.si = newSnode("p1", apc.Proxy, auditNetInfo("10.0.0.1"), meta.NetInfo{}, meta.NetInfo{})
+		smap := newSmap()
+		smap.addProxy(p.si)
+		smap.addProxy(newSnode("p2", apc.Proxy, auditNetInfo("10.0.0.2"), meta.NetInfo{}, meta.NetInfo{}))
+		smap.addTarget(newSnode("t1", apc.Target, auditNetInfo("10.0.0.3"), meta.NetInfo{}, meta.NetInfo{}))
+		smap.addTarget(newSnode("t2", apc.Target, auditNetInfo("localhost"), meta.NetInfo{}, meta.NetInfo{}))
+		smap.Primary = p.si
+		p.owner.smap = newSmapOwner(cmn.GCO.Get())
+		p.owner.smap.put(smap)
+		a = &auditor{}
+		a.init(p)
+	})
+	AfterEach(func() {
+		if a.fh != nil {
+			a.fh.Close()
+		}
+		setConf(cmn.AuditConf{})
+	})
+
+	It("should record modifying control plane operations", func() {
+		setConf(cmn.AuditConf{Enabled: true, Classes: "cluster, bucket"})
+		h := a.wrap(apc.AuditBucket, created)
+
+		call(h, http.MethodDelete, "/v1/buckets/abc?provider=aws", cos.MustMarshal(&apc.ActMsg{Action: apc.ActDestroyBck}))
+		call(h, http.MethodGet, "/v1/buckets/abc", nil)
+		call(a.wrap(apc.AuditObject, created), http.MethodPut, "/v1/objects/abc/obj", []byte("data"))
+
+		events := a.recent(10)
+		Expect(events).To(HaveLen(1))
+		ev := events[0]
+		Expect(ev.Class).To(Equal(apc.AuditBucket))
+		Expect(ev.Action).To(Equal(apc.ActDestroyBck))
+		Expect(ev.Bucket).To(Equal("s3://abc"))
+		Expect(ev.Status).To(Equal(http.StatusCreated))
+		Expect(ev.Node).To(Equal("p1"))
+
+		// JSON lines
+		fh, err := os.Open(filepath.Join(logDir, auditLogName))
+		Expect(err).NotTo(HaveOccurred())
+		defer fh.Close()
+		scanner := bufio.NewScanner(fh)
+		Expect(scanner.Scan()).To(BeTrue())
+		logged := &apc.AuditEvent{}
+		Expect(jsoniter.Unmarshal(scanner.Bytes(), logged)).NotTo(HaveOccurred())
+		Expect(logged.Action).To(Equal(apc.ActDestroyBck))
+		Expect(scanner.Scan()).To(BeFalse())
+	})
+
+	It("should record data plane operations and classify s3 requests", func() {
+		setConf(cmn.AuditConf{Enabled: true, Classes: apc.AuditObject})
+		h := a.wrap("", created)
+
+		call(h, http.MethodGet, "/s3/abc/dir/obj", nil)
+		call(h, http.MethodPut, "/s3/abc", nil) // bucket class: not audited
+		call(a.wrap(apc.AuditObject, created), http.MethodGet, "/v1/objects/abc/obj2", nil)
+
+		events := a.recent(10)
+		Expect(events).To(HaveLen(2))
+		Expect(events[0].Object).To(Equal("obj2")) // most recent first
+		Expect(events[1].Bucket).To(Equal("abc"))
+		Expect(events[1].Object).To(Equal("dir/obj"))
+		Expect(a.recent(1)).To(HaveLen(1))
+	})
+
+	It("should rotate", func() {
+		setConf(cmn.AuditConf{Enabled: true, Classes: apc.AuditCluster, MaxSize: 512, MaxFiles: 2})
+		h := a.wrap(apc.AuditCluster, created)
+		for range 20 {
+			call(h, http.MethodPut, "/v1/cluster/set-config", nil)
+		}
+		Expect(filepath.Join(logDir, auditLogName+".1")).To(BeAnExistingFile())
+		Expect(filepath.Join(logDir, auditLogName+".2")).To(BeAnExistingFile())
+		Expect(filepath.Join(logDir, auditLogName+".3")).NotTo(BeAnExistingFile())
+		Expect(a.recent(100)).To(HaveLen(20))
+	})
+
+	It("should skip intra-cluster requests but not the spoofed ones", func() {
+		setConf(cmn.AuditConf{Enabled: true, Classes: apc.AuditCluster})
+		h := a.wrap(apc.AuditCluster, created)
+		callFrom := func(remote, callerID string) {
+			req := httptest.NewRequest(http.MethodPut, "/v1/cluster/set-config", http.NoBody)
+			req.RemoteAddr = remote
+			req.Header.Set(apc.HdrCallerID, callerID)
+			h(httptest.NewRecorder(), req)
+		}
+		callFrom("10.0.0.3:51000", "t1")     // target, from its own address
+		callFrom("127.0.0.1:51000", "t2")    // ditto, resolved
+		callFrom("192.0.2.1:51000", "t1")    // spoofed caller ID
+		callFrom("10.0.0.3:51000", "nosuch") // not in the Smap
+
+		events := a.recent(10)
+		Expect(events).To(HaveLen(2))
+		Expect(events[0].Client).To(Equal("10.0.0.3:51000"))
+		Expect(events[1].Client).To(Equal("192.0.2.1:51000"))
+
+		// intra-cluster network: any address (NAT)
+		config := cmn.GCO.BeginUpdate()
+		config.HostNet.UseIntraControl = true
+		cmn.GCO.CommitUpdate(config)
+		defer func() {
+			config := cmn.GCO.BeginUpdate()
+			config.HostNet.UseIntraControl = false
+			cmn.GCO.CommitUpdate(config)
+		}()
+		req := httptest.NewRequest(http.MethodPut, "/v1/cluster/set-config", http.NoBody)
+		req.RemoteAddr = "192.0.2.1:51000"
+		req.Header.Set(apc.HdrCallerID, "t1")
+		h(httptest.NewRecorder(), req)
+		h(httptest.NewRecorder(), req.WithContext(context.WithValue(req.Context(), intraNetKey{}, true)))
+		Expect(a.recent(10)).To(HaveLen(3))
+	})
+
+	It("should flush", func() {
+		setConf(cmn.AuditConf{Enabled: true, Classes: apc.AuditCluster})
+		rec := httptest.NewRecorder()
+		h := a.wrap(apc.AuditCluster, func(w http.ResponseWriter, _ *http.Request) {
+			Expect(http.NewResponseController(w).Flush()).NotTo(HaveOccurred())
+		})
+		h(rec, httptest.NewRequest(http.MethodPut, "/v1/cluster/set-config", http.NoBody))
+		Expect(rec.Flushed).To(BeTrue())
+		Expect(a.recent(1)[0].Status).To(Equal(http.StatusOK))
+	})
+
+	It("should honor X-Forwarded-For from trusted proxies only", func() {
+		setConf(cmn.AuditConf{Enabled: true, Classes: apc.AuditCluster, TrustedProxies: "192.168.1.0/24, 172.16.0.7"})
+		h := a.wrap(apc.AuditCluster, created)
+		callVia := func(remote string, xff ...string) string {
+			req := httptest.NewRequest(http.MethodPut, "/v1/cluster/set-config", http.NoBody)
+			req.RemoteAddr = remote
+			for _, v := range xff {
+				req.Header.Add(hdrForwardedFor, v)
+			}
+			h(httptest.NewRecorder(), req)
+			return a.recent(1)[0].Client
+		}
+		Expect(callVia("192.0.2.1:51000", "1.2.3.4")).To(Equal("192.0.2.1:51000"))         // untrusted
+		Expect(callVia("10.0.0.2:51000", "1.2.3.4")).To(Equal("1.2.3.4"))                  // another gateway
+		Expect(callVia("192.168.1.5:51000", "6.6.6.6, 1.2.3.4")).To(Equal("1.2.3.4"))      // configured CIDR
+		Expect(callVia("172.16.0.7:51000", "1.2.3.4", "192.168.1.9")).To(Equal("1.2.3.4")) // chain of trusted
+		Expect(callVia("172.16.0.8:51000", "1.2.3.4")).To(Equal("172.16.0.8:51000"))       // not configured
+	})
+})
+
+func auditNetInfo(ip string) (ni meta.NetInfo) {
+	ni.Init("http", ip, "8080")
+	return
+}
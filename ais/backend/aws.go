@@ -8,11 +8,13 @@ package backend
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -300,8 +302,8 @@ func (*s3bp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (ecode
 		return 0, err
 	}
 	params := &s3.ListObjectsV2Input{Bucket: aws.String(cloudBck.Name)}
-	if msg.IsFlagSet(apc.LsNoRecursion) {
-		params.Delimiter = aws.String("/")
+	if delim := msg.Delim(); delim != "" {
+		params.Delimiter = aws.String(delim)
 	}
 	if prefix := msg.Prefix; prefix != "" {
 		params.Prefix = aws.String(prefix)
@@ -310,8 +312,11 @@ func (*s3bp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (ecode
 		params.ContinuationToken = aws.String(msg.ContinuationToken)
 	}
 
-	versioning = bck.Props != nil && bck.Props.Versioning.Enabled && msg.WantProp(apc.GetPropsVersion)
 	msg.PageSize = calcPageSize(msg.PageSize, bck.MaxPageSize())
+	if msg.IsFlagSet(apc.LsVersions) {
+		return listVersions(svc, cloudBck, msg, lst)
+	}
+	versioning = bck.Props != nil && bck.Props.Versioning.Enabled && msg.WantProp(apc.GetPropsVersion)
 	if versioning {
 		msg.PageSize = min(versionedPageSize, msg.PageSize)
 	}
@@ -399,6 +404,112 @@ func (*s3bp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (ecode
 	return 0, nil
 }
 
+// list all object versions and delete markers (apc.LsVersions)
+// - continuation token encodes the (next key marker, next version-id marker) pair
+// - versions of the same object are ordered from the most to the least recent
+func listVersions(svc *s3.Client, cloudBck *cmn.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (int, error) {
+	var (
+		h      = cmn.BackendHelpers.Amazon
+		params = &s3.ListObjectVersionsInput{Bucket: aws.String(cloudBck.Name), MaxKeys: aws.Int32(int32(msg.PageSize))}
+	)
+	if msg.Prefix != "" {
+		params.Prefix = aws.String(msg.Prefix)
+	}
+	if delim := msg.Delim(); delim != "" {
+		params.Delimiter = aws.String(delim)
+	}
+	if msg.ContinuationToken != "" {
+		key, vid, err := decVerToken(msg.ContinuationToken)
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+		params.KeyMarker = aws.String(key)
+		if vid != "" {
+			params.VersionIdMarker = aws.String(vid)
+		}
+	}
+	resp, err := svc.ListObjectVersions(context.Background(), params)
+	if err != nil {
+		return awsErrorToAISError(err, cloudBck, "")
+	}
+
+	type vent struct {
+		en    *cmn.LsoEnt
+		mtime time.Time
+	}
+	vents := make([]vent, 0, len(resp.Versions)+len(resp.DeleteMarkers))
+	for i := range resp.Versions {
+		vers := &resp.Versions[i]
+		en := &cmn.LsoEnt{Name: aws.ToString(vers.Key), Size: aws.ToInt64(vers.Size)}
+		en.Version, _ = h.EncodeVersion(vers.VersionId)
+		en.Checksum, _ = h.EncodeCksum(vers.ETag)
+		if !aws.ToBool(vers.IsLatest) {
+			en.Flags |= apc.EntryNotLatest
+		}
+		var (
+			etag, _ = h.EncodeETag(aws.ToString(vers.ETag))
+			mtime   = aws.ToTime(vers.LastModified)
+		)
+		en.Custom = cmn.CustomProps2S(cmn.ETag, etag, cmn.LastModified, fmtTime(mtime))
+		vents = append(vents, vent{en, mtime})
+	}
+	for i := range resp.DeleteMarkers {
+		marker := &resp.DeleteMarkers[i]
+		en := &cmn.LsoEnt{Name: aws.ToString(marker.Key), Flags: apc.EntryDelMarker}
+		en.Version, _ = h.EncodeVersion(marker.VersionId)
+		if !aws.ToBool(marker.IsLatest) {
+			en.Flags |= apc.EntryNotLatest
+		}
+		mtime := aws.ToTime(marker.LastModified)
+		en.Custom = cmn.CustomProps2S(cmn.LastModified, fmtTime(mtime))
+		vents = append(vents, vent{en, mtime})
+	}
+	sort.SliceStable(vents, func(i, j int) bool {
+		if vents[i].en.Name != vents[j].en.Name {
+			return vents[i].en.Name < vents[j].en.Name
+		}
+		return vents[i].mtime.After(vents[j].mtime)
+	})
+
+	lst.Entries = lst.Entries[:0]
+	for _, v := range vents {
+		lst.Entries = append(lst.Entries, v.en)
+	}
+	if !msg.IsFlagSet(apc.LsNoDirs) {
+		for _, dir := range resp.CommonPrefixes {
+			lst.Entries = append(lst.Entries, &cmn.LsoEnt{Name: *dir.Prefix, Flags: apc.EntryIsDir})
+		}
+	}
+	if aws.ToBool(resp.IsTruncated) {
+		lst.ContinuationToken = encVerToken(aws.ToString(resp.NextKeyMarker), aws.ToString(resp.NextVersionIdMarker))
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
+		nlog.Infoln("list_object_versions", cloudBck.Name, len(lst.Entries))
+	}
+	return 0, nil
+}
+
+// object names and version IDs may contain pretty much anything, thus base64
+func encVerToken(key, vid string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key)) + "." + base64.RawURLEncoding.EncodeToString([]byte(vid))
+}
+
+func decVerToken(token string) (key, vid string, _ error) {
+	k, v, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", "", fmt.Errorf("invalid list-object-versions continuation token %q", token)
+	}
+	bk, err := base64.RawURLEncoding.DecodeString(k)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid list-object-versions continuation token %q: %v", token, err)
+	}
+	bv, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid list-object-versions continuation token %q: %v", token, err)
+	}
+	return string(bk), string(bv), nil
+}
+
 //
 // LIST BUCKETS
 //
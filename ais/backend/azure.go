@@ -250,12 +250,16 @@ func (azbp *azbp) HeadBucket(ctx context.Context, bck *meta.Bck) (cos.StrKVs, in
 // TODO: research "hierarchical namespaces"
 // See also: aws.go, gcp.go
 func (azbp *azbp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (int, error) {
+	if msg.IsDelimAny() {
+		return http.StatusNotImplemented, cmn.NewErrUnsupp("list objects with arbitrary delimiter in", bck.Cname(""))
+	}
 	msg.PageSize = calcPageSize(msg.PageSize, bck.MaxPageSize())
 	var (
 		cloudBck = bck.RemoteBck()
 		cntURL   = azbp.u + "/" + cloudBck.Name
 		num      = int32(msg.PageSize)
 		opts     = container.ListBlobsFlatOptions{Prefix: apc.Ptr(msg.Prefix), MaxResults: &num}
+		versions = msg.IsFlagSet(apc.LsVersions)
 	)
 	client, err := container.NewClientWithSharedKeyCredential(cntURL, azbp.creds, nil)
 	if err != nil {
@@ -267,6 +271,10 @@ func (azbp *azbp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (
 	if msg.ContinuationToken != "" {
 		opts.Marker = apc.Ptr(msg.ContinuationToken)
 	}
+	if versions {
+		// all blob versions (Azure has no delete markers)
+		opts.Include = container.ListBlobsInclude{Versions: true}
+	}
 
 	pager := client.NewListBlobsFlatPager(&opts)
 	resp, err := pager.NextPage(context.Background())
@@ -275,7 +283,7 @@ func (azbp *azbp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (
 	}
 
 	var (
-		wantCustom = msg.WantProp(apc.GetPropsCustom)
+		wantCustom = msg.WantProp(apc.GetPropsCustom) || versions
 		custom     []string
 	)
 	if wantCustom {
@@ -288,14 +296,24 @@ func (azbp *azbp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (
 		// not expecting directories
 		debug.Assert(en.Name != "" && !cos.IsLastB(en.Name, '/'), en.Name)
 
-		if msg.IsFlagSet(apc.LsNameOnly) || msg.IsFlagSet(apc.LsNameSize) {
+		if versions {
+			// (no version ID when blob versioning is disabled)
+			if blob.VersionID != nil {
+				en.Version = *blob.VersionID
+				if blob.IsCurrentVersion == nil || !*blob.IsCurrentVersion {
+					en.Flags |= apc.EntryNotLatest
+				}
+			}
+		} else if msg.IsFlagSet(apc.LsNameOnly) || msg.IsFlagSet(apc.LsNameSize) {
 			lst.Entries = append(lst.Entries, &en)
 			continue
 		}
 
 		en.Checksum = azEncodeChecksum(blob.Properties.ContentMD5)
 		etag := azEncodeEtag(*blob.Properties.ETag)
-		en.Version = etag // (TODO a the top)
+		if !versions {
+			en.Version = etag // (TODO a the top)
+		}
 		if wantCustom {
 			custom = custom[:0]
 			custom = append(custom, cmn.ETag, etag)
@@ -163,13 +163,15 @@ func (*gsbp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (ecode
 	)
 	msg.PageSize = calcPageSize(msg.PageSize, bck.MaxPageSize())
 
-	if prefix := msg.Prefix; prefix != "" {
-		query = &storage.Query{Prefix: prefix}
-		if msg.IsFlagSet(apc.LsNoRecursion) {
-			query.Delimiter = "/"
+	if prefix, delim := msg.Prefix, msg.Delim(); prefix != "" || delim != "" {
+		query = &storage.Query{Prefix: prefix, Delimiter: delim}
+	}
+	if msg.IsFlagSet(apc.LsVersions) {
+		// all generations, including noncurrent ones (GCP has no delete markers)
+		if query == nil {
+			query = &storage.Query{}
 		}
-	} else if msg.IsFlagSet(apc.LsNoRecursion) {
-		query = &storage.Query{Delimiter: "/"}
+		query.Versions = true
 	}
 
 	var (
@@ -205,6 +207,14 @@ func (*gsbp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (ecode
 			}
 			en.Name = attrs.Prefix
 			en.Flags = apc.EntryIsDir
+		} else if msg.IsFlagSet(apc.LsVersions) {
+			en.Version, _ = h.EncodeVersion(attrs.Generation)
+			if !attrs.Deleted.IsZero() {
+				en.Flags |= apc.EntryNotLatest
+			}
+			en.Checksum, _ = h.EncodeCksum(attrs.MD5)
+			etag, _ := h.EncodeETag(attrs.Etag)
+			en.Custom = cmn.CustomProps2S(cmn.ETag, etag, cmn.LastModified, fmtTime(attrs.Updated))
 		} else if !msg.IsFlagSet(apc.LsNameOnly) && !msg.IsFlagSet(apc.LsNameSize) {
 			if v, ok := h.EncodeCksum(attrs.MD5); ok {
 				en.Checksum = v
@@ -174,7 +174,7 @@ func (bp *ocibp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (e
 		cloudBck          = bck.RemoteBck()
 		continuationToken string
 		customKVs         cos.StrKVs
-		delimiter         = string("/")
+		delimiter         = msg.Delim()
 		fields            string
 		h                 = cmn.BackendHelpers.OCI
 		limitAsInt        int
@@ -191,7 +191,7 @@ func (bp *ocibp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (e
 	if msg.Prefix != "" {
 		req.Prefix = &msg.Prefix
 	}
-	if msg.IsFlagSet(apc.LsNoRecursion) {
+	if delimiter != "" {
 		// [TODO] Need to handle case where I need to enumerate directories (while not "decending")
 		req.Delimiter = &delimiter
 	}
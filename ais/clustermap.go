@@ -360,6 +360,7 @@ func (m *smapX) clone() *smapX {
 	}
 	dst.Primary = dst.GetProxy(m.Primary.ID())
 	dst._sgl = nil
+	dst.ResetHrwMode()
 	return dst
 }
 
@@ -38,6 +38,7 @@ type dpq struct {
 	fltPresence string // QparamFltPresence
 	etlName     string // QparamETLName
 	binfo       string // bucket info, with or without requirement to summarize remote obj-s
+	user        string // AuthN user ID added by redirecting proxy (QparamUser)
 
 	skipVC        bool // QparamSkipVC (skip loading existing object's metadata)
 	isGFN         bool // QparamIsGFNRequest
@@ -151,6 +152,10 @@ func (dpq *dpq) parse(rawQuery string) (err error) {
 			dpq.silent = cos.IsParseBool(value)
 		case apc.QparamLatestVer:
 			dpq.latestVer = cos.IsParseBool(value)
+		case apc.QparamUser:
+			if dpq.user, err = url.QueryUnescape(value); err != nil {
+				return
+			}
 
 		default: // the key must be known or `_except`-ed
 			if strings.HasPrefix(key, s3.HeaderPrefix) {
@@ -166,6 +171,10 @@ func (dpq *dpq) parse(rawQuery string) (err error) {
 	if err == nil && iters >= maxNumQparams {
 		err = errors.New("exceeded max number of dpq iterations: " + strconv.Itoa(iters))
 	}
+	if err == nil && dpq.arch.regx != "" && dpq.arch.mmode == "" {
+		// (archregx without archmode)
+		err = dpq._arch(apc.QparamArchmode, archive.DfltMatchMode())
+	}
 	return err
 }
 
@@ -205,6 +214,16 @@ func (dpq *dpq) _arch(key, val string) (err error) {
 
 func (dpq *dpq) isArch() bool { return dpq.arch.path != "" || dpq.arch.mmode != "" }
 
+// archpath ending with '/' is an archive-internal directory: select all files under it
+// (and return them as a single TAR, same as archregx + archmode=prefix)
+func (dpq *dpq) archDir() {
+	if !cos.IsLastB(dpq.arch.path, '/') {
+		return
+	}
+	dpq.arch.regx, dpq.arch.mmode = dpq.arch.path, archive.DirMatchMode()
+	dpq.arch.path = ""
+}
+
 // err & log
 func (dpq *dpq) _archstr() string {
 	if dpq.arch.path != "" {
@@ -74,6 +74,7 @@ func (g *fsprungroup) _postAdd(action string, mi *fs.Mountpath) {
 	for _, disk := range mi.Disks {
 		tstats.RegDiskMetrics(g.t.si, disk)
 	}
+	g.t.reweigh()
 }
 
 //
@@ -197,6 +198,7 @@ func (g *fsprungroup) postDD(rmi *fs.Mountpath, action string, xres *xs.Resilver
 	}
 	fspathsConfigAddDel(rmi.Path, false /*add*/)
 	nlog.Infof("%s: %s %q %s done", g.t, rmi, action, xres)
+	g.t.reweigh()
 
 	// 3. the case of multiple overlapping detach _or_ disable operations
 	//    (ie., commit previously aborted xs.Resilver, if any)
@@ -173,9 +173,13 @@ type (
 		sndRcvBufSize int
 		sync.Mutex
 		lowLatencyToS bool
+		intra         bool // separately configured intra-cluster (control or data) network
 	}
 
 	nlogWriter struct{}
+
+	// context key: request received via intra-cluster network (see htrun.isIntraNet)
+	intraNetKey struct{}
 )
 
 // error types
@@ -402,6 +406,8 @@ type (
 
 	cresLso   struct{} // -> cmn.LsoRes
 	cresBsumm struct{} // -> cmn.AllBsummResults
+	cresAudit struct{} // -> []*apc.AuditEvent
+	cresUsage struct{} // -> []*apc.UsageStats
 )
 
 var (
@@ -416,6 +422,8 @@ var (
 	_ cresv = cresIC{}
 	_ cresv = cresBM{}
 	_ cresv = cresBsumm{}
+	_ cresv = cresAudit{}
+	_ cresv = cresUsage{}
 )
 
 func (res *callResult) read(body io.Reader, size int64) {
@@ -467,6 +475,12 @@ func (c cresBM) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jr
 func (cresBsumm) newV() any                              { return &cmn.AllBsummResults{} }
 func (c cresBsumm) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
 
+func (cresAudit) newV() any                              { return &[]*apc.AuditEvent{} }
+func (c cresAudit) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
+
+func (cresUsage) newV() any                              { return &[]*apc.UsageStats{} }
+func (c cresUsage) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
+
 ////////////////
 // nlogWriter //
 ////////////////
@@ -556,6 +570,12 @@ func (server *netServer) listen(addr string, logger *log.Logger, tlsConf *tls.Co
 		server.s.ConnState = server.connStateListener
 	}
 
+	if server.intra {
+		server.s.BaseContext = func(net.Listener) context.Context {
+			return context.WithValue(context.Background(), intraNetKey{}, true)
+		}
+	}
+
 	server.s.TLSConfig = tlsConf
 	server.Unlock()
 retry:
@@ -289,14 +289,14 @@ func (h *htrun) init(config *cmn.Config) {
 		// TODO: for now tracing is always disabled for intra-cluster traffic.
 		// Allow enabling through config.
 		muxers = newMuxers(false /*enableTracing*/)
-		g.netServ.control = &netServer{muxers: muxers, sndRcvBufSize: 0, lowLatencyToS: true}
+		g.netServ.control = &netServer{muxers: muxers, sndRcvBufSize: 0, lowLatencyToS: true, intra: true}
 	}
 	g.netServ.data = g.netServ.control // if not configured, intra-data net is intra-control
 	if config.HostNet.UseIntraData {
 		// TODO: for now tracing is always disabled for intra-data traffic.
 		// Allow enabling through config.
 		muxers = newMuxers(false /*enableTracing*/)
-		g.netServ.data = &netServer{muxers: muxers, sndRcvBufSize: tcpbuf}
+		g.netServ.data = &netServer{muxers: muxers, sndRcvBufSize: tcpbuf, intra: true}
 	}
 
 	h.owner.smap = newSmapOwner(config)
@@ -424,6 +424,7 @@ func (h *htrun) initSnode(config *cmn.Config) {
 		PubNet:     pubAddr,
 		ControlNet: ctrlAddr,
 		DataNet:    dataAddr,
+		FD:         config.FD,
 	}
 	if l := len(pubExtra); l > 0 {
 		h.si.PubExtra = make([]meta.NetInfo, l)
@@ -2239,6 +2240,17 @@ func (h *htrun) externalWD(w http.ResponseWriter, r *http.Request) (responded bo
 // intra-cluster request validations and helpers
 //
 
+// whether the request was received via intra-cluster network;
+// with no intra-cluster networks configured there's only one (public) network to trust -
+// same as all intra-cluster handlers, e.g. metasync
+func isIntraNet(r *http.Request) bool {
+	if config := cmn.GCO.Get(); !config.HostNet.UseIntraControl && !config.HostNet.UseIntraData {
+		return true
+	}
+	v, ok := r.Context().Value(intraNetKey{}).(bool)
+	return ok && v
+}
+
 func (h *htrun) checkIntraCall(hdr http.Header, fromPrimary bool) (err error) {
 	debug.Assert(hdr != nil)
 	var (
@@ -46,7 +46,8 @@ type (
 		cluUptime(int64) time.Duration
 	}
 	talive struct {
-		t *target
+		t         *target
+		reweighed atomic.Bool // HRW weight changed - use slow path to update primary (see target.reweigh)
 		keepalive
 	}
 	palive struct {
@@ -142,12 +143,17 @@ func (tkr *talive) sendKalive(smap *smapX, timeout time.Duration, _ int64, fast
 		interrupted, restarted := tkr.t.interruptedRestarted()
 		fast = !interrupted && !restarted
 	}
-	if fast {
+	if fast && !tkr.reweighed.Load() {
 		debug.Assert(ec.ECM != nil)
 		pid, _, err = tkr.t.fastKalive(smap, timeout, ec.ECM.IsActive())
 		return pid, 0, err
 	}
-	return tkr.t.slowKalive(smap, tkr.t, timeout)
+	reweighed := tkr.reweighed.Swap(false)
+	pid, status, err = tkr.t.slowKalive(smap, tkr.t, timeout)
+	if err != nil && reweighed {
+		tkr.reweighed.Store(true)
+	}
+	return pid, status, err
 }
 
 func (tkr *talive) do(config *cmn.Config) (stopped bool) {
@@ -62,6 +62,8 @@ type (
 		rproxy     reverseProxy
 		notifs     notifs
 		lstca      lstca
+		audit      auditor
+		ratelim    rateLimiters
 		reg        struct {
 			pool nodeRegPool
 			mu   sync.RWMutex
@@ -201,6 +203,8 @@ func (p *proxy) Run() error {
 	p.notifs.init(p)
 	p.ic.init(p)
 	p.qm.init()
+	p.audit.init(p)
+	p.ratelim.init(p)
 
 	//
 	// REST API: register proxy handlers and start listening
@@ -209,16 +213,18 @@ func (p *proxy) Run() error {
 		{r: apc.Reverse, h: p.reverseHandler, net: accessNetPublic},
 
 		// pubnet handlers: cluster must be started
-		{r: apc.Buckets, h: p.bucketHandler, net: accessNetPublic},
-		{r: apc.Objects, h: p.objectHandler, net: accessNetPublic},
-		{r: apc.Download, h: p.dloadHandler, net: accessNetPublic},
-		{r: apc.ETL, h: p.etlHandler, net: accessNetPublic},
-		{r: apc.Sort, h: p.dsortHandler, net: accessNetPublic},
+		{r: apc.Buckets, h: p.audit.wrap(apc.AuditBucket, p.bucketHandler), net: accessNetPublic},
+		{r: apc.Objects, h: p.audit.wrap(apc.AuditObject, p.objectHandler), net: accessNetPublic},
+		{r: apc.Batch, h: p.audit.wrap(apc.AuditObject, p.batchHandler), net: accessNetPublic},
+		{r: apc.Events, h: p.audit.wrap(apc.AuditObject, p.eventsHandler), net: accessNetPublic},
+		{r: apc.Download, h: p.audit.wrap(apc.AuditBucket, p.dloadHandler), net: accessNetPublic},
+		{r: apc.ETL, h: p.audit.wrap(apc.AuditBucket, p.etlHandler), net: accessNetPublic},
+		{r: apc.Sort, h: p.audit.wrap(apc.AuditBucket, p.dsortHandler), net: accessNetPublic},
 
 		{r: apc.IC, h: p.ic.handler, net: accessNetIntraControl},
-		{r: apc.Daemon, h: p.daemonHandler, net: accessNetPublicControl},
-		{r: apc.Cluster, h: p.clusterHandler, net: accessNetPublicControl},
-		{r: apc.Tokens, h: p.tokenHandler, net: accessNetPublic},
+		{r: apc.Daemon, h: p.audit.wrap(apc.AuditCluster, p.daemonHandler), net: accessNetPublicControl},
+		{r: apc.Cluster, h: p.audit.wrap(apc.AuditCluster, p.clusterHandler), net: accessNetPublicControl},
+		{r: apc.Tokens, h: p.tokenHandler, net: accessNetPublicControl},
 
 		{r: apc.Metasync, h: p.metasyncHandler, net: accessNetIntraControl},
 		{r: apc.Health, h: p.healthHandler, net: accessNetPublicControl},
@@ -226,17 +232,18 @@ func (p *proxy) Run() error {
 
 		{r: apc.Notifs, h: p.notifs.handler, net: accessNetIntraControl},
 		{r: apc.EC, h: p.ecHandler, net: accessNetIntraControl},
+		{r: apc.RateLim, h: p.ratelimHandler, net: accessNetIntraControl},
 
 		// S3 compatibility
-		{r: "/" + apc.S3, h: p.s3Handler, net: accessNetPublic},
+		{r: "/" + apc.S3, h: p.audit.wrap("", p.s3Handler), net: accessNetPublic},
 
 		// "easy URL"
-		{r: "/" + apc.GSScheme, h: p.easyURLHandler, net: accessNetPublic},
-		{r: "/" + apc.AZScheme, h: p.easyURLHandler, net: accessNetPublic},
-		{r: "/" + apc.AISScheme, h: p.easyURLHandler, net: accessNetPublic},
+		{r: "/" + apc.GSScheme, h: p.audit.wrap("", p.easyURLHandler), net: accessNetPublic},
+		{r: "/" + apc.AZScheme, h: p.audit.wrap("", p.easyURLHandler), net: accessNetPublic},
+		{r: "/" + apc.AISScheme, h: p.audit.wrap("", p.easyURLHandler), net: accessNetPublic},
 
 		// ht:// _or_ S3 compatibility, depending on feature flag
-		{r: "/", h: p.rootHandler, net: accessNetPublic},
+		{r: "/", h: p.audit.wrap("", p.rootHandler), net: accessNetPublic},
 	}
 	p.regNetHandlers(networkHandlers)
 
@@ -638,6 +645,22 @@ func (p *proxy) httpbckget(w http.ResponseWriter, r *http.Request, dpq *dpq) {
 		return
 	}
 
+	// (I.b) search objects by custom metadata
+	if msg.Action == apc.ActSearchMD {
+		if !qbck.IsBucket() {
+			p.writeErrf(w, r, "%s: %q requires bucket name", p, msg.Action)
+			return
+		}
+		bck := (*meta.Bck)(qbck)
+		bckArgs := bctx{p: p, w: w, r: r, msg: msg, perms: apc.AceObjLIST, bck: bck, dpq: dpq}
+		bckArgs.createAIS = false
+		if _, err := bckArgs.initAndTry(); err != nil {
+			return
+		}
+		p.searchMD(w, r, bck, msg)
+		return
+	}
+
 	// (II) invalid action
 	if msg.Action != apc.ActList {
 		p.writeErrAct(w, r, msg.Action)
@@ -740,6 +763,9 @@ func (p *proxy) httpobjget(w http.ResponseWriter, r *http.Request, origURLBck ..
 		p.writeErr(w, r, err)
 		return
 	}
+	if p.rateLimit(w, r, bck, false /*s3*/) {
+		return
+	}
 
 	started := time.Now()
 
@@ -823,6 +849,9 @@ func (p *proxy) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiRe
 		p.writeErr(w, r, err)
 		return
 	}
+	if p.rateLimit(w, r, bck, false /*s3*/) {
+		return
+	}
 	if nodeID == "" {
 		tsi, netPub, err = smap.HrwMultiHome(bck.MakeUname(objName))
 		if err != nil {
@@ -874,6 +903,9 @@ func (p *proxy) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		p.writeErr(w, r, err)
 		return
 	}
+	if p.rateLimit(w, r, bck, false /*s3*/) {
+		return
+	}
 	smap := p.owner.smap.get()
 	tsi, err := smap.HrwName2T(bck.MakeUname(objName))
 	if err != nil {
@@ -973,6 +1005,7 @@ func (p *proxy) httpbckdelete(w http.ResponseWriter, r *http.Request, apireq *ap
 				return
 			}
 		}
+		p.usageUser(r.Header, apireq.query)
 		xid, err := p.listrange(r.Method, bck.Name, msg, apireq.query)
 		if err != nil {
 			p.writeErr(w, r, err)
@@ -1350,6 +1383,10 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 			p.writeErrf(w, r, errPrependSync, tcbmsg.Prepend)
 			return
 		}
+		if err := tcbmsg.CopyBckMsg.Validate(); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
 		bckTo, err = newBckFromQuname(query, true /*required*/)
 		if err != nil {
 			p.writeErr(w, r, err)
@@ -1424,6 +1461,10 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 			p.writeErrf(w, r, errPrependSync, tcomsg.Prepend)
 			return
 		}
+		if err := tcomsg.CopyBckMsg.Validate(); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
 		tcomsg.Prefix = cos.TrimPrefix(tcomsg.Prefix)
 		bckTo = meta.CloneBck(&tcomsg.ToBck)
 
@@ -1714,6 +1755,20 @@ func _checkVerChanged(bck *meta.Bck, lsmsg *apc.LsoMsg) error {
 	return nil
 }
 
+// listing remote object versions is always pass-through (wantOnlyRemote)
+func _checkVersions(bck *meta.Bck, lsmsg *apc.LsoMsg) error {
+	if !bck.IsRemote() || lsmsg.IsFlagSet(apc.LsObjCached) {
+		return nil // in-cluster objects: latest versions only
+	}
+	switch bck.RemoteBck().Provider {
+	case apc.AWS, apc.GCP, apc.Azure:
+		lsmsg.SetFlag(apc.LsWantOnlyRemoteProps)
+		return nil
+	default:
+		return cmn.NewErrUnsupp("list object versions in", bck.Cname(""))
+	}
+}
+
 // one page; common code (native, s3 api)
 func (p *proxy) lsPage(bck *meta.Bck, amsg *apc.ActMsg, lsmsg *apc.LsoMsg, hdr http.Header, smap *smapX) (*cmn.LsoRes, error) {
 	var (
@@ -1725,6 +1780,19 @@ func (p *proxy) lsPage(bck *meta.Bck, amsg *apc.ActMsg, lsmsg *apc.LsoMsg, hdr h
 		listRemote     bool
 		wantOnlyRemote bool
 	)
+	if err := lsmsg.InitDelim(); err != nil {
+		return nil, err
+	}
+	if lsmsg.ArchRegx != "" {
+		if _, err := archive.NewMatcher(lsmsg.ArchRegx, lsmsg.ArchMode); err != nil {
+			return nil, err
+		}
+	}
+	if lsmsg.IsFlagSet(apc.LsVersions) {
+		if err := _checkVersions(bck, lsmsg); err != nil {
+			return nil, err
+		}
+	}
 	if lsmsg.UUID == "" {
 		lsmsg.UUID = cos.GenUUID()
 		newls = true
@@ -1905,11 +1973,80 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *apiR
 		}
 		objName := msg.Name
 		p.redirectAction(w, r, bck, objName, msg)
+	case apc.ActCopyRange:
+		if err := p.checkAccess(w, r, bck, apc.AcePUT); err != nil {
+			return
+		}
+		if err := p.copyRangeBck(w, r, bck, msg); err != nil {
+			return
+		}
+		p.redirectAction(w, r, bck, apireq.items[1], msg)
+	case apc.ActComposeObject:
+		if err := p.checkAccess(w, r, bck, apc.AcePUT); err != nil {
+			return
+		}
+		if err := p.composeBcks(w, r, bck, msg); err != nil {
+			return
+		}
+		p.redirectAction(w, r, bck, apireq.items[1], msg)
 	default:
 		p.writeErrAct(w, r, msg.Action)
 	}
 }
 
+// validate copy-range message and check access to the source bucket
+func (p *proxy) copyRangeBck(w http.ResponseWriter, r *http.Request, bck *meta.Bck, msg *apc.ActMsg) error {
+	crMsg := &cmn.CopyRangeMsg{}
+	if err := cos.MorphMarshal(msg.Value, crMsg); err != nil {
+		err = fmt.Errorf(cmn.FmtErrMorphUnmarshal, p, msg.Action, msg.Value, err)
+		p.writeErr(w, r, err)
+		return err
+	}
+	err := crMsg.Init(bck.Bucket())
+	if err == nil {
+		err = checkPatch(bck)
+	}
+	if err != nil {
+		p.writeErr(w, r, err)
+		return err
+	}
+	bckArgs := bctx{p: p, w: w, r: r, msg: msg, bck: meta.CloneBck(&crMsg.Source.Bck), perms: apc.AceGET}
+	_, err = bckArgs.initAndTry()
+	return err
+}
+
+// validate compose message and check access to all source buckets
+func (p *proxy) composeBcks(w http.ResponseWriter, r *http.Request, bck *meta.Bck, msg *apc.ActMsg) error {
+	composeMsg := &cmn.ComposeMsg{}
+	if err := cos.MorphMarshal(msg.Value, composeMsg); err != nil {
+		err = fmt.Errorf(cmn.FmtErrMorphUnmarshal, p, msg.Action, msg.Value, err)
+		p.writeErr(w, r, err)
+		return err
+	}
+	if err := composeMsg.Init(bck.Bucket()); err != nil {
+		p.writeErr(w, r, err)
+		return err
+	}
+	perms := apc.AceGET
+	if composeMsg.DeleteSources {
+		perms |= apc.AceObjDELETE
+	}
+	bcks := make(cos.StrSet, 2)
+	for i := range composeMsg.Sources {
+		e := &composeMsg.Sources[i]
+		cname := e.Bck.Cname("")
+		if bcks.Contains(cname) {
+			continue
+		}
+		bcks.Set(cname)
+		bckArgs := bctx{p: p, w: w, r: r, msg: msg, bck: meta.CloneBck(&e.Bck), perms: perms}
+		if _, err := bckArgs.initAndTry(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func _checkObjMv(bck *meta.Bck, msg *apc.ActMsg, apireq *apiRequest) error {
 	if bck.IsRemote() {
 		err := fmt.Errorf("invalid action %q: not supported for remote buckets (%s)", msg.Action, bck)
@@ -2120,6 +2257,21 @@ func (p *proxy) httpbckpatch(w http.ResponseWriter, r *http.Request, apireq *api
 			return
 		}
 	}
+	if nprops.Replication.Enabled {
+		// replication destination must exist (or, if remote, must be accessible) and be writable
+		dst, _ := nprops.Replication.DestBck() // (validated)
+		dstBck := meta.CloneBck(&dst)
+		args := bctx{p: p, w: w, r: r, bck: dstBck, msg: msg, perms: apc.AcePUT | apc.AceObjDELETE,
+			dpq: apireq.dpq, query: apireq.query}
+		args.createAIS = false
+		if dstBck, err = args.initAndTry(); err != nil {
+			return
+		}
+		if err = p.initReplProp(bck, dstBck, nprops); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+	}
 	if xid, err = p.setBprops(msg, bck, nprops); err != nil {
 		p.writeErr(w, r, err)
 		return
@@ -2146,6 +2298,9 @@ func (p *proxy) httpobjhead(w http.ResponseWriter, r *http.Request, origURLBck .
 	if err != nil {
 		return
 	}
+	if p.rateLimit(w, r, bck, false /*s3*/) {
+		return
+	}
 
 	// TODO: control plane multihoming: return LRU data plane interface - here and elsewhere (bcast)
 
@@ -2165,12 +2320,16 @@ func (p *proxy) httpobjhead(w http.ResponseWriter, r *http.Request, origURLBck .
 // PATCH /v1/objects/bucket-name/object-name
 func (p *proxy) httpobjpatch(w http.ResponseWriter, r *http.Request) {
 	started := time.Now()
+	patch := r.URL.Query().Has(apc.QparamPatchOffset) // (write at offset vs. set custom metadata)
 	bckArgs := allocBctx()
 	{
 		bckArgs.p = p
 		bckArgs.w = w
 		bckArgs.r = r
 		bckArgs.perms = apc.AceObjUpdate
+		if patch {
+			bckArgs.perms = apc.AcePUT
+		}
 		bckArgs.createAIS = false
 	}
 	bck, objName, err := p._parseReqTry(w, r, bckArgs)
@@ -2181,6 +2340,12 @@ func (p *proxy) httpobjpatch(w http.ResponseWriter, r *http.Request) {
 		p.writeErr(w, r, err)
 		return
 	}
+	if patch {
+		if err := checkPatch(bck); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+	}
 	smap := p.owner.smap.get()
 	si, err := smap.HrwName2T(bck.MakeUname(objName))
 	if err != nil {
@@ -2190,7 +2355,11 @@ func (p *proxy) httpobjpatch(w http.ResponseWriter, r *http.Request) {
 	if cmn.Rom.FastV(5, cos.SmoduleAIS) {
 		nlog.Infoln(r.Method, bck.Cname(objName), "=>", si.StringEx())
 	}
-	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraControl)
+	netIntra := cmn.NetIntraControl
+	if patch {
+		netIntra = cmn.NetIntraData
+	}
+	redirectURL := p.redirectURL(r, si, started, netIntra)
 	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
 }
 
@@ -2280,14 +2449,22 @@ func (p *proxy) redirectURL(r *http.Request, si *meta.Snode, ts time.Time, netIn
 		}
 	}
 	redirect = nodeURL + r.URL.Path + "?"
-	if r.URL.RawQuery != "" {
-		redirect += r.URL.RawQuery + "&"
+	if rawQuery := r.URL.RawQuery; rawQuery != "" {
+		if strings.Contains(rawQuery, apc.QparamUser+"=") { // (internal use only)
+			q := r.URL.Query()
+			q.Del(apc.QparamUser)
+			rawQuery = q.Encode()
+		}
+		if rawQuery != "" {
+			redirect += rawQuery + "&"
+		}
 	}
 
 	query := url.Values{
 		apc.QparamProxyID:  []string{p.SID()},
 		apc.QparamUnixTime: []string{cos.UnixNano2S(ts.UnixNano())},
 	}
+	p.usageUser(r.Header, query)
 	redirect += query.Encode()
 	return
 }
@@ -2391,10 +2568,11 @@ end:
 		allEntries.ContinuationToken = entries[len(entries)-1].Name
 	}
 
-	// when recursion is disabled (i.e., lsmsg.IsFlagSet(apc.LsNoRecursion))
-	// the (`cmn.LsoRes`) result _may_ include duplicated names of the virtual subdirectories
-	// - that's why:
-	if lsmsg.IsFlagSet(apc.LsNoRecursion) {
+	// when recursion is disabled (i.e., lsmsg.IsFlagSet(apc.LsNoRecursion)) or when listing
+	// with arbitrary delimiter, the (`cmn.LsoRes`) result _may_ include duplicated names
+	// of the virtual subdirectories (common prefixes) - that's why:
+	if lsmsg.IsFlagSet(apc.LsNoRecursion) || lsmsg.IsDelimAny() {
+		cmn.SortLso(allEntries.Entries) // directories-first (the page itself is cut in lexicographic order - see above)
 		allEntries.Entries = cmn.DedupLso(allEntries.Entries, len(entries), false /*no-dirs*/)
 	}
 
@@ -2414,6 +2592,12 @@ func (p *proxy) lsObjsR(bck *meta.Bck, lsmsg *apc.LsoMsg, hdr http.Header, smap
 		if !bck.IsRemoteS3() {
 			return nil, cmn.NewErrUnsupp("list (via bucket inventory) non-S3 bucket", bck.Cname(""))
 		}
+		if lsmsg.IsDelimAny() {
+			return nil, cmn.NewErrUnsupp("list (via bucket inventory) with arbitrary delimiter", bck.Cname(""))
+		}
+		if lsmsg.IsFlagSet(apc.LsVersions) {
+			return nil, cmn.NewErrUnsupp("list (via bucket inventory) object versions", bck.Cname(""))
+		}
 		if lsmsg.ContinuationToken == "" /*first page*/ {
 			// override _lsofc selection (see above)
 			var (
@@ -2694,6 +2878,9 @@ func (p *proxy) httpdaeget(w http.ResponseWriter, r *http.Request) {
 		apc.WhatNodeStatsAndStatusV322:
 		p.htrun.httpdaeget(w, r, query, nil /*htext*/)
 
+	case apc.WhatAudit:
+		p.writeJSON(w, r, p.audit.recent(auditLimit(query)), what)
+
 	case apc.WhatNodeStatsAndStatus:
 		ds := p.statsAndStatus()
 		daeStats := p.statsT.GetStats()
@@ -2961,6 +3148,10 @@ func (p *proxy) dsortHandler(w http.ResponseWriter, r *http.Request) {
 
 	switch r.Method {
 	case http.MethodPost:
+		if len(apiItems) == 1 && apiItems[0] == apc.Resume {
+			dsort.PresumeHandler(w, r)
+			return
+		}
 		// - validate request, check input_bck and output_bck
 		// - start dsort
 		body, err := cos.ReadAllN(r.Body, r.ContentLength)
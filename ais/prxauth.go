@@ -8,19 +8,24 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/ais/s3"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/authn"
 	"github.com/NVIDIA/aistore/api/env"
 	"github.com/NVIDIA/aistore/cmd/authn/tok"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
-	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/jsp"
 	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/memsys"
+	jsoniter "github.com/json-iterator/go"
 )
 
 type (
@@ -32,25 +37,41 @@ type (
 		// list of invalid tokens(revoked or of deleted users)
 		// Authn sends these tokens to primary for broadcasting
 		revokedTokens map[string]bool
-		version       int64
+		// S3 access keys (AuthN-issued), by access key ID
+		accessKeys map[string]*authn.AccessKey
+		version    int64
 		// signing key secret
 		secret string
+		// verifies HS256 tokens with the secret, RS256 and ES256 - with AuthN public keys
+		verifier tok.Verifier
 		// lock
 		sync.Mutex
 	}
+	// fetches AuthN public keys (JWKS) from `config.Auth.JWKSURL`
+	// or, if that fails, loads them from `config.Auth.JWKSFile`
+	// (to be cached by tok.JWKSCache)
+	jwksFetcher struct {
+		client *http.Client
+		url    string // client's URL (to recreate the client when URL changes)
+	}
 )
 
+const jwksTimeout = 5 * time.Second
+
 /////////////////
 // authManager //
 /////////////////
 
 func newAuthManager(config *cmn.Config) *authManager {
-	return &authManager{
+	a := &authManager{
 		tkList:        make(tkList),
 		revokedTokens: make(map[string]bool), // TODO: preallocate
+		accessKeys:    make(map[string]*authn.AccessKey),
 		version:       1,
 		secret:        cos.Right(config.Auth.Secret, os.Getenv(env.AuthN.SecretKey)), // environment override
 	}
+	a.verifier = tok.Verifier{Secret: a.secret, Keys: newJWKSCache()}
+	return a
 }
 
 // Add tokens to the list of invalid ones and clean up the list from expired tokens.
@@ -83,9 +104,8 @@ func (a *authManager) updateRevokedList(newRevoked *tokenList) (allRevoked *toke
 	now := time.Now()
 
 	for token := range a.revokedTokens {
-		tk, err := tok.DecryptToken(token, a.secret)
-		debug.AssertNoErr(err)
-		if tk.Expires.Before(now) {
+		tk, err := a.verifier.DecryptToken(token)
+		if err != nil || tk.Expires.Before(now) { // (err: signed with a since-removed key)
 			delete(a.revokedTokens, token)
 		} else {
 			allRevoked.Tokens = append(allRevoked.Tokens, token)
@@ -112,6 +132,42 @@ func (a *authManager) revokedTokenList() (allRevoked *tokenList) {
 	return
 }
 
+// Add S3 access keys and clean up expired and revoked ones; returns all (valid) keys.
+// Unlike revoked tokens, access keys (and their secrets) are never metasync-ed -
+// proxies only (see bcastAccessKeys).
+func (a *authManager) addAccessKeys(keys []*authn.AccessKey) (all []*authn.AccessKey) {
+	a.Lock()
+	defer a.Unlock()
+	for _, key := range keys {
+		a.accessKeys[key.ID] = key
+	}
+	now := time.Now()
+	all = make([]*authn.AccessKey, 0, len(a.accessKeys))
+	for id, key := range a.accessKeys {
+		if a.revokedTokens[key.Token] || key.Expires.Before(now) {
+			delete(a.accessKeys, id)
+		} else {
+			all = append(all, key)
+		}
+	}
+	return all
+}
+
+// returns nil if the access key is unknown, revoked, or expired
+func (a *authManager) accessKey(id string) *authn.AccessKey {
+	a.Lock()
+	defer a.Unlock()
+	key, ok := a.accessKeys[id]
+	if !ok {
+		return nil
+	}
+	if a.revokedTokens[key.Token] || key.Expires.Before(time.Now()) {
+		delete(a.accessKeys, id)
+		return nil
+	}
+	return key
+}
+
 // Checks if a token is valid:
 //   - must not be revoked one
 //   - must not be expired
@@ -135,7 +191,7 @@ func (a *authManager) validateAddRm(token string, now time.Time) (*tok.Token, er
 	tk, ok := a.tkList[token]
 	if !ok || tk == nil {
 		var err error
-		if tk, err = tok.DecryptToken(token, a.secret); err != nil {
+		if tk, err = a.verifier.DecryptToken(token); err != nil {
 			nlog.Errorln(err)
 			return nil, tok.ErrInvalidToken
 		}
@@ -148,6 +204,74 @@ func (a *authManager) validateAddRm(token string, now time.Time) (*tok.Token, er
 	return tk, nil
 }
 
+/////////////////
+// jwksFetcher //
+/////////////////
+
+func newJWKSCache() *tok.JWKSCache {
+	f := &jwksFetcher{}
+	return &tok.JWKSCache{Fetch: f.fetch}
+}
+
+func (f *jwksFetcher) fetch() (*tok.JWKS, error) {
+	var (
+		jwks tok.JWKS
+		conf = &cmn.GCO.Get().Auth
+		err  error
+	)
+	if conf.JWKSURL == "" && conf.JWKSFile == "" {
+		return nil, fmt.Errorf("%w: neither auth.jwks_url nor auth.jwks_file is configured", tok.ErrUnknownKey)
+	}
+	if conf.JWKSURL != "" {
+		if err = f.get(conf.JWKSURL, &jwks); err == nil {
+			return &jwks, nil
+		}
+		nlog.Errorln("failed to fetch AuthN public keys:", err)
+	}
+	if conf.JWKSFile != "" {
+		if _, err = jsp.Load(conf.JWKSFile, &jwks, jsp.Plain()); err == nil {
+			return &jwks, nil
+		}
+		nlog.Errorf("failed to load AuthN public keys from %q: %v", conf.JWKSFile, err)
+	}
+	return nil, err
+}
+
+func (f *jwksFetcher) get(u string, jwks *tok.JWKS) error {
+	if f.client == nil || f.url != u {
+		cargs := cmn.TransportArgs{Timeout: jwksTimeout}
+		if cos.IsHTTPS(u) {
+			config := cmn.GCO.Get()
+			tlsConf, err := cmn.NewTLS(cmn.TLSArgs{
+				ClientCA:   config.Net.HTTP.ClientCA,
+				SkipVerify: config.Net.HTTP.SkipVerifyCrt,
+			}, false /*intra*/)
+			if err != nil {
+				return err
+			}
+			transport := cmn.NewTransport(cargs)
+			transport.TLSClientConfig = tlsConf
+			f.client = &http.Client{Transport: transport, Timeout: cargs.Timeout}
+		} else {
+			f.client = cmn.NewClient(cargs)
+		}
+		f.url = u
+	}
+	req, err := http.NewRequest(http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return err
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+	return jsoniter.NewDecoder(resp.Body).Decode(jwks)
+}
+
 ///////////////
 // tokenList //
 ///////////////
@@ -172,10 +296,10 @@ func (p *proxy) tokenHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
 		p.validateSecret(w, r)
-	case http.MethodDelete:
-		p.delToken(w, r)
+	case http.MethodDelete, http.MethodPut:
+		p.updateTokenList(w, r)
 	default:
-		cmn.WriteErr405(w, r, http.MethodDelete)
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodPut)
 	}
 }
 
@@ -196,17 +320,40 @@ func (p *proxy) validateSecret(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (p *proxy) delToken(w http.ResponseWriter, r *http.Request) {
+// DELETE: revoke tokens; PUT: add S3 access keys
+// - from AuthN: must carry AuthN-issued admin token (see cmd/authn/aisreq.go);
+// - from primary (access keys): see checkKeysFromPrimary
+func (p *proxy) updateTokenList(w http.ResponseWriter, r *http.Request) {
 	if _, err := p.parseURL(w, r, apc.URLPathTokens.L, 0, false); err != nil {
 		return
 	}
-	if p.forwardCP(w, r, nil, "revoke token") {
+	tokenList := &tokenList{}
+	if r.Method == http.MethodPut && r.Header.Get(apc.HdrCallerID) != "" {
+		if err := p.checkKeysFromPrimary(r); err != nil {
+			p.writeErr(w, r, err, http.StatusForbidden)
+			return
+		}
+		if err := cmn.ReadJSON(w, r, tokenList); err != nil {
+			return
+		}
+		p.authn.addAccessKeys(tokenList.AccessKeys)
+		return
+	}
+	if err := p.checkAuthnAdmin(r.Header); err != nil {
+		p.writeErr(w, r, err, http.StatusUnauthorized)
+		return
+	}
+	if p.forwardCP(w, r, nil, "update token list") {
 		return
 	}
-	tokenList := &tokenList{}
 	if err := cmn.ReadJSON(w, r, tokenList); err != nil {
 		return
 	}
+	if r.Method == http.MethodPut {
+		all := p.authn.addAccessKeys(tokenList.AccessKeys)
+		p.bcastAccessKeys(all, nil)
+		return
+	}
 	allRevoked := p.authn.updateRevokedList(tokenList)
 	if allRevoked != nil && p.owner.smap.get().isPrimary(p.si) {
 		msg := p.newAmsgStr(apc.ActNewPrimary, nil)
@@ -214,6 +361,103 @@ func (p *proxy) delToken(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// the request must carry a valid (not expired, not revoked) admin token
+func (p *proxy) checkAuthnAdmin(hdr http.Header) error {
+	tk, err := p.validateToken(hdr)
+	if err != nil {
+		return err
+	}
+	if !tk.IsAdmin {
+		return fmt.Errorf("%s: %q is not an admin", p, tk.UserID)
+	}
+	return nil
+}
+
+// Caller ID and name are plain (and easily copied) headers; to accept access keys
+// from the primary, the call must also arrive via intra-cluster network (see isIntraNet)
+func (p *proxy) checkKeysFromPrimary(r *http.Request) error {
+	if err := p.checkIntraCall(r.Header, true /*from primary*/); err != nil {
+		return err
+	}
+	var (
+		smap   = p.owner.smap.get()
+		caller = smap.GetNode(r.Header.Get(apc.HdrCallerID))
+	)
+	if caller == nil || !smap.isPrimary(caller) {
+		return fmt.Errorf("%s: access keys are expected from primary (%s), got %q", p, smap.Primary, r.Header.Get(apc.HdrCallerName))
+	}
+	if !isIntraNet(r) {
+		return fmt.Errorf("%s: access keys from primary %s must arrive via intra-cluster network (remaddr=%s)",
+			p, caller.StringEx(), r.RemoteAddr)
+	}
+	return nil
+}
+
+// primary => all other proxies or, upon joining, a given one (targets never get
+// to see access keys and their secrets)
+func (p *proxy) bcastAccessKeys(keys []*authn.AccessKey, si *meta.Snode) {
+	if len(keys) == 0 {
+		return
+	}
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{
+		Method: http.MethodPut,
+		Path:   apc.URLPathTokens.S,
+		Body:   cos.MustMarshal(&tokenList{AccessKeys: keys}),
+	}
+	args.timeout = cmn.Rom.CplaneOperation()
+	if si != nil {
+		args.to = core.SelectedNodes
+		args.nodes = []meta.NodeMap{{si.ID(): si}}
+	} else {
+		args.to = core.Proxies
+	}
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			nlog.Errorln(p.String(), "failed to send access keys to", res.si.StringEx(), "err:", res.toErr())
+		}
+	}
+	freeBcastRes(results)
+}
+
+// S3 request signed with AuthN-issued access key (AWS SigV4): verify the signature
+// and, if valid, authorize the request with the key's token (see p.access);
+// the payload (if any) gets validated against its signed SHA256 as it is being read
+func (p *proxy) s3Auth(w http.ResponseWriter, r *http.Request) bool {
+	sig, err := s3.ParseSigV4(r)
+	if err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return false
+	}
+	if sig == nil {
+		return true
+	}
+	key := p.authn.accessKey(sig.AccessKeyID)
+	if key == nil {
+		return true // not ours (e.g., AWS credentials to pass through - see feat.S3PresignedRequest)
+	}
+	var (
+		now  = time.Now()
+		path = r.URL.Path
+	)
+	err = sig.Verify(r, key.Secret, path, now)
+	if err == s3.ErrSignatureMismatch && cmn.Rom.Features().IsSet(feat.S3APIviaRoot) {
+		// signed as "/<bucket>/<object>" (see rootHandler)
+		err = sig.Verify(r, key.Secret, strings.TrimPrefix(path, "/"+apc.S3), now)
+	}
+	if err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return false
+	}
+	if sum := s3.PayloadSHA256(r); sum != "" && r.Body != nil && r.Body != http.NoBody {
+		r.Body = s3.NewPayloadReader(r.Body, sum)
+	}
+	r.Header.Set(apc.HdrAuthorization, apc.AuthenticationTypeBearer+" "+key.Token)
+	return true
+}
+
 // Validates a token from the request header
 func (p *proxy) validateToken(hdr http.Header) (*tok.Token, error) {
 	token, err := tok.ExtractToken(hdr)
@@ -228,6 +472,19 @@ func (p *proxy) validateToken(hdr http.Header) (*tok.Token, error) {
 	return tk, nil
 }
 
+// user ID from the (valid) token, if any; not logging errors (see validateToken)
+func (p *proxy) userID(hdr http.Header) string {
+	token, err := tok.ExtractToken(hdr)
+	if err != nil {
+		return ""
+	}
+	tk, err := p.authn.validateToken(token)
+	if err != nil {
+		return ""
+	}
+	return tk.UserID
+}
+
 // When AuthN is on, accessing a bucket requires two permissions:
 //   - access to the bucket is granted to a user
 //   - bucket ACL allows the required operation
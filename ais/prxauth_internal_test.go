@@ -0,0 +1,207 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JWKS cache", func() {
+	var (
+		srv     *httptest.Server
+		fetches atomic.Int32
+		status  atomic.Int32
+		c       *tok.JWKSCache
+		setURL  = func(u string) {
+			config := cmn.GCO.BeginUpdate()
+			config.Auth.JWKSURL = u
+			cmn.GCO.CommitUpdate(config)
+		}
+	)
+
+	BeforeEach(func() {
+		fetches.Store(0)
+		status.Store(http.StatusOK)
+		srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			fetches.Add(1)
+			if code := int(status.Load()); code != http.StatusOK {
+				w.WriteHeader(code)
+				return
+			}
+			w.Write(cos.MustMarshal(&tok.JWKS{Keys: []tok.JWK{{Kty: "RSA", Kid: "k1", Alg: "RS256"}}}))
+		}))
+		setURL(srv.URL)
+		c = newJWKSCache()
+	})
+	AfterEach(func() {
+		srv.Close()
+		setURL("")
+	})
+
+	It("should fetch once and serve known keys from cache", func() {
+		for range 10 {
+			jwk, err := c.PublicKey("k1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jwk.Kid).To(Equal("k1"))
+		}
+		Expect(fetches.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("should not refetch for unknown key IDs within the interval", func() {
+		_, err := c.PublicKey("k1")
+		Expect(err).NotTo(HaveOccurred())
+		for _, kid := range []string{"x", "y", "z", "x"} {
+			_, err := c.PublicKey(kid)
+			Expect(errors.Is(err, tok.ErrUnknownKey)).To(BeTrue())
+		}
+		Expect(fetches.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("should rate-limit fetches when nothing is cached", func() {
+		status.Store(http.StatusServiceUnavailable)
+		for range 10 {
+			_, err := c.PublicKey("k1")
+			Expect(err).To(HaveOccurred())
+		}
+		Expect(fetches.Load()).To(BeEquivalentTo(1))
+	})
+
+	It("should remember unknown key IDs after the interval", func() {
+		c.RefetchIval = time.Millisecond
+		_, err := c.PublicKey("x")
+		Expect(errors.Is(err, tok.ErrUnknownKey)).To(BeTrue())
+		Expect(fetches.Load()).To(BeEquivalentTo(1))
+
+		time.Sleep(2 * c.RefetchIval)
+		_, err = c.PublicKey("x")
+		Expect(errors.Is(err, tok.ErrUnknownKey)).To(BeTrue())
+		Expect(fetches.Load()).To(BeEquivalentTo(1))
+
+		// but not other (new) key IDs
+		_, err = c.PublicKey("y")
+		Expect(errors.Is(err, tok.ErrUnknownKey)).To(BeTrue())
+		Expect(fetches.Load()).To(BeEquivalentTo(2))
+	})
+
+	It("should not cache keys fetched prior to reset", func() {
+		c.Fetch = func() (*tok.JWKS, error) {
+			c.Reset() // (e.g., the source of keys reconfigured while fetching)
+			return &tok.JWKS{Keys: []tok.JWK{{Kty: "RSA", Kid: "k1", Alg: "RS256"}}}, nil
+		}
+		_, err := c.PublicKey("k1")
+		Expect(errors.Is(err, tok.ErrUnknownKey)).To(BeTrue())
+
+		c.Fetch = newJWKSCache().Fetch
+		_, err = c.PublicKey("k1")
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
+var _ = Describe("S3 access keys", func() {
+	It("should keep access keys out of the (metasync-ed) token list", func() {
+		a := newAuthManager(cmn.GCO.Get())
+		now := time.Now()
+		all := a.addAccessKeys([]*authn.AccessKey{
+			{ID: "k1", Secret: "s1", Token: "t1", Expires: now.Add(time.Hour)},
+			{ID: "k2", Secret: "s2", Token: "t2", Expires: now.Add(-time.Hour)},
+		})
+		Expect(all).To(HaveLen(1))
+		Expect(all[0].ID).To(Equal("k1"))
+		Expect(a.accessKey("k1")).NotTo(BeNil())
+		Expect(a.accessKey("k2")).To(BeNil())
+		Expect(a.revokedTokenList()).To(BeNil())
+
+		// revoking the key's token revokes the key
+		a.Lock()
+		a.revokedTokens["t1"] = true
+		a.Unlock()
+		Expect(a.accessKey("k1")).To(BeNil())
+		Expect(a.addAccessKeys(nil)).To(BeEmpty())
+	})
+
+	It("should accept access keys from primary only via intra-cluster network", func() {
+		const secret = "aBitLongSecretKey"
+		p := &proxy{}
+		p.si = newSnode("p1", apc.Proxy, meta.NetInfo{Hostname: "10.0.0.1"}, meta.NetInfo{Hostname: "10.1.0.1"}, meta.NetInfo{})
+		smap := newSmap()
+		smap.addProxy(p.si)
+		smap.addProxy(newSnode("p2", apc.Proxy, meta.NetInfo{Hostname: "10.0.0.2"}, meta.NetInfo{Hostname: "10.1.0.2"}, meta.NetInfo{}))
+		smap.Primary = p.si
+		p.owner.smap = newSmapOwner(cmn.GCO.Get())
+		p.owner.smap.put(smap)
+		p.authn = newAuthManager(cmn.GCO.Get())
+		p.authn.secret = secret
+		p.authn.verifier = tok.Verifier{Secret: secret}
+
+		config := cmn.GCO.BeginUpdate()
+		config.HostNet.UseIntraControl = true
+		cmn.GCO.CommitUpdate(config)
+		defer func() {
+			config := cmn.GCO.BeginUpdate()
+			config.HostNet.UseIntraControl = false
+			cmn.GCO.CommitUpdate(config)
+		}()
+
+		newReq := func(callerID, remaddr string, intra bool) *http.Request {
+			r := httptest.NewRequest(http.MethodPut, "/v1/tokens", http.NoBody)
+			r.Header.Set(apc.HdrCallerID, callerID)
+			r.Header.Set(apc.HdrCallerName, callerID)
+			r.RemoteAddr = remaddr + ":51000"
+			if intra {
+				r = r.WithContext(context.WithValue(r.Context(), intraNetKey{}, true))
+			}
+			return r
+		}
+
+		// copied caller headers (public network) are not enough, AuthN admin token notwithstanding
+		r := newReq("p1", "10.0.0.1", false)
+		token, err := tok.AdminJWT(time.Now().Add(time.Hour), "admin", secret)
+		Expect(err).NotTo(HaveOccurred())
+		r.Header.Set(apc.HdrAuthorization, apc.AuthenticationTypeBearer+" "+token)
+		Expect(p.checkKeysFromPrimary(r)).To(HaveOccurred())
+
+		// intra-cluster network - regardless of the remote address (DNS names, NAT)
+		Expect(p.checkKeysFromPrimary(newReq("p1", "10.1.0.1", true))).NotTo(HaveOccurred())
+		Expect(p.checkKeysFromPrimary(newReq("p1", "192.0.2.1", true))).NotTo(HaveOccurred())
+
+		// not from primary
+		Expect(p.checkKeysFromPrimary(newReq("p2", "10.1.0.2", true))).To(HaveOccurred())
+
+		// single network: no token needed
+		config = cmn.GCO.BeginUpdate()
+		config.HostNet.UseIntraControl = false
+		cmn.GCO.CommitUpdate(config)
+		Expect(p.checkKeysFromPrimary(newReq("p1", "10.0.0.1", false))).NotTo(HaveOccurred())
+	})
+
+	It("should tell intra-cluster network", func() {
+		config := cmn.GCO.BeginUpdate()
+		config.HostNet.UseIntraControl = true
+		cmn.GCO.CommitUpdate(config)
+		defer func() {
+			config := cmn.GCO.BeginUpdate()
+			config.HostNet.UseIntraControl = false
+			cmn.GCO.CommitUpdate(config)
+		}()
+		r := httptest.NewRequest(http.MethodPut, "/v1/tokens", http.NoBody)
+		Expect(isIntraNet(r)).To(BeFalse())
+		r = r.WithContext(context.WithValue(r.Context(), intraNetKey{}, true))
+		Expect(isIntraNet(r)).To(BeTrue())
+	})
+})
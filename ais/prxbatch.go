@@ -0,0 +1,95 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// GET /v1/batch/<bucket-name>
+// validate, check access to all buckets, and redirect to the designated target (see tgtbatch.go)
+func (p *proxy) batchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.WriteErr405(w, r, http.MethodGet)
+		return
+	}
+	apireq := apiReqAlloc(1, apc.URLPathBatch.L, false /*dpq*/)
+	err := p.parseReq(w, r, apireq)
+	bck, query := apireq.bck, apireq.query
+	apiReqFree(apireq)
+	if err != nil {
+		return
+	}
+	body, err := cmn.ReadBytes(r)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	msg := &cmn.GetBatchMsg{}
+	if err := jsoniter.Unmarshal(body, msg); err != nil {
+		err = fmt.Errorf(cmn.FmtErrUnmarshal, p, "get-batch", cos.BHead(body), err)
+		p.writeErr(w, r, err)
+		return
+	}
+	if err := msg.Init(bck.Bucket()); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if _, err := archive.Mime(msg.Mime, ""); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+
+	// all buckets
+	var (
+		first  *meta.Bck
+		bcks   = make(map[string]*meta.Bck, 2)
+		counts = make(map[string]int, 2) // entries per bucket
+	)
+	for i := range msg.Entries {
+		e := &msg.Entries[i]
+		cname := e.Bck.Cname("")
+		counts[cname]++
+		if _, ok := bcks[cname]; ok {
+			continue
+		}
+		bckArgs := bctx{p: p, w: w, r: r, query: query, reqBody: body, bck: meta.CloneBck(&e.Bck), perms: apc.AceGET}
+		b, err := bckArgs.initAndTry()
+		if err != nil {
+			return
+		}
+		bcks[cname] = b
+		if i == 0 {
+			first = b
+		}
+	}
+	if p.rateLimitBatch(w, r, bcks, counts) {
+		return
+	}
+
+	// redirect to the owner of the first entry
+	started := time.Now()
+	smap := p.owner.smap.get()
+	tsi, netPub, err := smap.HrwMultiHome(first.MakeUname(msg.Entries[0].ObjName))
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleAIS) {
+		nlog.Infoln("GET batch", first.Cname(""), len(msg.Entries), "=>", tsi.StringEx())
+	}
+	redirectURL := p.redirectURL(r, tsi, started, cmn.NetIntraData, netPub)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+}
@@ -79,6 +79,10 @@ func (p *proxy) httpcluget(w http.ResponseWriter, r *http.Request) {
 		p.qcluStats(w, r, what, query)
 	case apc.WhatSysInfo:
 		p.qcluSysinfo(w, r, what, query)
+	case apc.WhatAudit:
+		p.qcluAudit(w, r, what, query)
+	case apc.WhatUsage:
+		p.qcluUsage(w, r, what, query)
 	case apc.WhatMountpaths:
 		p.qcluMountpaths(w, r, what, query)
 	case apc.WhatBackends:
@@ -724,6 +728,10 @@ func (p *proxy) kalive(nsi, osi *meta.Snode) bool {
 		nlog.Warningf("%s: renewing registration %s (info changed!)", p, nsi.StringEx())
 		return true // NOTE: update cluster map
 	}
+	if osi.Weight != nsi.Weight {
+		nlog.Warningf("%s: renewing registration %s (HRW weight %d => %d)", p, nsi.StringEx(), osi.Weight, nsi.Weight)
+		return true // ditto
+	}
 
 	p.keepalive.heardFrom(nsi.ID())
 	return false
@@ -733,7 +741,7 @@ func (p *proxy) rereg(nsi, osi *meta.Snode) bool {
 	if !p.NodeStarted() {
 		return true
 	}
-	if osi.Eq(nsi) && osi.Flags == nsi.Flags {
+	if osi.Eq(nsi) && osi.Flags == nsi.Flags && osi.FD == nsi.FD && osi.Weight == nsi.Weight {
 		nlog.Infoln(p.String(), "node", nsi.StringEx(), "is already _in_ - nothing to do")
 		return false
 	}
@@ -899,6 +907,11 @@ func (p *proxy) _joinedFinal(ctx *smapModifier, clone *smapX) {
 	}
 	_ = p.metasyncer.sync(pairs...)
 	p.syncNewICOwners(ctx.smap, clone)
+
+	// S3 access keys: proxies only (see bcastAccessKeys)
+	if ctx.nsi != nil && ctx.nsi.IsProxy() {
+		go p.bcastAccessKeys(p.authn.addAccessKeys(nil), ctx.nsi)
+	}
 }
 
 func (p *proxy) _syncFinal(ctx *smapModifier, clone *smapX) {
@@ -2183,5 +2196,13 @@ func mustRebalance(ctx *smapModifier, cur *smapX) bool {
 			return true
 		}
 	}
+	// placement change: the same active target with a different weight or failure domain
+	for _, tsi := range cur.Tmap {
+		if osi := prev.GetActiveNode(tsi.ID()); osi != nil && !tsi.InMaintOrDecomm() {
+			if osi.Weight != tsi.Weight || osi.FD != tsi.FD {
+				return true
+			}
+		}
+	}
 	return false
 }
@@ -0,0 +1,134 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/core/meta"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// GET /v1/events/<bucket-name>?rule=<rule-ID>&token=<token>&wait=<duration>
+// long-poll object events (see tgtevents.go):
+// - broadcast to all targets: first without waiting and then, if there are no events yet,
+//   in short (evPollSlice) rounds until the requested wait time expires;
+// - merge the results (ordered by time) and advance per-target positions encoded in the returned token;
+// - events are re-delivered until the client passes back the new token (at-least-once).
+
+const (
+	evPollSlice = 2 * time.Second
+	evMaxWait   = 5 * time.Minute
+)
+
+func (p *proxy) eventsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.WriteErr405(w, r, http.MethodGet)
+		return
+	}
+	apireq := apiReqAlloc(1, apc.URLPathEvents.L, false /*dpq*/)
+	err := p.parseReq(w, r, apireq)
+	bck, query := apireq.bck, apireq.query
+	apiReqFree(apireq)
+	if err != nil {
+		return
+	}
+	bckArgs := bctx{p: p, w: w, r: r, query: query, bck: bck, perms: apc.AceObjLIST}
+	if bck, err = bckArgs.initAndTry(); err != nil {
+		return
+	}
+	ruleID := query.Get(apc.QparamEventRule)
+	if bck.Props.Events.Rule(ruleID) == nil {
+		p.writeErr(w, r, cos.NewErrNotFound(p, "event rule "+strconv.Quote(ruleID)+" in bucket "+bck.Cname("")),
+			http.StatusNotFound)
+		return
+	}
+	token := query.Get(apc.QparamEventToken)
+	seqs, err := cmn.DecodeEventsToken(token)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	var wait time.Duration
+	if s := query.Get(apc.QparamEventWait); s != "" {
+		if wait, err = time.ParseDuration(s); err != nil {
+			p.writeErrf(w, r, "%s: invalid %s=%q: %v", p, apc.QparamEventWait, s, err)
+			return
+		}
+		wait = min(max(wait, 0), evMaxWait)
+	}
+
+	var (
+		evs      []cmn.ObjEvent
+		slice    time.Duration
+		deadline = mono.NanoTime() + int64(wait)
+	)
+	for {
+		if evs, err = p.pollEvents(bck, ruleID, token, slice); err != nil {
+			p.writeErr(w, r, err)
+			return
+		}
+		left := time.Duration(deadline - mono.NanoTime())
+		if len(evs) > 0 || left <= 0 {
+			break
+		}
+		slice = min(evPollSlice, left)
+	}
+
+	for i := range evs {
+		ev := &evs[i]
+		seqs[ev.Node] = max(seqs[ev.Node], ev.Seq)
+	}
+	sort.SliceStable(evs, func(i, k int) bool { return evs[i].Time < evs[k].Time })
+	if evs == nil {
+		evs = []cmn.ObjEvent{}
+	}
+	p.writeJSON(w, r, &cmn.EventsResult{Events: evs, Token: cmn.EncodeEventsToken(seqs)}, "events")
+}
+
+func (p *proxy) pollEvents(bck *meta.Bck, ruleID, token string, wait time.Duration) ([]cmn.ObjEvent, error) {
+	q := bck.NewQuery()
+	q.Set(apc.QparamEventRule, ruleID)
+	if token != "" {
+		q.Set(apc.QparamEventToken, token)
+	}
+	if wait > 0 {
+		q.Set(apc.QparamEventWait, wait.String())
+	}
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodGet, Path: apc.URLPathEvents.Join(bck.Name), Query: q}
+	args.smap = p.owner.smap.get()
+	args.timeout = wait + cmn.GCO.Get().Timeout.CplaneOperation.D()
+	if cnt := args.smap.CountActiveTs(); cnt < 1 {
+		freeBcArgs(args)
+		return nil, cmn.NewErrNoNodes(apc.Target, args.smap.CountTargets())
+	}
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+
+	var all []cmn.ObjEvent
+	for _, res := range results {
+		if res.err != nil {
+			err := res.toErr()
+			freeBcastRes(results)
+			return nil, err
+		}
+		var evs []cmn.ObjEvent
+		if err := jsoniter.Unmarshal(res.bytes, &evs); err != nil {
+			freeBcastRes(results)
+			return nil, err
+		}
+		all = append(all, evs...)
+	}
+	freeBcastRes(results)
+	return all, nil
+}
@@ -192,7 +192,7 @@ func (b *lsobjBuffer) mergeTargetBuffers() (filled bool) {
 		}
 	}
 
-	cmn.SortLso(entries)
+	cmn.SortLsoByName(entries) // (virtual dirs, if any, in their lexicographic place - see sort.Search below)
 
 	if minObj != "" {
 		idx := sort.Search(len(entries), func(i int) bool {
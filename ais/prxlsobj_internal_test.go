@@ -5,7 +5,9 @@
 package ais
 
 import (
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -386,6 +388,27 @@ var _ = Describe("ListObjectsCache+ListObjectsBuffer", func() {
 			Expect(entries).To(BeNil())
 		})
 
+		It("should keep common prefixes (virtual dirs) in lexicographic order", func() {
+			dirs := func(entries cmn.LsoEntries) cmn.LsoEntries {
+				for _, en := range entries {
+					if cos.IsLastB(en.Name, '|') {
+						en.Flags = apc.EntryIsDir
+					}
+				}
+				return entries
+			}
+			buffer.set(id, "target1", dirs(makeEntries("a|", "d", "g|")), 3)
+			buffer.set(id, "target2", dirs(makeEntries("a|", "c|", "h")), 3)
+
+			entries, hasEnough := buffer.get(id, "", 4)
+			Expect(hasEnough).To(BeTrue())
+			Expect(extractNames(entries)).To(Equal([]string{"a|", "a|", "c|", "d"}))
+
+			entries, hasEnough = buffer.get(id, "d", 1)
+			Expect(hasEnough).To(BeTrue())
+			Expect(extractNames(entries)).To(Equal([]string{"g|"}))
+		})
+
 		It("should correctly identify no objects", func() {
 			entries, hasEnough := buffer.get("id", "a", 10)
 			Expect(hasEnough).To(BeFalse())
@@ -0,0 +1,73 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/core/meta"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// api.SearchObjectsMD(bck, MDSearchMsg) => all targets (see tgtmdidx.go)
+// - merges and dedups the names of matching objects (e.g., while rebalancing)
+func (p *proxy) searchMD(w http.ResponseWriter, r *http.Request, bck *meta.Bck, msg *apc.ActMsg) {
+	var smsg apc.MDSearchMsg
+	if err := cos.MorphMarshal(msg.Value, &smsg); err != nil {
+		p.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, p.si, msg.Action, msg.Value, err)
+		return
+	}
+	if smsg.Key == "" {
+		p.writeErrf(w, r, "%s: missing custom metadata key to search %s", p, bck.Cname(""))
+		return
+	}
+	if !bck.Props.Features.IsSet(feat.IndexCustomMD) {
+		p.writeErrf(w, r, "%s: bucket %s does not have %q feature enabled", p, bck.Cname(""),
+			feat.Flags(feat.IndexCustomMD).Names()[0])
+		return
+	}
+
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{
+		Method: http.MethodGet,
+		Path:   apc.URLPathBuckets.Join(bck.Name),
+		Query:  bck.NewQuery(),
+		Body:   cos.MustMarshal(p.newAmsgActVal(apc.ActSearchMD, &smsg)),
+	}
+	args.smap = p.owner.smap.get()
+	if cnt := args.smap.CountActiveTs(); cnt < 1 {
+		freeBcArgs(args)
+		p.writeErr(w, r, cmn.NewErrNoNodes(apc.Target, args.smap.CountTargets()))
+		return
+	}
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+
+	all := cos.NewStrSet()
+	for _, res := range results {
+		if res.err != nil {
+			p.writeErr(w, r, res.toErr())
+			freeBcastRes(results)
+			return
+		}
+		var names []string
+		if err := jsoniter.Unmarshal(res.bytes, &names); err != nil {
+			p.writeErr(w, r, err)
+			freeBcastRes(results)
+			return
+		}
+		all.Add(names...)
+	}
+	freeBcastRes(results)
+
+	names := all.ToSlice()
+	slices.Sort(names)
+	p.writeJSON(w, r, names, msg.Action)
+}
@@ -0,0 +1,367 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Rate limiting (see cmn.RateLimitConf and bucket property "rate_limit"):
+// - gateways enforce per-bucket and per-user (AuthN) limits on request rate and bandwidth
+//   prior to redirecting data path requests to targets;
+// - token bucket with the burst of one second worth of tokens; a single request that exceeds
+//   the burst (e.g., large PUT) is let through when the bucket is full, taking it into debt
+//   (negative tokens) for the entire size;
+// - a request takes tokens only when it fits into all applicable limits (RPS and bandwidth,
+//   bucket and user), so that rejected requests do not count;
+// - the configured limits are cluster-wide: each gateway maintains its own replica of each
+//   token bucket and periodically (ratelimSyncIval) sends the tokens it has taken to all other
+//   gateways, which then debit their replicas - the aggregate may exceed the limit by
+//   (at most) one sync interval worth of other gateways' usage;
+// - PUT bandwidth is charged upfront (Content-Length); GET bandwidth is charged post-hoc:
+//   targets report the bytes they have sent to all gateways (see tgtratelim.go), and
+//   GET requests are rejected while the bandwidth bucket is in debt;
+// - over-limit requests fail with 429 (Too Many Requests) and Retry-After header
+//   (S3 API: error code "SlowDown").
+
+const (
+	hdrRetryAfter = "Retry-After"
+
+	ratelimIdle     = 10 * time.Minute // remove limiters that haven't been used for so long
+	ratelimHkIval   = ratelimIdle
+	ratelimSyncIval = time.Second // exchange usage (gateways <=> gateways, targets => gateways)
+)
+
+type (
+	tokenBucket struct {
+		tokens float64 // negative when in debt
+		last   int64   // mono.NanoTime
+	}
+	rateLimiter struct {
+		rps, bps tokenBucket
+		pending  ratelimUsage // taken locally and not yet sent to other gateways
+		atime    atomic.Int64 // mono.NanoTime
+		mu       sync.Mutex
+	}
+	ratelimArg struct {
+		lim  *rateLimiter
+		conf *cmn.RateConf
+		what string  // (for error message; also, the locking order - see admit)
+		reqs float64 // number of requests to charge (e.g., batch GET entries)
+	}
+	rateLimiters struct {
+		p *proxy
+		m sync.Map // "b:<bucket cname>" | "u:<user ID>" => *rateLimiter
+	}
+
+	// tokens taken (or bytes sent) elsewhere in the cluster
+	// (payload of intra-cluster POST /v1/ratelim)
+	ratelimUsage struct {
+		Key   string  `json:"k"`
+		Reqs  float64 `json:"n,omitempty"` // number of requests
+		Bytes float64 `json:"b,omitempty"`
+		RPS   float64 `json:"rps,omitempty"` // configured limits (to refill prior to debiting)
+		Bps   float64 `json:"bps,omitempty"`
+	}
+)
+
+func ratelimKeyBck(bck *meta.Bck) string { return "b:" + bck.Cname("") }
+func ratelimKeyUser(uid string) string   { return "u:" + uid }
+
+// per-user bandwidth limit (GET requests must carry user ID - see usageUser)
+func ratelimUserBps(config *cmn.Config) bool {
+	conf := &config.RateLimit.User
+	return conf.IsSet() && conf.MaxBps > 0
+}
+
+/////////////////
+// tokenBucket //
+/////////////////
+
+// (under rateLimiter lock) refill and return zero when n tokens can be taken;
+// otherwise, the time until enough tokens accumulate
+// - a single request that exceeds the burst requires a full bucket (and takes it into debt)
+func (tb *tokenBucket) check(n, rate float64, now int64) time.Duration {
+	burst := max(rate, 1)
+	if tb.last == 0 {
+		tb.tokens = burst
+	} else {
+		tb.tokens = min(burst, tb.tokens+rate*float64(now-tb.last)/float64(time.Second))
+	}
+	tb.last = now
+	if need := min(n, burst); tb.tokens < need {
+		return time.Duration((need - tb.tokens) / rate * float64(time.Second))
+	}
+	return 0
+}
+
+// (under rateLimiter lock) the entire n - possibly, going negative
+func (tb *tokenBucket) take(n float64) { tb.tokens -= n }
+
+/////////////////
+// rateLimiter //
+/////////////////
+
+// (under lock)
+// - size: PUT size (taken upfront), or zero
+// - bw: whether the bandwidth limit applies (PUT, or GET charged post-hoc - must not be in debt)
+func (lim *rateLimiter) check(conf *cmn.RateConf, reqs float64, size int64, bw bool, now int64) time.Duration {
+	lim.atime.Store(now)
+	var wait time.Duration
+	if conf.MaxRPS > 0 {
+		wait = lim.rps.check(reqs, float64(conf.MaxRPS), now)
+	}
+	if conf.MaxBps > 0 && bw {
+		wait = max(wait, lim.bps.check(float64(size), float64(conf.MaxBps), now))
+	}
+	return wait
+}
+
+// (under lock) must be preceded by a successful check
+func (lim *rateLimiter) take(conf *cmn.RateConf, reqs float64, size int64) {
+	if conf.MaxRPS > 0 {
+		lim.rps.take(reqs)
+		lim.pending.Reqs += reqs
+		lim.pending.RPS = float64(conf.MaxRPS)
+	}
+	if conf.MaxBps > 0 && size > 0 {
+		lim.bps.take(float64(size))
+		lim.pending.Bytes += float64(size)
+		lim.pending.Bps = float64(conf.MaxBps)
+	}
+}
+
+// (under lock) tokens taken elsewhere: refill (to account for the time elapsed) and debit
+func (lim *rateLimiter) debit(u *ratelimUsage, now int64) {
+	if u.Reqs > 0 && u.RPS > 0 {
+		lim.rps.check(0, u.RPS, now)
+		lim.rps.take(u.Reqs)
+	}
+	if u.Bytes > 0 && u.Bps > 0 {
+		lim.bps.check(0, u.Bps, now)
+		lim.bps.take(u.Bytes)
+	}
+}
+
+// check all limits (in order: bucket(s), user) and only then take tokens from each;
+// returns zero when admitted; otherwise, the time to wait and the limit that rejected
+// - lims must be sorted by `what` (the order in which they get locked)
+func admit(lims []ratelimArg, size int64, bw bool, now int64) (wait time.Duration, what string) {
+	for i := range lims {
+		lims[i].lim.mu.Lock()
+	}
+	for i := range lims {
+		if wait = lims[i].lim.check(lims[i].conf, lims[i].reqs, size, bw, now); wait > 0 {
+			what = lims[i].what
+			break
+		}
+	}
+	if wait == 0 {
+		for i := range lims {
+			lims[i].lim.take(lims[i].conf, lims[i].reqs, size)
+		}
+	}
+	for i := range lims {
+		lims[i].lim.mu.Unlock()
+	}
+	return wait, what
+}
+
+//////////////////
+// rateLimiters //
+//////////////////
+
+func (rl *rateLimiters) init(p *proxy) {
+	rl.p = p
+	hk.Reg("rate-limiters"+hk.NameSuffix, rl.housekeep, ratelimHkIval)
+	hk.Reg("rate-limiters-sync"+hk.NameSuffix, rl.sync, ratelimSyncIval)
+}
+
+func (rl *rateLimiters) get(key string) *rateLimiter {
+	if v, ok := rl.m.Load(key); ok {
+		return v.(*rateLimiter)
+	}
+	v, _ := rl.m.LoadOrStore(key, &rateLimiter{})
+	return v.(*rateLimiter)
+}
+
+func (rl *rateLimiters) housekeep(now int64) time.Duration {
+	rl.m.Range(func(k, v any) bool {
+		if lim := v.(*rateLimiter); time.Duration(now-lim.atime.Load()) > ratelimIdle {
+			rl.m.Delete(k)
+		}
+		return true
+	})
+	return ratelimHkIval
+}
+
+// collect (and reset) tokens taken locally since the previous call
+func (rl *rateLimiters) collect() (usages []ratelimUsage) {
+	rl.m.Range(func(k, v any) bool {
+		lim := v.(*rateLimiter)
+		lim.mu.Lock()
+		if lim.pending.Reqs > 0 || lim.pending.Bytes > 0 {
+			u := lim.pending
+			u.Key = k.(string)
+			usages = append(usages, u)
+			lim.pending = ratelimUsage{}
+		}
+		lim.mu.Unlock()
+		return true
+	})
+	return usages
+}
+
+// debit tokens taken by other gateways (or GET bytes sent by targets)
+func (rl *rateLimiters) debit(usages []ratelimUsage, now int64) {
+	for i := range usages {
+		u := &usages[i]
+		lim := rl.get(u.Key)
+		lim.mu.Lock()
+		lim.debit(u, now)
+		lim.mu.Unlock()
+		lim.atime.Store(now)
+	}
+}
+
+func (rl *rateLimiters) sync(int64) time.Duration {
+	usages := rl.collect()
+	if len(usages) == 0 || !rl.p.ClusterStarted() {
+		return ratelimSyncIval
+	}
+	if smap := rl.p.owner.smap.get(); smap.CountActivePs() > 1 {
+		rl.p.bcastRatelim(smap, usages)
+	}
+	return ratelimSyncIval
+}
+
+// (gateway => other gateways; target => all gateways)
+func (h *htrun) bcastRatelim(smap *smapX, usages []ratelimUsage) {
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodPost, Path: apc.URLPathRateLim.S, Body: cos.MustMarshal(usages)}
+	args.to = core.Proxies
+	args.smap = smap
+	args.async = true
+	_ = h.bcastGroup(args) // args.async: result is already discarded/freed
+	freeBcArgs(args)
+}
+
+// POST /v1/ratelim (intra-cluster)
+func (p *proxy) ratelimHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		cmn.WriteErr405(w, r, http.MethodPost)
+		return
+	}
+	if err := p.checkIntraCall(r.Header, false /*from primary*/); err != nil {
+		p.writeErr(w, r, err, http.StatusUnauthorized)
+		return
+	}
+	var usages []ratelimUsage
+	if cmn.ReadJSON(w, r, &usages) != nil {
+		return
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleAIS) {
+		nlog.Infoln(p.String(), "rate-limit usage from", r.Header.Get(apc.HdrCallerName), len(usages))
+	}
+	p.ratelim.debit(usages, mono.NanoTime())
+}
+
+// enforce bucket and user limits prior to redirecting; returns true when the request has been rejected
+func (p *proxy) rateLimit(w http.ResponseWriter, r *http.Request, bck *meta.Bck, isS3 bool) bool {
+	var (
+		bconf = &bck.Props.RateLimit
+		uconf = &cmn.GCO.Get().RateLimit.User
+		bset  = bconf.IsSet()
+		uset  = uconf.IsSet() && cmn.Rom.AuthEnabled()
+	)
+	if !bset && !uset {
+		return false
+	}
+	var (
+		size int64
+		lims [2]ratelimArg
+		cnt  int
+		bw   = r.Method == http.MethodGet // (charged post-hoc)
+	)
+	if r.Method == http.MethodPut {
+		size, bw = max(r.ContentLength, 0), true
+	}
+	if bset {
+		lims[cnt] = ratelimArg{p.ratelim.get(ratelimKeyBck(bck)), bconf, "bucket " + bck.Cname(""), 1}
+		cnt++
+	}
+	if uset {
+		if uid := p.userID(r.Header); uid != "" {
+			lims[cnt] = ratelimArg{p.ratelim.get(ratelimKeyUser(uid)), uconf, "user " + uid, 1}
+			cnt++
+		}
+	}
+	return p._rateLimit(w, r, lims[:cnt], size, bw, bck, isS3)
+}
+
+// batch GET: charge each bucket the number of its entries, and the user - the total
+// (bcks and counts by bucket cname)
+func (p *proxy) rateLimitBatch(w http.ResponseWriter, r *http.Request, bcks map[string]*meta.Bck, counts map[string]int) bool {
+	var (
+		uconf = &cmn.GCO.Get().RateLimit.User
+		uset  = uconf.IsSet() && cmn.Rom.AuthEnabled()
+		lims  = make([]ratelimArg, 0, len(bcks)+1)
+		first *meta.Bck
+		total int
+	)
+	for cname, bck := range bcks {
+		total += counts[cname]
+		if bconf := &bck.Props.RateLimit; bconf.IsSet() {
+			lims = append(lims, ratelimArg{p.ratelim.get(ratelimKeyBck(bck)), bconf, "bucket " + cname, float64(counts[cname])})
+		}
+		if first == nil || cname < first.Cname("") {
+			first = bck // (for stats: deterministic)
+		}
+	}
+	if uset {
+		if uid := p.userID(r.Header); uid != "" {
+			lims = append(lims, ratelimArg{p.ratelim.get(ratelimKeyUser(uid)), uconf, "user " + uid, float64(total)})
+		}
+	}
+	if len(lims) == 0 {
+		return false
+	}
+	sort.Slice(lims, func(i, j int) bool { return lims[i].what < lims[j].what })
+	return p._rateLimit(w, r, lims, 0, true /*bw: GET*/, first, false /*s3*/)
+}
+
+func (p *proxy) _rateLimit(w http.ResponseWriter, r *http.Request, lims []ratelimArg, size int64, bw bool, bck *meta.Bck, isS3 bool) bool {
+	wait, what := admit(lims, size, bw, mono.NanoTime())
+	if wait == 0 {
+		return false
+	}
+
+	p.statsT.IncBck(stats.ErrRateLimitCount, bck.Bucket())
+	w.Header().Set(hdrRetryAfter, strconv.Itoa(max(int(math.Ceil(wait.Seconds())), 1)))
+	err := cmn.NewErrRateLimit(what, wait)
+	if isS3 {
+		s3.WriteErr(w, r, err, http.StatusTooManyRequests)
+	} else {
+		p.writeErr(w, r, err, http.StatusTooManyRequests, Silent)
+	}
+	return true
+}
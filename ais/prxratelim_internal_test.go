@@ -0,0 +1,188 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rate limiting", func() {
+	const now = int64(time.Hour) // arbitrary mono time
+
+	take := func(lim *rateLimiter, conf *cmn.RateConf, size, now int64) time.Duration {
+		wait, _ := admit([]ratelimArg{{lim, conf, "test", 1}}, size, size > 0, now)
+		return wait
+	}
+
+	It("should allow burst and then throttle", func() {
+		var (
+			lim  rateLimiter
+			conf = cmn.RateConf{MaxRPS: 10, Enabled: true}
+		)
+		for range 10 {
+			Expect(take(&lim, &conf, 0, now)).To(BeZero())
+		}
+		wait := take(&lim, &conf, 0, now)
+		Expect(wait).To(Equal(100 * time.Millisecond))
+
+		// refill
+		Expect(take(&lim, &conf, 0, now+int64(100*time.Millisecond))).To(BeZero())
+		Expect(take(&lim, &conf, 0, now+int64(100*time.Millisecond))).NotTo(BeZero())
+	})
+
+	It("should not accumulate beyond burst", func() {
+		var (
+			lim  rateLimiter
+			conf = cmn.RateConf{MaxRPS: 2, Enabled: true}
+		)
+		Expect(take(&lim, &conf, 0, now)).To(BeZero())
+		later := now + int64(time.Minute)
+		Expect(take(&lim, &conf, 0, later)).To(BeZero())
+		Expect(take(&lim, &conf, 0, later)).To(BeZero())
+		Expect(take(&lim, &conf, 0, later)).NotTo(BeZero())
+	})
+
+	It("should admit oversized request into debt for its entire size", func() {
+		var (
+			lim  rateLimiter
+			conf = cmn.RateConf{MaxBps: cos.MiB, Enabled: true}
+		)
+		Expect(take(&lim, &conf, 10*cos.MiB, now)).To(BeZero())
+		Expect(lim.bps.tokens).To(Equal(float64(-9 * cos.MiB)))
+
+		// the debt must be paid off: 9s to zero, plus the time to accumulate 1KiB
+		wait := take(&lim, &conf, cos.KiB, now)
+		Expect(wait).To(BeNumerically(">", 9*time.Second))
+		Expect(wait).To(BeNumerically("<=", 9*time.Second+10*time.Millisecond))
+
+		// a full bucket is required to admit the next oversized one
+		later := now + int64(10*time.Second)
+		Expect(take(&lim, &conf, 2*cos.MiB, later)).To(BeZero())
+	})
+
+	It("should not take tokens when rejected", func() {
+		var (
+			lim  rateLimiter
+			conf = cmn.RateConf{MaxRPS: 10, MaxBps: cos.MiB, Enabled: true}
+		)
+		Expect(take(&lim, &conf, 2*cos.MiB, now)).To(BeZero())
+		rps := lim.rps.tokens
+		Expect(rps).To(Equal(float64(9)))
+
+		// rejected by bandwidth - RPS tokens intact
+		for range 5 {
+			Expect(take(&lim, &conf, cos.KiB, now)).NotTo(BeZero())
+		}
+		Expect(lim.rps.tokens).To(Equal(rps))
+	})
+
+	It("should check bucket and user limits before taking from either", func() {
+		var (
+			blim, ulim rateLimiter
+			bconf      = cmn.RateConf{MaxRPS: 100, Enabled: true}
+			uconf      = cmn.RateConf{MaxRPS: 1, Enabled: true}
+			lims       = []ratelimArg{{&blim, &bconf, "bucket", 1}, {&ulim, &uconf, "user", 1}}
+		)
+		wait, _ := admit(lims, 0, false, now)
+		Expect(wait).To(BeZero())
+		Expect(blim.rps.tokens).To(Equal(float64(99)))
+
+		for range 10 {
+			wait, what := admit(lims, 0, false, now)
+			Expect(wait).NotTo(BeZero())
+			Expect(what).To(Equal("user"))
+		}
+		// the bucket limit was not charged for the requests rejected by user limit
+		Expect(blim.rps.tokens).To(Equal(float64(99)))
+	})
+
+	It("should collect tokens taken locally and debit those taken elsewhere", func() {
+		var (
+			rl   rateLimiters
+			conf = cmn.RateConf{MaxRPS: 100, MaxBps: cos.MiB, Enabled: true}
+			lim  = rl.get("b:ais://test")
+		)
+		for range 10 {
+			Expect(take(lim, &conf, cos.KiB, now)).To(BeZero())
+		}
+		usages := rl.collect()
+		Expect(usages).To(HaveLen(1))
+		Expect(usages[0]).To(Equal(ratelimUsage{
+			Key: "b:ais://test", Reqs: 10, Bytes: 10 * cos.KiB, RPS: 100, Bps: cos.MiB,
+		}))
+		Expect(rl.collect()).To(BeEmpty()) // reset
+
+		// another gateway's replica of the same (cluster-wide) limit
+		var other rateLimiters
+		other.debit(usages, now)
+		olim := other.get("b:ais://test")
+		Expect(olim.rps.tokens).To(Equal(float64(90)))
+		Expect(olim.bps.tokens).To(Equal(float64(cos.MiB - 10*cos.KiB)))
+		Expect(other.collect()).To(BeEmpty()) // (debits are not forwarded)
+
+		for range 90 {
+			Expect(take(olim, &conf, 0, now)).To(BeZero())
+		}
+		Expect(take(olim, &conf, 0, now)).NotTo(BeZero())
+	})
+
+	It("should reject GET while bandwidth is in debt", func() {
+		var (
+			lim  rateLimiter
+			conf = cmn.RateConf{MaxBps: cos.MiB, Enabled: true}
+			get  = func(now int64) time.Duration {
+				wait, _ := admit([]ratelimArg{{&lim, &conf, "test", 1}}, 0, true /*bw*/, now)
+				return wait
+			}
+		)
+		Expect(get(now)).To(BeZero())
+
+		// post-hoc: 3MiB sent by targets
+		lim.debit(&ratelimUsage{Bytes: 3 * cos.MiB, Bps: cos.MiB}, now)
+		wait := get(now)
+		Expect(wait).To(Equal(2 * time.Second))
+		Expect(get(now + int64(2*time.Second))).To(BeZero())
+
+		// other requests (e.g., HEAD) are not subject to bandwidth limit
+		lim.debit(&ratelimUsage{Bytes: 3 * cos.MiB, Bps: cos.MiB}, now)
+		w, _ := admit([]ratelimArg{{&lim, &conf, "test", 1}}, 0, false, now)
+		Expect(w).To(BeZero())
+	})
+
+	It("should charge batch entries per bucket and in total per user", func() {
+		var (
+			alim, blim, ulim rateLimiter
+			conf             = cmn.RateConf{MaxRPS: 10, Enabled: true}
+			uconf            = cmn.RateConf{MaxRPS: 12, Enabled: true}
+			lims             = []ratelimArg{
+				{&alim, &conf, "bucket ais://a", 6}, {&blim, &conf, "bucket ais://b", 4}, {&ulim, &uconf, "user u", 10},
+			}
+		)
+		wait, _ := admit(lims, 0, true, now)
+		Expect(wait).To(BeZero())
+		Expect(alim.rps.tokens).To(Equal(float64(4)))
+		Expect(blim.rps.tokens).To(Equal(float64(6)))
+		Expect(ulim.rps.tokens).To(Equal(float64(2)))
+
+		// the same batch again: rejected by the first bucket, nothing taken
+		wait, what := admit(lims, 0, true, now)
+		Expect(wait).NotTo(BeZero())
+		Expect(what).To(Equal("bucket ais://a"))
+		Expect(blim.rps.tokens).To(Equal(float64(6)))
+		Expect(ulim.rps.tokens).To(Equal(float64(2)))
+	})
+
+	It("should validate", func() {
+		Expect((&cmn.RateConf{MaxRPS: 10}).IsSet()).To(BeFalse())
+		Expect((&cmn.RateConf{Enabled: true}).IsSet()).To(BeFalse())
+		Expect((&cmn.RateConf{MaxRPS: 10, Enabled: true}).IsSet()).To(BeTrue())
+		Expect((&cmn.RateConf{MaxRPS: -1}).ValidateAsProps()).To(HaveOccurred())
+	})
+})
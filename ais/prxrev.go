@@ -105,6 +105,9 @@ func (p *proxy) forwardCP(w http.ResponseWriter, r *http.Request, msg *apc.ActMs
 			nlog.Infoln(p.String(), "forwarding [", s, "] to the primary", pname)
 		}
 	}
+	if aw, ok := w.(*auditWriter); ok {
+		aw.forwarded = true
+	}
 	primary.rp.ServeHTTP(w, r)
 	return true // forwarded
 }
@@ -39,6 +39,16 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 		nlog.Infoln("s3Handler", p.String(), r.Method, r.URL)
 	}
 
+	// CORS preflight (unauthenticated)
+	if r.Method == http.MethodOptions {
+		p.preflightS3(w, r)
+		return
+	}
+
+	if cmn.Rom.AuthEnabled() && !p.s3Auth(w, r) {
+		return
+	}
+
 	// TODO: Fix the hack, https://github.com/tensorflow/tensorflow/issues/41798
 	cos.ReparseQuery(r)
 	apiItems, err := p.parseURL(w, r, apc.URLPathS3.L, 0, true)
@@ -46,6 +56,13 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// CORS (actual cross-origin request)
+	if len(apiItems) > 0 && r.Header.Get(cos.HdrOrigin) != "" {
+		if bck, err, _ := meta.InitByNameOnly(apiItems[0], p.owner.bmd); err == nil {
+			s3.SetCORS(w.Header(), r, &bck.Props.CORS)
+		}
+	}
+
 	switch r.Method {
 	case http.MethodHead:
 		if len(apiItems) == 0 {
@@ -75,11 +92,9 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 		var (
 			q            = r.URL.Query()
 			_, lifecycle = q[s3.QparamLifecycle]
-			_, policy    = q[s3.QparamPolicy]
-			_, cors      = q[s3.QparamCORS]
 			_, acl       = q[s3.QparamACL]
 		)
-		if lifecycle || policy || cors || acl {
+		if lifecycle || acl {
 			p.unsupported(w, r, apiItems[0])
 			return
 		}
@@ -90,8 +105,37 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.getBckVersioningS3(w, r, apiItems[0])
 				return
 			}
+			if q.Has(s3.QparamCORS) {
+				// perms: apc.AceBckHEAD
+				p.getBckCORS(w, r, apiItems[0])
+				return
+			}
+			if q.Has(s3.QparamNotification) {
+				// perms: apc.AceBckHEAD
+				p.getBckNotif(w, r, apiItems[0])
+				return
+			}
+			if q.Has(s3.QparamPolicy) {
+				// perms: apc.AceBckHEAD
+				p.getBckPolicy(w, r, apiItems[0])
+				return
+			}
 			// perms: apc.AceObjLIST
-			p.listObjectsS3(w, r, apiItems[0], q)
+			if q.Has(s3.QparamVersions) {
+				p.listObjectVersionsS3(w, r, apiItems[0], q)
+			} else {
+				p.listObjectsS3(w, r, apiItems[0], q)
+			}
+			return
+		}
+		if q.Has(s3.QparamTagging) {
+			// perms: apc.AceObjHEAD
+			p.objMetaS3(w, r, apiItems, apc.AceObjHEAD, s3.QparamTagging)
+			return
+		}
+		if q.Has(s3.QparamAttributes) {
+			// perms: apc.AceObjHEAD
+			p.objMetaS3(w, r, apiItems, apc.AceObjHEAD, s3.QparamAttributes)
 			return
 		}
 		// object data otherwise
@@ -109,10 +153,30 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.putBckVersioningS3(w, r, apiItems[0])
 				return
 			}
+			if q.Has(s3.QparamCORS) {
+				// perms: apc.AcePATCH
+				p.putBckCORS(w, r, apiItems[0])
+				return
+			}
+			if q.Has(s3.QparamNotification) {
+				// perms: apc.AcePATCH
+				p.putBckNotif(w, r, apiItems[0])
+				return
+			}
+			if q.Has(s3.QparamPolicy) {
+				// perms: apc.AceBckSetACL
+				p.putBckPolicy(w, r, apiItems[0])
+				return
+			}
 			// perms: apc.AceCreateBucket
 			p.putBckS3(w, r, apiItems[0])
 			return
 		}
+		if r.URL.Query().Has(s3.QparamTagging) {
+			// perms: apc.AceObjUpdate
+			p.objMetaS3(w, r, apiItems, apc.AceObjUpdate, s3.QparamTagging)
+			return
+		}
 		// perms: apc.AcePUT
 		p.putObjS3(w, r, apiItems)
 	case http.MethodPost:
@@ -143,10 +207,25 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.delMultipleObjs(w, r, apiItems[0])
 				return
 			}
+			if q.Has(s3.QparamCORS) {
+				// perms: apc.AcePATCH
+				p.delBckCORS(w, r, apiItems[0])
+				return
+			}
+			if q.Has(s3.QparamPolicy) {
+				// perms: apc.AceBckSetACL
+				p.delBckPolicy(w, r, apiItems[0])
+				return
+			}
 			// perms: apc.AceDestroyBucket
 			p.delBckS3(w, r, apiItems[0])
 			return
 		}
+		if r.URL.Query().Has(s3.QparamTagging) {
+			// perms: apc.AceObjUpdate
+			p.objMetaS3(w, r, apiItems, apc.AceObjUpdate, s3.QparamTagging)
+			return
+		}
 		// perms: apc.AceObjDELETE
 		p.delObjS3(w, r, apiItems)
 	default:
@@ -155,6 +234,25 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// OPTIONS /s3/<bucket-name>[/<object-name>]
+// (CORS preflight - see s3/cors.go)
+func (p *proxy) preflightS3(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get(cos.HdrOrigin) == "" {
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodHead,
+			http.MethodPost, http.MethodPut)
+		return
+	}
+	apiItems, err := p.parseURL(w, r, apc.URLPathS3.L, 1, true)
+	if err != nil {
+		return
+	}
+	bck := p.initByNameOnly(w, r, apiItems[0])
+	if bck == nil {
+		return
+	}
+	s3.Preflight(w, r, &bck.Props.CORS)
+}
+
 // GET /s3
 // NOTE: unlike native API, this one is limited to list only those that are currently present in the BMD.
 func (p *proxy) bckNamesFromBMD(w http.ResponseWriter) {
@@ -237,6 +335,10 @@ func (p *proxy) handleMptUpload(w http.ResponseWriter, r *http.Request, parts []
 		return
 	}
 
+	if p.rateLimit(w, r, bck, true /*s3*/) {
+		return
+	}
+
 	smap := p.owner.smap.get()
 	si, netPub, err := smap.HrwMultiHome(bck.MakeUname(objName))
 	if err != nil {
@@ -259,9 +361,8 @@ func (p *proxy) delMultipleObjs(w http.ResponseWriter, r *http.Request, bucket s
 		s3.WriteErr(w, r, err, http.StatusForbidden)
 		return
 	}
-	decoder := xml.NewDecoder(r.Body)
 	lst := &s3.Delete{}
-	if err := decoder.Decode(lst); err != nil {
+	if err := s3.ReadXML(r.Body, lst); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
@@ -285,6 +386,7 @@ func (p *proxy) delMultipleObjs(w http.ResponseWriter, r *http.Request, bucket s
 		query = make(url.Values, 1)
 	)
 	query.Set(apc.QparamProvider, apc.AIS)
+	p.usageUser(r.Header, query)
 	if err := jsoniter.Unmarshal(bt, &msg2); err != nil {
 		err = fmt.Errorf(cmn.FmtErrUnmarshal, p, "list-range action message", cos.BHead(bt), err)
 		s3.WriteErr(w, r, err, 0)
@@ -365,7 +467,7 @@ func (p *proxy) listObjectsS3(w http.ResponseWriter, r *http.Request, bucket str
 	// - "max-keys"
 	// - "prefix"
 	// - "start-after"
-	// - "delimiter" (TODO: limited support: no recursion)
+	// - "delimiter" (any, not only "/" - see LsoMsg.InitDelim)
 	// - "continuation-token" (NOTE: base64 encoded, as in: base64.StdEncoding.DecodeString(token)
 	// TODO:
 	// - "fetch-owner"
@@ -405,6 +507,57 @@ func (p *proxy) listObjectsS3(w http.ResponseWriter, r *http.Request, bucket str
 	lst = nil
 }
 
+// GET /s3/<bucket-name>?versions
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListObjectVersions.html
+// - remote buckets: backend's own version listing (aws://, gs://, az://);
+// - in-cluster: each object is its own latest (and only) version
+// - one page at a time (compare with `listObjectsS3`)
+func (p *proxy) listObjectVersionsS3(w http.ResponseWriter, r *http.Request, bucket string, q url.Values) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceObjLIST); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	amsg := &apc.ActMsg{Action: apc.ActList}
+	if p.forwardCP(w, r, amsg, lsotag+" "+bck.String()) {
+		return
+	}
+
+	lsmsg := &apc.LsoMsg{TimeFormat: time.RFC3339}
+	lsmsg.AddProps(apc.GetPropsSize, apc.GetPropsChecksum, apc.GetPropsAtime, apc.GetPropsCustom, apc.GetPropsVersion)
+	keyMarker, vidMarker := s3.FillLsoVersionsMsg(q, lsmsg)
+	amsg.Value = lsmsg
+
+	var (
+		beg      = mono.NanoTime()
+		lst, err = p.lsPage(bck, amsg, lsmsg, r.Header, p.owner.smap.get())
+	)
+	if cmn.Rom.FastV(5, cos.SmoduleS3) {
+		nlog.Infoln("lsoS3 versions", bck.Cname(""), err)
+	}
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	vlabs := map[string]string{stats.VarlabBucket: bck.Cname("")}
+	p.statsT.AddWith(
+		cos.NamedVal64{Name: stats.ListCount, Value: 1, VarLabs: vlabs},
+		cos.NamedVal64{Name: stats.ListLatency, Value: mono.SinceNano(beg), VarLabs: vlabs},
+	)
+
+	resp := s3.NewListVersionsResult(bucket, lsmsg)
+	resp.KeyMarker, resp.VersionIDMarker = keyMarker, vidMarker
+	resp.FromLsoResult(lst, lsmsg)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
 func (p *proxy) lsAllPagesS3(bck *meta.Bck, amsg *apc.ActMsg, lsmsg *apc.LsoMsg, hdr http.Header) (lst *cmn.LsoRes, _ error) {
 	smap := p.owner.smap.get()
 	for pageNum := 1; ; pageNum++ {
@@ -477,6 +630,9 @@ func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string
 	}
 
 	objName := strings.Trim(parts[1], "/")
+	if p.rateLimit(w, r, bckDst, true /*s3*/) {
+		return
+	}
 	smap := p.owner.smap.get()
 	si, err := smap.HrwName2T(bckSrc.MakeUname(objName))
 	if err != nil {
@@ -512,6 +668,10 @@ func (p *proxy) directPutObjS3(w http.ResponseWriter, r *http.Request, items []s
 		return
 	}
 
+	if p.rateLimit(w, r, bck, true /*s3*/) {
+		return
+	}
+
 	smap := p.owner.smap.get()
 	si, netPub, err := smap.HrwMultiHome(bck.MakeUname(objName))
 	if err != nil {
@@ -551,6 +711,10 @@ func (p *proxy) getObjS3(w http.ResponseWriter, r *http.Request, items []string,
 		return
 	}
 
+	if p.rateLimit(w, r, bck, true /*s3*/) {
+		return
+	}
+
 	smap := p.owner.smap.get()
 	si, netPub, err := smap.HrwMultiHome(bck.MakeUname(objName))
 	if err != nil {
@@ -632,6 +796,9 @@ func (p *proxy) headObjS3(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+	if p.rateLimit(w, r, bck, true /*s3*/) {
+		return
+	}
 	smap := p.owner.smap.get()
 	si, err := smap.HrwName2T(bck.MakeUname(objName))
 	if err != nil {
@@ -665,6 +832,10 @@ func (p *proxy) delObjS3(w http.ResponseWriter, r *http.Request, items []string)
 		return
 	}
 
+	if p.rateLimit(w, r, bck, true /*s3*/) {
+		return
+	}
+
 	smap := p.owner.smap.get()
 	si, err := smap.HrwName2T(bck.MakeUname(objName))
 	if err != nil {
@@ -693,7 +864,7 @@ func (p *proxy) getBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 	sgl.Free()
 }
 
-// GET /s3/<bucket-name>?lifecycle|cors|policy|acl
+// GET /s3/<bucket-name>?lifecycle|acl
 func (p *proxy) unsupported(w http.ResponseWriter, r *http.Request, bucket string) {
 	if _, err, ecode := meta.InitByNameOnly(bucket, p.owner.bmd); err != nil {
 		s3.WriteErr(w, r, err, ecode)
@@ -712,9 +883,8 @@ func (p *proxy) putBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 	if bck == nil {
 		return
 	}
-	decoder := xml.NewDecoder(r.Body)
 	vconf := &s3.VersioningConfiguration{}
-	if err := decoder.Decode(vconf); err != nil {
+	if err := s3.ReadXML(r.Body, vconf); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
@@ -733,10 +903,262 @@ func (p *proxy) putBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 	}
 }
 
+// GET /s3/<bucket-name>?cors
+func (p *proxy) getBckCORS(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceBckHEAD); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if !bck.Props.CORS.IsSet() {
+		err := &s3.ErrCode{Code: "NoSuchCORSConfiguration", Msg: "the CORS configuration does not exist: " + bck.Cname("")}
+		s3.WriteErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	resp := s3.NewCORSConfiguration(&bck.Props.CORS)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?cors
+func (p *proxy) putBckCORS(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AcePATCH); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	cconf := &s3.CORSConfiguration{}
+	if err := s3.ReadXML(r.Body, cconf); err != nil {
+		if err != s3.ErrPayloadMismatch {
+			err = &s3.ErrCode{Code: "MalformedXML", Msg: err.Error()}
+		}
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	conf, err := cconf.Conf()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	p.setBpropsS3(w, r, msg, bck, &cmn.BpropsToSet{CORS: &cmn.CORSConfToSet{Rules: &conf.Rules}})
+}
+
+// DELETE /s3/<bucket-name>?cors
+func (p *proxy) delBckCORS(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AcePATCH); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if bck.Props.CORS.IsSet() {
+		var none []cmn.CORSRule
+		if !p.setBpropsS3(w, r, msg, bck, &cmn.BpropsToSet{CORS: &cmn.CORSConfToSet{Rules: &none}}) {
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET /s3/<bucket-name>?notification
+func (p *proxy) getBckNotif(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceBckHEAD); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	resp := s3.NewNotificationConfiguration(bck.Bucket(), &bck.Props.Events)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?notification
+func (p *proxy) putBckNotif(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AcePATCH); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	nconf := &s3.NotificationConfiguration{}
+	if err := s3.ReadXML(r.Body, nconf); err != nil {
+		if err != s3.ErrPayloadMismatch {
+			err = &s3.ErrCode{Code: "MalformedXML", Msg: err.Error()}
+		}
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	conf, err := nconf.Conf()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if !conf.IsSet() && !bck.Props.Events.IsSet() {
+		return
+	}
+	p.setBpropsS3(w, r, msg, bck, &cmn.BpropsToSet{Events: &cmn.EventsConfToSet{Rules: &conf.Rules}})
+}
+
+// GET /s3/<bucket-name>?policy
+// (synthesized from the bucket's access attributes - see s3/policy.go)
+func (p *proxy) getBckPolicy(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceBckHEAD); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if bck.Props.Access == apc.AccessAll {
+		err := &s3.ErrCode{Code: "NoSuchBucketPolicy", Msg: "the bucket policy does not exist: " + bck.Cname("")}
+		s3.WriteErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	policy := s3.NewPolicy(bck.Name, bck.Props.Access)
+	w.Header().Set(cos.HdrContentType, cos.ContentJSON)
+	w.Write(cos.MustMarshal(policy))
+}
+
+// PUT /s3/<bucket-name>?policy
+func (p *proxy) putBckPolicy(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceBckSetACL); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	body, err := cmn.ReadBytes(r)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	access, err := s3.ParsePolicy(body, bck.Name)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if p.setBpropsS3(w, r, msg, bck, &cmn.BpropsToSet{Access: &access}) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// DELETE /s3/<bucket-name>?policy (i.e., reset bucket access to default)
+func (p *proxy) delBckPolicy(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := p.initByNameOnly(w, r, bucket)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, apc.AceBckSetACL); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	if bck.Props.Access != apc.AccessAll {
+		access := apc.AccessAll
+		if !p.setBpropsS3(w, r, msg, bck, &cmn.BpropsToSet{Access: &access}) {
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// [PUT|GET|DELETE] /s3/<bucket-name>/<object-name>?tagging
+// GET /s3/<bucket-name>/<object-name>?attributes
+// object tags are stored (and object attributes are reported) by the owning target - see tgts3.go
+func (p *proxy) objMetaS3(w http.ResponseWriter, r *http.Request, items []string, perms apc.AccessAttrs, what string) {
+	if len(items) < 2 {
+		s3.WriteErr(w, r, errS3BckObj, 0)
+		return
+	}
+	bck := p.initByNameOnly(w, r, items[0] /*bucket*/)
+	if bck == nil {
+		return
+	}
+	if err := p.access(r.Header, bck, perms); err != nil {
+		s3.WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	objName := s3.ObjName(items)
+	if err := cmn.ValidOname(objName); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if p.rateLimit(w, r, bck, true /*s3*/) {
+		return
+	}
+	smap := p.owner.smap.get()
+	si, err := smap.HrwName2T(bck.MakeUname(objName))
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleS3) {
+		nlog.Infoln(r.Method, what, bck.Cname(objName), "=>", si.StringEx())
+	}
+	started := time.Now()
+	redirectURL := p.redirectURL(r, si, started, cmn.NetIntraControl)
+	p.s3Redirect(w, r, si, redirectURL, bck.Name)
+}
+
 //
 // misc. utils
 //
 
+// make, validate, and set new bucket props (compare with putBckVersioningS3)
+func (p *proxy) setBpropsS3(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg, bck *meta.Bck, toSet *cmn.BpropsToSet) bool {
+	nprops, err := p.makeNewBckProps(bck, toSet)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return false
+	}
+	if _, err := p.setBprops(msg, bck, nprops); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return false
+	}
+	return true
+}
+
 func (p *proxy) initByNameOnly(w http.ResponseWriter, r *http.Request, bucket string) *meta.Bck {
 	bck, err, ecode := meta.InitByNameOnly(bucket, p.owner.bmd)
 	if err != nil {
@@ -809,12 +809,14 @@ func (p *proxy) validateECConf(bck *meta.Bck, confToSet *cmn.ECConfToSet, currCo
 	}
 
 	if currConf.Enabled {
-		err := fmt.Errorf("%s: EC is already enabled on the bucket %s", p, bck.Cname(""))
 		if newConf.DataSlices != currConf.DataSlices || newConf.ParitySlices != currConf.ParitySlices {
-			// Changing data or parity slice count on the fly is unsupported
-			return err
+			// re-encoding (see _reEC)
+			nlog.Infof("%s: re-encoding %s: (D=%d, P=%d) => (D=%d, P=%d)", p, bck.Cname(""),
+				currConf.DataSlices, currConf.ParitySlices, newConf.DataSlices, newConf.ParitySlices)
+		} else {
+			err := fmt.Errorf("%s: EC is already enabled on the bucket %s", p, bck.Cname(""))
+			nlog.Warningf("%v: old %+v, new %+v", err, currConf, newConf)
 		}
-		nlog.Warningf("%v: old %+v, new %+v", err, currConf, newConf)
 	}
 
 	smap := p.owner.smap.get()
@@ -1105,10 +1107,10 @@ func (p *proxy) makeNewBckProps(bck *meta.Bck, propsToUpdate *cmn.BpropsToSet, c
 		}
 	}
 	if bprops.EC.Enabled && nprops.EC.Enabled {
-		sameSlices := bprops.EC.DataSlices == nprops.EC.DataSlices && bprops.EC.ParitySlices == nprops.EC.ParitySlices
-		sameLimit := bprops.EC.ObjSizeLimit == nprops.EC.ObjSizeLimit
-		if !sameSlices || (!sameLimit && !propsToUpdate.Force) {
-			err = fmt.Errorf("%s: once enabled, EC configuration can be only disabled but cannot change", p.si)
+		// NOTE: changing the number of data and/or parity slices is allowed and
+		// results in re-encoding the bucket (see _reEC)
+		if bprops.EC.ObjSizeLimit != nprops.EC.ObjSizeLimit && !propsToUpdate.Force {
+			err = fmt.Errorf("%s: once enabled, EC object size limit cannot change (%s)", p.si, bck.Cname(""))
 			return
 		}
 	} else if nprops.EC.Enabled {
@@ -1170,6 +1172,21 @@ func (p *proxy) initBackendProp(nprops *cmn.Bprops) (err error) {
 	return
 }
 
+// replication destination: must differ from the source (and its backend), must not
+// replicate back to the source (direct cycle); remote ais alias resolves to UUID
+func (*proxy) initReplProp(bck, dst *meta.Bck, nprops *cmn.Bprops) error {
+	if dst.Bucket().Equal(bck.Bucket()) || dst.Bucket().Equal(&nprops.BackendBck) {
+		return fmt.Errorf("%s: cannot replicate bucket to itself (%s)", bck.Cname(""), dst.Cname(""))
+	}
+	if repl := &dst.Props.Replication; repl.Enabled {
+		if back, err := repl.DestBck(); err == nil && back.Equal(bck.Bucket()) {
+			return fmt.Errorf("%s: cyclic replication (%s already replicates to %s)", bck.Cname(""), dst.Cname(""), repl.Dest)
+		}
+	}
+	nprops.Replication.Dest = dst.Cname("")
+	return nil
+}
+
 /////////////
 // _tcbfin //
 /////////////
@@ -0,0 +1,195 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Get object attributes
+// - https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectAttributes.html
+// - requested attributes: `x-amz-object-attributes` (comma-separated, required)
+// - `ObjectParts`: only for objects assembled via multipart upload; part sizes are
+//   reported for in-cluster objects (see xattr.go), part count otherwise (from the ETag's "-N" suffix)
+
+// object attributes (names)
+const (
+	ObjAttrETag         = "ETag"
+	ObjAttrChecksum     = "Checksum"
+	ObjAttrObjectParts  = "ObjectParts"
+	ObjAttrStorageClass = "StorageClass"
+	ObjAttrObjectSize   = "ObjectSize"
+)
+
+const dfltMaxParts = 1000
+
+type (
+	ObjAttrsReq struct {
+		attrs      cos.StrSet
+		MaxParts   int
+		PartMarker int
+	}
+
+	// NOTE: do not rename (see types.go)
+	GetObjectAttributesResponse struct {
+		Ns           string        `xml:"xmlns,attr"`
+		ETag         string        `xml:"ETag,omitempty"`
+		Checksum     *ObjChecksum  `xml:"Checksum,omitempty"`
+		ObjectParts  *ObjPartsInfo `xml:"ObjectParts,omitempty"`
+		StorageClass string        `xml:"StorageClass,omitempty"`
+		ObjectSize   *int64        `xml:"ObjectSize,omitempty"`
+	}
+	ObjChecksum struct {
+		ChecksumCRC32C string `xml:"ChecksumCRC32C,omitempty"`
+	}
+	ObjPartsInfo struct {
+		TotalPartsCount      int       `xml:"TotalPartsCount"`
+		PartNumberMarker     int       `xml:"PartNumberMarker"`
+		NextPartNumberMarker int       `xml:"NextPartNumberMarker"`
+		MaxParts             int       `xml:"MaxParts"`
+		IsTruncated          bool      `xml:"IsTruncated"`
+		Parts                []ObjPart `xml:"Part,omitempty"`
+	}
+	ObjPart struct {
+		PartNumber int32 `xml:"PartNumber"`
+		Size       int64 `xml:"Size"`
+	}
+)
+
+func errInvalidAttrs(msg string) error { return &ErrCode{Code: "InvalidArgument", Msg: msg} }
+
+func ParseObjAttrsReq(hdr http.Header) (*ObjAttrsReq, error) {
+	req := &ObjAttrsReq{attrs: make(cos.StrSet, 4), MaxParts: dfltMaxParts}
+	for _, v := range hdr.Values(cos.S3HdrObjAttrs) {
+		for _, name := range strings.Split(v, ",") {
+			name = strings.TrimSpace(name)
+			switch name {
+			case "":
+			case ObjAttrETag, ObjAttrChecksum, ObjAttrObjectParts, ObjAttrStorageClass, ObjAttrObjectSize:
+				req.attrs.Add(name)
+			default:
+				return nil, errInvalidAttrs("invalid object attribute " + strconv.Quote(name))
+			}
+		}
+	}
+	if len(req.attrs) == 0 {
+		return nil, errInvalidAttrs("missing " + cos.S3HdrObjAttrs + " header")
+	}
+	if s := hdr.Get(cos.S3HdrMaxParts); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return nil, errInvalidAttrs("invalid " + cos.S3HdrMaxParts + " " + strconv.Quote(s))
+		}
+		req.MaxParts = min(n, dfltMaxParts)
+	}
+	if s := hdr.Get(cos.S3HdrPartNumberMarker); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 || n > MaxPartsPerUpload {
+			return nil, errInvalidAttrs("invalid " + cos.S3HdrPartNumberMarker + " " + strconv.Quote(s))
+		}
+		req.PartMarker = n
+	}
+	return req, nil
+}
+
+func (req *ObjAttrsReq) Has(name string) bool { return req.attrs.Contains(name) }
+
+// given object attributes and, for in-cluster objects, the object's FQN
+func NewObjAttrsResult(req *ObjAttrsReq, oa *cmn.ObjAttrs, fqn string) (*GetObjectAttributesResponse, error) {
+	r := &GetObjectAttributesResponse{Ns: s3Namespace}
+	etag, _ := oa.GetCustomKey(cmn.ETag)
+	etag = cmn.UnquoteCEV(etag)
+	if req.Has(ObjAttrETag) {
+		r.ETag = etag
+	}
+	if req.Has(ObjAttrChecksum) {
+		if v := crc32cS3(oa); v != "" {
+			r.Checksum = &ObjChecksum{ChecksumCRC32C: v}
+		}
+	}
+	if req.Has(ObjAttrStorageClass) {
+		r.StorageClass = StorageClass
+	}
+	if req.Has(ObjAttrObjectSize) {
+		size := oa.Size
+		r.ObjectSize = &size
+	}
+	if req.Has(ObjAttrObjectParts) {
+		parts, err := req.parts(etag, fqn)
+		if err != nil {
+			return nil, err
+		}
+		r.ObjectParts = parts
+	}
+	return r, nil
+}
+
+// S3 CRC32C is base64-encoded big-endian; aistore's crc32c is hex (see cos.CksumHash)
+func crc32cS3(oa *cmn.ObjAttrs) string {
+	if v, ok := oa.GetCustomKey(cmn.CRC32CObjMD); ok && v != "" {
+		return v
+	}
+	cksum := oa.Checksum()
+	if cksum == nil || cksum.Ty() != cos.ChecksumCRC32C {
+		return ""
+	}
+	b, err := hex.DecodeString(cksum.Val())
+	if err != nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func (req *ObjAttrsReq) parts(etag, fqn string) (*ObjPartsInfo, error) {
+	var mpt *mpt
+	if fqn != "" {
+		var err error
+		if mpt, err = loadMptXattr(fqn); err != nil {
+			return nil, err
+		}
+	}
+	if mpt == nil {
+		// not a multipart upload, or not assembled in-cluster
+		i := strings.LastIndexByte(etag, cmn.AwsMultipartDelim[0])
+		if i < 0 {
+			return nil, nil
+		}
+		cnt, err := strconv.Atoi(etag[i+1:])
+		if err != nil || cnt <= 0 {
+			return nil, nil
+		}
+		return &ObjPartsInfo{TotalPartsCount: cnt, PartNumberMarker: req.PartMarker, MaxParts: req.MaxParts}, nil
+	}
+
+	info := &ObjPartsInfo{TotalPartsCount: len(mpt.parts), PartNumberMarker: req.PartMarker, MaxParts: req.MaxParts}
+	for _, part := range mpt.parts { // sorted by part number
+		if int(part.Num) <= req.PartMarker {
+			continue
+		}
+		if len(info.Parts) == req.MaxParts {
+			info.IsTruncated = true
+			break
+		}
+		info.Parts = append(info.Parts, ObjPart{PartNumber: part.Num, Size: part.Size})
+		info.NextPartNumberMarker = int(part.Num)
+	}
+	return info, nil
+}
+
+func (r *GetObjectAttributesResponse) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
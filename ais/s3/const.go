@@ -9,14 +9,22 @@ const (
 	QparamVersioning        = "versioning"
 	QparamLifecycle         = "lifecycle"
 	QparamCORS              = "cors"
+	QparamNotification      = "notification"
 	QparamPolicy            = "policy"
 	QparamACL               = "acl"
+	QparamTagging           = "tagging"
 	QparamMultiDelete       = "delete"
 	QparamMaxKeys           = "max-keys"
 	QparamPrefix            = "prefix"
 	QparamContinuationToken = "continuation-token"
 	QparamStartAfter        = "start-after"
 	QparamDelimiter         = "delimiter"
+	QparamAttributes        = "attributes"
+
+	// list object versions
+	QparamVersions        = "versions"
+	QparamKeyMarker       = "key-marker"
+	QparamVersionIDMarker = "version-id-marker"
 
 	// multipart
 	QparamMptUploads        = "uploads"
@@ -42,6 +50,9 @@ const (
 
 	s3Namespace = "http://s3.amazonaws.com/doc/2006-03-01"
 
+	StorageClass = "STANDARD"
+	nullVersion  = "null"
+
 	AISRegion = "ais"
 	AISServer = "AIStore"
 )
@@ -0,0 +1,150 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Bucket CORS configuration
+// - https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketCors.html
+// - stored as bucket property (cmn.CORSConf); the rules are matched by cmn.CORSConf.Match
+
+type (
+	CORSConfiguration struct {
+		XMLName xml.Name   `xml:"CORSConfiguration"`
+		Ns      string     `xml:"xmlns,attr,omitempty"`
+		Rules   []CORSRule `xml:"CORSRule"`
+	}
+	CORSRule struct {
+		ID            string   `xml:"ID,omitempty"`
+		AllowedOrigin []string `xml:"AllowedOrigin"`
+		AllowedMethod []string `xml:"AllowedMethod"`
+		AllowedHeader []string `xml:"AllowedHeader,omitempty"`
+		ExposeHeader  []string `xml:"ExposeHeader,omitempty"`
+		MaxAgeSeconds int      `xml:"MaxAgeSeconds,omitempty"`
+	}
+)
+
+func NewCORSConfiguration(conf *cmn.CORSConf) *CORSConfiguration {
+	c := &CORSConfiguration{Ns: s3Namespace, Rules: make([]CORSRule, len(conf.Rules))}
+	for i := range conf.Rules {
+		rule := &conf.Rules[i]
+		c.Rules[i] = CORSRule{
+			ID:            rule.ID,
+			AllowedOrigin: rule.AllowedOrigins,
+			AllowedMethod: rule.AllowedMethods,
+			AllowedHeader: rule.AllowedHeaders,
+			ExposeHeader:  rule.ExposeHeaders,
+			MaxAgeSeconds: rule.MaxAgeSeconds,
+		}
+	}
+	return c
+}
+
+func (c *CORSConfiguration) Conf() (conf cmn.CORSConf, err error) {
+	if len(c.Rules) == 0 {
+		return conf, &ErrCode{Code: "MalformedXML", Msg: "CORS configuration must have at least one rule"}
+	}
+	conf.Rules = make([]cmn.CORSRule, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		conf.Rules[i] = cmn.CORSRule{
+			ID:             rule.ID,
+			AllowedOrigins: rule.AllowedOrigin,
+			AllowedMethods: rule.AllowedMethod,
+			AllowedHeaders: rule.AllowedHeader,
+			ExposeHeaders:  rule.ExposeHeader,
+			MaxAgeSeconds:  rule.MaxAgeSeconds,
+		}
+	}
+	if err := conf.ValidateAsProps(); err != nil {
+		return conf, &ErrCode{Code: "InvalidRequest", Msg: err.Error()}
+	}
+	return conf, nil
+}
+
+func (c *CORSConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(c)
+	debug.AssertNoErr(err)
+}
+
+// actual (non-preflight) cross-origin request
+// - no matching rule: no CORS headers (and the browser, not the server, will deny access)
+func SetCORS(hdr http.Header, r *http.Request, conf *cmn.CORSConf) {
+	origin := r.Header.Get(cos.HdrOrigin)
+	if origin == "" || !conf.IsSet() {
+		return
+	}
+	rule := conf.Match(origin, r.Method, nil)
+	if rule == nil {
+		return
+	}
+	allowOrigin(hdr, origin, rule)
+	if len(rule.ExposeHeaders) > 0 {
+		hdr.Set(cos.HdrACExposeHeaders, strings.Join(rule.ExposeHeaders, ", "))
+	}
+}
+
+// OPTIONS (preflight)
+func Preflight(w http.ResponseWriter, r *http.Request, conf *cmn.CORSConf) {
+	var (
+		origin  = r.Header.Get(cos.HdrOrigin)
+		method  = r.Header.Get(cos.HdrACRequestMethod)
+		headers []string
+	)
+	if origin == "" || method == "" {
+		err := errors.New("insufficient information: origin and access-control-request-method headers are required")
+		WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if s := r.Header.Get(cos.HdrACRequestHeaders); s != "" {
+		for _, h := range strings.Split(s, ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				headers = append(headers, h)
+			}
+		}
+	}
+	rule := conf.Match(origin, method, headers)
+	if rule == nil {
+		err := &ErrCode{Code: "AccessForbidden", Msg: "CORSResponse: this CORS request is not allowed"}
+		WriteErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	hdr := w.Header()
+	allowOrigin(hdr, origin, rule)
+	hdr.Set(cos.HdrACAllowMethods, strings.Join(rule.AllowedMethods, ", "))
+	if len(headers) > 0 {
+		hdr.Set(cos.HdrACAllowHeaders, strings.Join(headers, ", "))
+	}
+	if len(rule.ExposeHeaders) > 0 {
+		hdr.Set(cos.HdrACExposeHeaders, strings.Join(rule.ExposeHeaders, ", "))
+	}
+	if rule.MaxAgeSeconds > 0 {
+		hdr.Set(cos.HdrACMaxAge, strconv.Itoa(rule.MaxAgeSeconds))
+	}
+	hdr.Add(cos.HdrVary, cos.HdrACRequestHeaders)
+	hdr.Add(cos.HdrVary, cos.HdrACRequestMethod)
+}
+
+func allowOrigin(hdr http.Header, origin string, rule *cmn.CORSRule) {
+	if rule.AnyOrigin() {
+		hdr.Set(cos.HdrACAllowOrigin, "*")
+	} else {
+		hdr.Set(cos.HdrACAllowOrigin, origin)
+		hdr.Set(cos.HdrACAllowCredentials, "true")
+	}
+	hdr.Add(cos.HdrVary, cos.HdrOrigin)
+}
@@ -6,6 +6,7 @@ package s3
 
 import (
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -20,12 +21,22 @@ import (
 
 const ErrPrefix = "aws-error"
 
-type Error struct {
-	Code      string
-	Message   string
-	Resource  string
-	RequestID string `xml:"RequestId"`
-}
+type (
+	Error struct {
+		Code      string
+		Message   string
+		Resource  string
+		RequestID string `xml:"RequestId"`
+	}
+
+	// S3 error code that cannot be otherwise derived (see WriteErr)
+	ErrCode struct {
+		Code string
+		Msg  string
+	}
+)
+
+func (e *ErrCode) Error() string { return e.Msg }
 
 func (e *Error) mustMarshal(sgl *memsys.SGL) {
 	sgl.Write([]byte(xml.Header))
@@ -60,11 +71,18 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, ecode int) {
 		allocated = true
 	}
 	out.Message = in.Message
+	var ec *ErrCode
 	switch {
+	case errors.As(err, &ec):
+		out.Code = ec.Code
 	case cmn.IsErrBucketAlreadyExists(err):
 		out.Code = "BucketAlreadyExists"
 	case cmn.IsErrBckNotFound(err):
 		out.Code = "NoSuchBucket"
+	case cmn.IsErrRateLimit(err):
+		out.Code = "SlowDown"
+	case cmn.IsErrPrecondFailed(err):
+		out.Code = "PreconditionFailed"
 	case in.TypeCode != "":
 		out.Code = in.TypeCode
 	default:
@@ -0,0 +1,159 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Bucket notification configuration
+// - https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketNotificationConfiguration.html
+// - stored as bucket property (cmn.EventsConf): one event rule per topic, queue, or cloud-function configuration;
+// - destination (topic, queue, or function) that is an http(s) URL becomes the rule's webhook;
+//   any other destination (e.g., SNS or SQS ARN) - local queue (see api.GetEvents);
+//   in the latter case, the destination itself is not retained;
+// - event types: s3:ObjectCreated:*, s3:ObjectRemoved:* (and their subtypes), as well as
+//   aistore-specific ObjectEvicted and ColdGetCompleted;
+// - empty configuration removes all notifications.
+
+const (
+	s3EvCreated = "s3:ObjectCreated:"
+	s3EvRemoved = "s3:ObjectRemoved:"
+
+	notifArnPrefix = "arn:ais:events:" // (local queue)
+)
+
+type (
+	NotificationConfiguration struct {
+		XMLName  xml.Name           `xml:"NotificationConfiguration"`
+		Ns       string             `xml:"xmlns,attr,omitempty"`
+		Topics   []NotificationConf `xml:"TopicConfiguration,omitempty"`
+		Queues   []NotificationConf `xml:"QueueConfiguration,omitempty"`
+		Lambdas  []NotificationConf `xml:"CloudFunctionConfiguration,omitempty"`
+		EventBus *struct{}          `xml:"EventBridgeConfiguration,omitempty"`
+	}
+	NotificationConf struct {
+		ID            string       `xml:"Id,omitempty"`
+		Topic         string       `xml:"Topic,omitempty"`
+		Queue         string       `xml:"Queue,omitempty"`
+		CloudFunction string       `xml:"CloudFunction,omitempty"`
+		Events        []string     `xml:"Event"`
+		Filter        *NotifFilter `xml:"Filter,omitempty"`
+	}
+	NotifFilter struct {
+		Key struct {
+			Rules []NotifFilterRule `xml:"FilterRule"`
+		} `xml:"S3Key"`
+	}
+	NotifFilterRule struct {
+		Name  string `xml:"Name"`
+		Value string `xml:"Value"`
+	}
+)
+
+func NewNotificationConfiguration(bck *cmn.Bck, conf *cmn.EventsConf) *NotificationConfiguration {
+	c := &NotificationConfiguration{Ns: s3Namespace}
+	for i := range conf.Rules {
+		rule := &conf.Rules[i]
+		nc := NotificationConf{ID: rule.ID}
+		events := rule.Events
+		if len(events) == 0 {
+			events = []string{cmn.EventObjCreated, cmn.EventObjRemoved, cmn.EventObjEvicted, cmn.EventColdGet}
+		}
+		for _, ev := range events {
+			switch ev {
+			case cmn.EventObjCreated:
+				nc.Events = append(nc.Events, s3EvCreated+"*")
+			case cmn.EventObjRemoved:
+				nc.Events = append(nc.Events, s3EvRemoved+"*")
+			default:
+				nc.Events = append(nc.Events, ev)
+			}
+		}
+		if rule.Prefix != "" || rule.Suffix != "" {
+			nc.Filter = &NotifFilter{}
+			if rule.Prefix != "" {
+				nc.Filter.Key.Rules = append(nc.Filter.Key.Rules, NotifFilterRule{Name: "prefix", Value: rule.Prefix})
+			}
+			if rule.Suffix != "" {
+				nc.Filter.Key.Rules = append(nc.Filter.Key.Rules, NotifFilterRule{Name: "suffix", Value: rule.Suffix})
+			}
+		}
+		if rule.Webhook != "" {
+			nc.Topic = rule.Webhook
+			c.Topics = append(c.Topics, nc)
+		} else {
+			nc.Queue = notifArnPrefix + bck.Name + ":" + rule.ID
+			c.Queues = append(c.Queues, nc)
+		}
+	}
+	return c
+}
+
+func (c *NotificationConfiguration) Conf() (conf cmn.EventsConf, err error) {
+	all := make([]NotificationConf, 0, len(c.Topics)+len(c.Queues)+len(c.Lambdas))
+	all = append(all, c.Topics...)
+	all = append(all, c.Queues...)
+	all = append(all, c.Lambdas...)
+	for i := range all {
+		nc := &all[i]
+		rule := cmn.EventRule{ID: nc.ID}
+		if rule.ID == "" {
+			rule.ID = "rule-" + strconv.Itoa(i+1)
+		}
+		if len(nc.Events) == 0 {
+			return conf, &ErrCode{Code: "MalformedXML", Msg: "notification configuration " + rule.ID + ": missing event(s)"}
+		}
+		for _, ev := range nc.Events {
+			var aev string
+			switch {
+			case strings.HasPrefix(ev, s3EvCreated):
+				aev = cmn.EventObjCreated
+			case strings.HasPrefix(ev, s3EvRemoved):
+				aev = cmn.EventObjRemoved
+			default:
+				aev = ev // (validated below)
+			}
+			if !cos.StringInSlice(aev, rule.Events) {
+				rule.Events = append(rule.Events, aev)
+			}
+		}
+		if nc.Filter != nil {
+			for _, fr := range nc.Filter.Key.Rules {
+				switch strings.ToLower(fr.Name) {
+				case "prefix":
+					rule.Prefix = fr.Value
+				case "suffix":
+					rule.Suffix = fr.Value
+				default:
+					return conf, &ErrCode{Code: "InvalidArgument", Msg: "filter rule name must be either prefix or suffix"}
+				}
+			}
+		}
+		for _, dst := range []string{nc.Topic, nc.Queue, nc.CloudFunction} {
+			if cos.IsHTTPS(dst) || cos.IsHT(dst) {
+				rule.Webhook = dst
+			}
+		}
+		conf.Rules = append(conf.Rules, rule)
+	}
+	if err := conf.ValidateAsProps(); err != nil {
+		return conf, &ErrCode{Code: "InvalidArgument", Msg: err.Error()}
+	}
+	return conf, nil
+}
+
+func (c *NotificationConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(c)
+	debug.AssertNoErr(err)
+}
@@ -0,0 +1,67 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3_test
+
+import (
+	"encoding/xml"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bucket notification configuration", func() {
+	const body = `<NotificationConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <QueueConfiguration>
+    <Id>uploads</Id>
+    <Queue>arn:aws:sqs:us-east-1:123456789012:uploads</Queue>
+    <Event>s3:ObjectCreated:Put</Event>
+    <Event>s3:ObjectCreated:CompleteMultipartUpload</Event>
+    <Filter><S3Key>
+      <FilterRule><Name>prefix</Name><Value>images/</Value></FilterRule>
+      <FilterRule><Name>Suffix</Name><Value>.jpg</Value></FilterRule>
+    </S3Key></Filter>
+  </QueueConfiguration>
+  <TopicConfiguration>
+    <Topic>https://example.com/hook</Topic>
+    <Event>s3:ObjectRemoved:*</Event>
+  </TopicConfiguration>
+</NotificationConfiguration>`
+
+	It("should convert to event rules and back", func() {
+		nconf := &s3.NotificationConfiguration{}
+		Expect(xml.Unmarshal([]byte(body), nconf)).NotTo(HaveOccurred())
+		conf, err := nconf.Conf()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.Rules).To(Equal([]cmn.EventRule{
+			{ID: "rule-1", Events: []string{cmn.EventObjRemoved}, Webhook: "https://example.com/hook"},
+			{ID: "uploads", Events: []string{cmn.EventObjCreated}, Prefix: "images/", Suffix: ".jpg"},
+		}))
+
+		bck := &cmn.Bck{Name: "b", Provider: apc.AIS}
+		out := s3.NewNotificationConfiguration(bck, &conf)
+		Expect(out.Topics).To(HaveLen(1))
+		Expect(out.Topics[0].Topic).To(Equal("https://example.com/hook"))
+		Expect(out.Queues).To(HaveLen(1))
+		Expect(out.Queues[0].Events).To(Equal([]string{"s3:ObjectCreated:*"}))
+		Expect(out.Queues[0].Filter.Key.Rules).To(HaveLen(2))
+	})
+
+	It("should reject unsupported events", func() {
+		nconf := &s3.NotificationConfiguration{Queues: []s3.NotificationConf{
+			{ID: "x", Queue: "arn:aws:sqs:q", Events: []string{"s3:ObjectRestore:Completed"}},
+		}}
+		_, err := nconf.Conf()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should accept empty configuration", func() {
+		conf, err := (&s3.NotificationConfiguration{}).Conf()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(conf.IsSet()).To(BeFalse())
+	})
+})
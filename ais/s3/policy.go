@@ -0,0 +1,243 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Bucket policy
+// - https://docs.aws.amazon.com/AmazonS3/latest/userguide/bucket-policies.html
+// - minimal evaluator that maps a given policy onto bucket access attributes (apc.AccessAttrs)
+//   which, in turn, apply to all users - hence, principal must be "*" (per-user permissions
+//   are the domain of AuthN);
+// - resulting access = (union of all allowed actions) minus (union of all denied ones);
+//   a policy that has no "Allow" statements denies the listed actions and allows everything else
+//   (apc.AccessAll minus denied) - in other words, each PUT ?policy replaces (not amends)
+//   the bucket's access, the same way DELETE ?policy resets it;
+// - since access attributes are bucket-wide, resource must be the bucket itself and/or all of
+//   its objects: "arn:aws:s3:::<bucket>" and "arn:aws:s3:::<bucket>/*" (prefixes are not supported);
+// - the mapping is coarse: denying an action denies all operations that share the same
+//   access bit (e.g., "s3:GetObjectTagging" and "s3:GetObject" both require apc.AceObjHEAD);
+// - `Condition`, `NotAction`, `NotPrincipal`, and `NotResource` are not supported.
+
+const (
+	PolicyVersion = "2012-10-17"
+	MaxPolicySize = 20 * cos.KiB
+
+	policyAllow  = "Allow"
+	policyDeny   = "Deny"
+	policyAny    = "*"
+	policyArnPre = "arn:aws:s3:::"
+	policyS3All  = "s3:*"
+)
+
+type (
+	Policy struct {
+		Version   string            `json:"Version"`
+		ID        string            `json:"Id,omitempty"`
+		Statement []PolicyStatement `json:"Statement"`
+	}
+	PolicyStatement struct {
+		Sid          string              `json:"Sid,omitempty"`
+		Effect       string              `json:"Effect"`
+		Principal    jsoniter.RawMessage `json:"Principal,omitempty"`
+		Action       policyList          `json:"Action"`
+		Resource     policyList          `json:"Resource"`
+		Condition    jsoniter.RawMessage `json:"Condition,omitempty"`
+		NotAction    jsoniter.RawMessage `json:"NotAction,omitempty"`
+		NotPrincipal jsoniter.RawMessage `json:"NotPrincipal,omitempty"`
+		NotResource  jsoniter.RawMessage `json:"NotResource,omitempty"`
+	}
+
+	// JSON string or list of strings
+	policyList []string
+)
+
+// supported actions
+var policyActions = map[string]apc.AccessAttrs{
+	"s3:getobject":                  apc.AceGET | apc.AceObjHEAD,
+	"s3:getobjecttagging":           apc.AceObjHEAD,
+	"s3:putobject":                  apc.AcePUT,
+	"s3:putobjecttagging":           apc.AceObjUpdate,
+	"s3:deleteobject":               apc.AceObjDELETE,
+	"s3:deleteobjecttagging":        apc.AceObjUpdate,
+	"s3:abortmultipartupload":       apc.AcePUT,
+	"s3:listbucket":                 apc.AceObjLIST | apc.AceBckHEAD,
+	"s3:listbucketmultipartuploads": apc.AceObjLIST,
+	"s3:getbucketcors":              apc.AceBckHEAD,
+	"s3:putbucketcors":              apc.AcePATCH,
+	"s3:getbucketnotification":      apc.AceBckHEAD,
+	"s3:putbucketnotification":      apc.AcePATCH,
+	"s3:getbucketversioning":        apc.AceBckHEAD,
+	"s3:putbucketversioning":        apc.AcePATCH,
+	"s3:getbucketpolicy":            apc.AceBckHEAD,
+	"s3:putbucketpolicy":            apc.AceBckSetACL,
+	"s3:deletebucketpolicy":         apc.AceBckSetACL,
+	"s3:deletebucket":               apc.AceDestroyBucket,
+}
+
+// canonical names (for GET ?policy)
+var policyActionNames = []string{
+	"s3:GetObject", "s3:GetObjectTagging", "s3:PutObject", "s3:PutObjectTagging", "s3:DeleteObject",
+	"s3:DeleteObjectTagging", "s3:AbortMultipartUpload", "s3:ListBucket", "s3:ListBucketMultipartUploads",
+	"s3:GetBucketCORS", "s3:PutBucketCORS", "s3:GetBucketNotification", "s3:PutBucketNotification",
+	"s3:GetBucketVersioning", "s3:PutBucketVersioning",
+	"s3:GetBucketPolicy", "s3:PutBucketPolicy", "s3:DeleteBucketPolicy", "s3:DeleteBucket",
+}
+
+func (l *policyList) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := jsoniter.Unmarshal(b, &s); err == nil {
+		*l = policyList{s}
+		return nil
+	}
+	var list []string
+	if err := jsoniter.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+func errMalformedPolicy(format string, a ...any) error {
+	return &ErrCode{Code: "MalformedPolicy", Msg: fmt.Sprintf(format, a...)}
+}
+
+// PUT ?policy: parse, validate, and evaluate
+func ParsePolicy(body []byte, bucket string) (apc.AccessAttrs, error) {
+	if len(body) > MaxPolicySize {
+		return 0, errMalformedPolicy("policy exceeds the maximum allowed size (%d)", MaxPolicySize)
+	}
+	var policy Policy
+	if err := jsoniter.Unmarshal(body, &policy); err != nil {
+		return 0, errMalformedPolicy("failed to parse policy: %v", err)
+	}
+	if len(policy.Statement) == 0 {
+		return 0, errMalformedPolicy("missing required field Statement")
+	}
+	var (
+		allow, deny apc.AccessAttrs
+		hasAllow    bool
+	)
+	for i := range policy.Statement {
+		stmt := &policy.Statement[i]
+		access, err := stmt.eval(bucket)
+		if err != nil {
+			return 0, err
+		}
+		if stmt.Effect == policyAllow {
+			allow |= access
+			hasAllow = true
+		} else {
+			deny |= access
+		}
+	}
+	if !hasAllow {
+		allow = apc.AccessAll // deny-only
+	}
+	return allow &^ deny, nil
+}
+
+func (stmt *PolicyStatement) eval(bucket string) (access apc.AccessAttrs, err error) {
+	switch {
+	case stmt.Effect != policyAllow && stmt.Effect != policyDeny:
+		return 0, errMalformedPolicy("invalid effect %q", stmt.Effect)
+	case len(stmt.Condition) > 0 || len(stmt.NotAction) > 0 || len(stmt.NotPrincipal) > 0 || len(stmt.NotResource) > 0:
+		return 0, errMalformedPolicy("Condition, NotAction, NotPrincipal, and NotResource are not supported")
+	case len(stmt.Action) == 0:
+		return 0, errMalformedPolicy("missing required field Action")
+	case len(stmt.Resource) == 0:
+		return 0, errMalformedPolicy("missing required field Resource")
+	}
+	if err := stmt.checkPrincipal(); err != nil {
+		return 0, err
+	}
+	for _, res := range stmt.Resource {
+		if res != policyArnPre+bucket && res != policyArnPre+bucket+"/*" {
+			return 0, errMalformedPolicy("policy has invalid (or unsupported) resource %q (expecting %s%s or %s%s/*)",
+				res, policyArnPre, bucket, policyArnPre, bucket)
+		}
+	}
+	for _, action := range stmt.Action {
+		a, err := policyAction(action)
+		if err != nil {
+			return 0, err
+		}
+		access |= a
+	}
+	return access, nil
+}
+
+// "*", {"AWS": "*"}, or {"AWS": ["*"]}
+func (stmt *PolicyStatement) checkPrincipal() error {
+	var s string
+	if err := jsoniter.Unmarshal(stmt.Principal, &s); err == nil {
+		if s == policyAny {
+			return nil
+		}
+	} else {
+		var m map[string]policyList
+		if err := jsoniter.Unmarshal(stmt.Principal, &m); err == nil && len(m) == 1 {
+			if l, ok := m["AWS"]; ok && len(l) == 1 && l[0] == policyAny {
+				return nil
+			}
+		}
+	}
+	return errMalformedPolicy("invalid principal %s: only \"*\" (everyone) is currently supported", string(stmt.Principal))
+}
+
+// action name or wildcard, e.g. "s3:Get*"
+func policyAction(action string) (access apc.AccessAttrs, _ error) {
+	lc := strings.ToLower(action)
+	if lc == policyS3All || lc == policyAny {
+		return apc.AccessAll, nil
+	}
+	if prefix, ok := strings.CutSuffix(lc, policyAny); ok {
+		for name, a := range policyActions {
+			if strings.HasPrefix(name, prefix) {
+				access |= a
+			}
+		}
+	} else {
+		access = policyActions[lc]
+	}
+	if access == 0 {
+		return 0, errMalformedPolicy("policy has invalid (or unsupported) action %q", action)
+	}
+	return access, nil
+}
+
+// GET ?policy: given bucket access attributes, synthesize the policy
+// (compare with ParsePolicy above)
+func NewPolicy(bucket string, access apc.AccessAttrs) *Policy {
+	stmt := PolicyStatement{
+		Sid:       "ais-bucket-access",
+		Effect:    policyAllow,
+		Principal: jsoniter.RawMessage(`"*"`),
+		Resource:  policyList{policyArnPre + bucket, policyArnPre + bucket + "/*"},
+	}
+	if access == apc.AccessAll {
+		stmt.Action = policyList{policyS3All}
+	} else {
+		for _, name := range policyActionNames {
+			if access.Has(policyActions[strings.ToLower(name)]) {
+				stmt.Action = append(stmt.Action, name)
+			}
+		}
+		sort.Strings(stmt.Action)
+	}
+	policy := &Policy{Version: PolicyVersion, Statement: make([]PolicyStatement, 0, 1)}
+	if len(stmt.Action) > 0 {
+		policy.Statement = append(policy.Statement, stmt)
+	}
+	return policy
+}
@@ -0,0 +1,100 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3_test
+
+import (
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bucket policy and object tagging", func() {
+	const bucket = "abc"
+
+	It("should map policy onto access attributes", func() {
+		policy := `{
+		  "Version": "2012-10-17",
+		  "Statement": [
+		    {"Effect": "Allow", "Principal": "*", "Action": ["s3:GetObject", "s3:ListBucket"],
+		     "Resource": ["arn:aws:s3:::abc", "arn:aws:s3:::abc/*"]},
+		    {"Effect": "Allow", "Principal": {"AWS": "*"}, "Action": "s3:Put*", "Resource": "arn:aws:s3:::abc/*"},
+		    {"Effect": "Deny", "Principal": {"AWS": ["*"]}, "Action": "s3:PutBucketPolicy", "Resource": "arn:aws:s3:::abc"}
+		  ]
+		}`
+		access, err := s3.ParsePolicy([]byte(policy), bucket)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(access.Has(apc.AceGET | apc.AceObjHEAD | apc.AceObjLIST | apc.AceBckHEAD)).To(BeTrue())
+		Expect(access.Has(apc.AcePUT | apc.AceObjUpdate | apc.AcePATCH)).To(BeTrue())
+		Expect(access.Has(apc.AceBckSetACL)).To(BeFalse())
+		Expect(access.Has(apc.AceObjDELETE)).To(BeFalse())
+
+		// round trip
+		again, err := s3.ParsePolicy(cos.MustMarshal(s3.NewPolicy(bucket, access)), bucket)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(Equal(access))
+
+		all, err := s3.ParsePolicy(cos.MustMarshal(s3.NewPolicy(bucket, apc.AccessAll)), bucket)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(all).To(Equal(apc.AccessAll))
+	})
+
+	It("should deny listed actions and allow the rest when there are no Allow statements", func() {
+		policy := `{
+		  "Version": "2012-10-17",
+		  "Statement": [
+		    {"Effect": "Deny", "Principal": "*", "Action": ["s3:DeleteObject", "s3:DeleteBucket"],
+		     "Resource": ["arn:aws:s3:::abc", "arn:aws:s3:::abc/*"]}
+		  ]
+		}`
+		access, err := s3.ParsePolicy([]byte(policy), bucket)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(access).To(Equal(apc.AccessAll &^ (apc.AceObjDELETE | apc.AceDestroyBucket)))
+		Expect(access.Has(apc.AceGET | apc.AcePUT | apc.AceObjLIST)).To(BeTrue())
+	})
+
+	DescribeTable("should reject unsupported policies",
+		func(policy string) {
+			_, err := s3.ParsePolicy([]byte(policy), bucket)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("principal", `{"Statement": [{"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::1:root"},
+			"Action": "s3:GetObject", "Resource": "arn:aws:s3:::abc/*"}]}`),
+		Entry("resource", `{"Statement": [{"Effect": "Allow", "Principal": "*",
+			"Action": "s3:GetObject", "Resource": "arn:aws:s3:::other/*"}]}`),
+		Entry("prefix", `{"Statement": [{"Effect": "Allow", "Principal": "*",
+			"Action": "s3:GetObject", "Resource": "arn:aws:s3:::abc/public/*"}]}`),
+		Entry("object", `{"Statement": [{"Effect": "Deny", "Principal": "*",
+			"Action": "s3:DeleteObject", "Resource": "arn:aws:s3:::abc/important.txt"}]}`),
+		Entry("other bucket with the same prefix", `{"Statement": [{"Effect": "Allow", "Principal": "*",
+			"Action": "s3:GetObject", "Resource": "arn:aws:s3:::abcd/*"}]}`),
+		Entry("no arn", `{"Statement": [{"Effect": "Allow", "Principal": "*",
+			"Action": "s3:GetObject", "Resource": "abc/*"}]}`),
+		Entry("action", `{"Statement": [{"Effect": "Allow", "Principal": "*",
+			"Action": "s3:GetObjectAcl", "Resource": "arn:aws:s3:::abc/*"}]}`),
+		Entry("condition", `{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject",
+			"Resource": "arn:aws:s3:::abc/*", "Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}}]}`),
+		Entry("effect", `{"Statement": [{"Effect": "Maybe", "Principal": "*",
+			"Action": "s3:GetObject", "Resource": "arn:aws:s3:::abc/*"}]}`),
+		Entry("empty", `{"Version": "2012-10-17"}`),
+	)
+
+	It("should decode and validate tags", func() {
+		tagging, err := s3.DecodeTagging("b=2&a=1&c=")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tagging.TagSet).To(Equal([]s3.Tag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}, {Key: "c"}}))
+		Expect(tagging.Encode()).To(Equal("a=1&b=2&c="))
+
+		_, err = s3.DecodeTagging("a=1&a=2")
+		Expect(err).To(HaveOccurred())
+
+		tagging = &s3.Tagging{}
+		for i := range s3.MaxTags + 1 {
+			tagging.TagSet = append(tagging.TagSet, s3.Tag{Key: string(rune('a' + i))})
+		}
+		Expect(tagging.Validate()).To(HaveOccurred())
+	})
+})
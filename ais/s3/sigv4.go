@@ -0,0 +1,310 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// AWS Signature Version 4 - verification of (header-based and presigned) requests signed
+// with AuthN-issued access keys; see:
+// - https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+// - https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html
+// Payload checksum (x-amz-content-sha256), unless UNSIGNED-PAYLOAD or streaming (chunked), is validated
+// against the payload as it is being read (see NewPayloadReader) - by gateways for the requests they
+// serve, and by targets for the (redirected) ones that carry object data.
+
+const (
+	HeaderAlgorithm     = "X-Amz-Algorithm"
+	HeaderDate          = "X-Amz-Date"
+	HeaderExpires       = "X-Amz-Expires"
+	HeaderSignedHeaders = "X-Amz-SignedHeaders"
+	HeaderSignature     = "X-Amz-Signature"
+	HeaderContentSHA256 = "X-Amz-Content-Sha256"
+
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	sigv4Terminator = "aws4_request"
+	sigv4TimeFormat = "20060102T150405Z"
+
+	sigv4MaxSkew    = 15 * time.Minute
+	sigv4MaxExpires = 7 * 24 * time.Hour
+)
+
+var (
+	ErrSignatureMismatch = errors.New("the request signature does not match (SignatureDoesNotMatch)")
+	ErrPayloadMismatch   = &ErrCode{
+		Code: "XAmzContentSHA256Mismatch",
+		Msg:  "the provided " + HeaderContentSHA256 + " header does not match what was computed",
+	}
+)
+
+// parsed SigV4 authentication (either Authorization header or presigned query)
+type SigV4 struct {
+	AccessKeyID   string
+	date          string // YYYYMMDD
+	region        string
+	service       string
+	amzDate       string // ISO 8601 basic
+	signedHeaders []string
+	signature     string
+	expires       time.Duration // presigned only
+	presigned     bool
+}
+
+// returns nil when the request is not SigV4-signed
+func ParseSigV4(r *http.Request) (*SigV4, error) {
+	var (
+		q            = r.URL.Query()
+		sig          = &SigV4{}
+		cred, signed string
+	)
+	if q.Get(HeaderAlgorithm) == signatureV4 {
+		sig.presigned = true
+		cred = q.Get(HeaderCredentials)
+		signed = q.Get(HeaderSignedHeaders)
+		sig.signature = q.Get(HeaderSignature)
+		sig.amzDate = q.Get(HeaderDate)
+		secs, err := strconv.ParseInt(q.Get(HeaderExpires), 10, 64)
+		if err != nil || secs < 0 || time.Duration(secs)*time.Second > sigv4MaxExpires {
+			return nil, fmt.Errorf("invalid %s %q", HeaderExpires, q.Get(HeaderExpires))
+		}
+		sig.expires = time.Duration(secs) * time.Second
+	} else {
+		authorization := r.Header.Get(apc.HdrAuthorization)
+		if !strings.HasPrefix(authorization, signatureV4+" ") {
+			return nil, nil
+		}
+		for _, kv := range strings.Split(strings.TrimPrefix(authorization, signatureV4+" "), ",") {
+			k, v, _ := strings.Cut(strings.TrimSpace(kv), "=")
+			switch k {
+			case "Credential":
+				cred = v
+			case "SignedHeaders":
+				signed = v
+			case "Signature":
+				sig.signature = v
+			}
+		}
+		sig.amzDate = r.Header.Get(HeaderDate)
+	}
+
+	// Credential=<access-key-id>/<date>/<region>/<service>/aws4_request
+	parts := strings.Split(cred, "/")
+	if len(parts) != 5 || parts[4] != sigv4Terminator || parts[0] == "" {
+		return nil, fmt.Errorf("invalid SigV4 credential %q", cred)
+	}
+	sig.AccessKeyID, sig.date, sig.region, sig.service = parts[0], parts[1], parts[2], parts[3]
+	if signed == "" || sig.signature == "" || sig.amzDate == "" {
+		return nil, errors.New("incomplete SigV4 authentication: missing signed headers, signature, or date")
+	}
+	sig.signedHeaders = strings.Split(signed, ";")
+	if !cos.StringInSlice("host", sig.signedHeaders) {
+		return nil, errors.New("invalid SigV4 signed headers: 'host' is required")
+	}
+	return sig, nil
+}
+
+// Verify the signature given the secret key and the request path as signed by the client
+// (which may differ from the one being served - see feat.S3APIviaRoot)
+func (sig *SigV4) Verify(r *http.Request, secret, path string, now time.Time) error {
+	t, err := time.Parse(sigv4TimeFormat, sig.amzDate)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q", HeaderDate, sig.amzDate)
+	}
+	if !strings.HasPrefix(sig.amzDate, sig.date) {
+		return fmt.Errorf("SigV4 credential date %q vs %q", sig.date, sig.amzDate)
+	}
+	if sig.presigned {
+		if now.After(t.Add(sig.expires)) {
+			return errors.New("presigned request has expired")
+		}
+		// (otherwise, a URL signed in the future would remain valid beyond sigv4MaxExpires)
+		if t.Sub(now) > sigv4MaxSkew {
+			return fmt.Errorf("presigned request time %s is in the future (RequestTimeTooSkewed)", t.Format(time.RFC3339))
+		}
+	} else if d := now.Sub(t); d > sigv4MaxSkew || d < -sigv4MaxSkew {
+		return fmt.Errorf("request time %s is too skewed (RequestTimeTooSkewed)", t.Format(time.RFC3339))
+	}
+	creq, err := sig.canonicalRequest(r, path)
+	if err != nil {
+		return err
+	}
+	expected := sig.sign(secret, creq)
+	if !hmac.Equal([]byte(expected), []byte(sig.signature)) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+func (sig *SigV4) canonicalRequest(r *http.Request, path string) (string, error) {
+	var (
+		sb      strings.Builder
+		payload string
+	)
+	sb.WriteString(r.Method)
+	sb.WriteByte('\n')
+	sb.WriteString(uriEncode(path, false /*encode slash*/))
+	sb.WriteByte('\n')
+	sb.WriteString(sig.canonicalQuery(r.URL.Query()))
+	sb.WriteByte('\n')
+	for _, name := range sig.signedHeaders {
+		sb.WriteString(name)
+		sb.WriteByte(':')
+		sb.WriteString(headerValue(r, name))
+		sb.WriteByte('\n')
+	}
+	sb.WriteByte('\n')
+	sb.WriteString(strings.Join(sig.signedHeaders, ";"))
+	sb.WriteByte('\n')
+	if sig.presigned {
+		payload = cos.Left(r.URL.Query().Get(HeaderContentSHA256), unsignedPayload)
+	} else if payload = r.Header.Get(HeaderContentSHA256); payload == "" {
+		return "", fmt.Errorf("missing %s header", HeaderContentSHA256)
+	}
+	sb.WriteString(payload)
+	return sb.String(), nil
+}
+
+// payload SHA256 to validate (hex), if any - empty for UNSIGNED-PAYLOAD and streaming payloads
+func PayloadSHA256(r *http.Request) string {
+	v := r.Header.Get(HeaderContentSHA256)
+	if v == "" {
+		v = r.URL.Query().Get(HeaderContentSHA256)
+	}
+	if len(v) != sha256.Size*2 {
+		return ""
+	}
+	if _, err := hex.DecodeString(v); err != nil {
+		return ""
+	}
+	return strings.ToLower(v)
+}
+
+// validates payload SHA256 upon reading it in its entirety (returns ErrPayloadMismatch instead of io.EOF)
+type payloadReader struct {
+	r        io.ReadCloser
+	h        hash.Hash
+	expected string
+}
+
+func NewPayloadReader(r io.ReadCloser, expected string) io.ReadCloser {
+	return &payloadReader{r: r, h: sha256.New(), expected: expected}
+}
+
+func (pr *payloadReader) Read(b []byte) (n int, err error) {
+	n, err = pr.r.Read(b)
+	pr.h.Write(b[:n])
+	if err == io.EOF && hex.EncodeToString(pr.h.Sum(nil)) != pr.expected {
+		err = ErrPayloadMismatch
+	}
+	return n, err
+}
+
+func (pr *payloadReader) Close() error { return pr.r.Close() }
+
+// read (and validate) XML payload in its entirety
+func ReadXML(r io.Reader, v any) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(b, v)
+}
+
+func (sig *SigV4) canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		if sig.presigned && k == HeaderSignature {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		vals := append([]string(nil), q[k]...)
+		sort.Strings(vals)
+		for _, v := range vals {
+			parts = append(parts, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func headerValue(r *http.Request, name string) string {
+	switch name {
+	case "host":
+		return r.Host
+	case "content-length":
+		if v := r.Header.Get(cos.HdrContentLength); v != "" {
+			return v
+		}
+		return strconv.FormatInt(r.ContentLength, 10)
+	}
+	vals := r.Header.Values(name)
+	trimmed := make([]string, len(vals))
+	for i, v := range vals {
+		trimmed[i] = strings.Join(strings.Fields(v), " ")
+	}
+	return strings.Join(trimmed, ",")
+}
+
+func (sig *SigV4) sign(secret, creq string) string {
+	var (
+		scope = sig.date + "/" + sig.region + "/" + sig.service + "/" + sigv4Terminator
+		hash  = sha256.Sum256([]byte(creq))
+		sts   = signatureV4 + "\n" + sig.amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+		key   = hmacSHA256([]byte("AWS4"+secret), sig.date)
+	)
+	key = hmacSHA256(key, sig.region)
+	key = hmacSHA256(key, sig.service)
+	key = hmacSHA256(key, sigv4Terminator)
+	return hex.EncodeToString(hmacSHA256(key, sts))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// URI-encode every byte except unreserved characters: 'A'-'Z', 'a'-'z', '0'-'9', '-', '.', '_', and '~'
+// (and, unless encodeSlash, '/')
+func uriEncode(s string, encodeSlash bool) string {
+	const hexd = "0123456789ABCDEF"
+	var sb strings.Builder
+	sb.Grow(len(s) + 8)
+	for i := range len(s) {
+		c := s[i]
+		switch {
+		case (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9'),
+			c == '-', c == '.', c == '_', c == '~':
+			sb.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte('%')
+			sb.WriteByte(hexd[c>>4])
+			sb.WriteByte(hexd[c&15])
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,91 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Object tagging
+// - https://docs.aws.amazon.com/AmazonS3/latest/userguide/object-tagging.html
+// - tags are stored in-cluster as object's custom metadata (cmn.TaggingObjMD),
+//   URL-encoded - the same way they are passed via `x-amz-tagging` header
+
+const (
+	MaxTags      = 10
+	maxTagKeyLen = 128
+	maxTagValLen = 256
+)
+
+type (
+	Tagging struct {
+		XMLName xml.Name `xml:"Tagging"`
+		Ns      string   `xml:"xmlns,attr,omitempty"`
+		TagSet  []Tag    `xml:"TagSet>Tag"`
+	}
+	Tag struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	}
+)
+
+// from `x-amz-tagging` header or custom metadata
+func DecodeTagging(s string) (*Tagging, error) {
+	q, err := url.ParseQuery(s)
+	if err != nil {
+		return nil, &ErrCode{Code: "InvalidTag", Msg: fmt.Sprintf("invalid tag set %q: %v", s, err)}
+	}
+	tagging := &Tagging{TagSet: make([]Tag, 0, len(q))}
+	for key, vals := range q {
+		if len(vals) != 1 {
+			return nil, &ErrCode{Code: "InvalidTag", Msg: fmt.Sprintf("duplicate tag key %q", key)}
+		}
+		tagging.TagSet = append(tagging.TagSet, Tag{Key: key, Value: vals[0]})
+	}
+	sort.Slice(tagging.TagSet, func(i, j int) bool { return tagging.TagSet[i].Key < tagging.TagSet[j].Key })
+	return tagging, tagging.Validate()
+}
+
+func (t *Tagging) Validate() error {
+	if len(t.TagSet) > MaxTags {
+		return &ErrCode{Code: "BadRequest", Msg: fmt.Sprintf("too many tags (%d), max %d", len(t.TagSet), MaxTags)}
+	}
+	keys := make(map[string]struct{}, len(t.TagSet))
+	for _, tag := range t.TagSet {
+		switch {
+		case tag.Key == "" || len(tag.Key) > maxTagKeyLen:
+			return &ErrCode{Code: "InvalidTag", Msg: fmt.Sprintf("invalid tag key %q", tag.Key)}
+		case len(tag.Value) > maxTagValLen:
+			return &ErrCode{Code: "InvalidTag", Msg: fmt.Sprintf("tag %q: value is too long", tag.Key)}
+		}
+		if _, ok := keys[tag.Key]; ok {
+			return &ErrCode{Code: "InvalidTag", Msg: fmt.Sprintf("duplicate tag key %q", tag.Key)}
+		}
+		keys[tag.Key] = struct{}{}
+	}
+	return nil
+}
+
+// (sorted by key)
+func (t *Tagging) Encode() string {
+	q := make(url.Values, len(t.TagSet))
+	for _, tag := range t.TagSet {
+		q.Set(tag.Key, tag.Value)
+	}
+	return q.Encode()
+}
+
+func (t *Tagging) MustMarshal(sgl *memsys.SGL) {
+	t.Ns = s3Namespace
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(t)
+	debug.AssertNoErr(err)
+}
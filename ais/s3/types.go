@@ -30,10 +30,11 @@ type (
 		Name                  string          `xml:"Name"`
 		Ns                    string          `xml:"xmlns,attr"`
 		Prefix                string          `xml:"Prefix"`
+		Delimiter             string          `xml:"Delimiter,omitempty"`
 		ContinuationToken     string          `xml:"ContinuationToken"`        // original
 		NextContinuationToken string          `xml:"NextContinuationToken"`    // to read the next page
 		Contents              []*ObjInfo      `xml:"Contents"`                 // list of object
-		CommonPrefixes        []*CommonPrefix `xml:"CommonPrefixes,omitempty"` // list of dirs (used with `apc.LsNoRecursion`)
+		CommonPrefixes        []*CommonPrefix `xml:"CommonPrefixes,omitempty"` // list of dirs (used with delimiter)
 		KeyCount              int             `xml:"KeyCount"`                 // number of object names in the response
 		MaxKeys               int             `xml:"MaxKeys"`                  // "The maximum number of keys returned ..."
 		IsTruncated           bool            `xml:"IsTruncated"`              // true if there are more pages to read
@@ -127,10 +128,8 @@ func FillLsoMsg(query url.Values, msg *apc.LsoMsg) {
 	if after := query.Get(QparamStartAfter); after != "" && token == "" {
 		msg.StartAfter = after
 	}
-	// TODO: check that the delimiter is '/' and raise an error otherwise
-	if delimiter := query.Get(QparamDelimiter); delimiter != "" {
-		msg.SetFlag(apc.LsNoRecursion)
-	}
+	// "/" implies apc.LsNoRecursion (see LsoMsg.InitDelim)
+	msg.Delimiter = query.Get(QparamDelimiter)
 }
 
 func NewListObjectResult(bucket string) *ListObjectResult {
@@ -152,8 +151,9 @@ func (r *ListObjectResult) Add(entry *cmn.LsoEnt, lsmsg *apc.LsoMsg) {
 	if entry.Flags&apc.EntryIsDir == 0 {
 		r.Contents = append(r.Contents, entryToS3(entry, lsmsg))
 	} else {
+		// common prefixes that were rolled up by arbitrary delimiter do include it
 		prefix := entry.Name
-		if !cos.IsLastB(entry.Name, '/') {
+		if !lsmsg.IsDelimAny() && !cos.IsLastB(entry.Name, '/') {
 			prefix += "/"
 		}
 		r.CommonPrefixes = append(r.CommonPrefixes, &CommonPrefix{Prefix: prefix})
@@ -178,6 +178,7 @@ func entryToS3(entry *cmn.LsoEnt, lsmsg *apc.LsoMsg) (oi *ObjInfo) {
 }
 
 func (r *ListObjectResult) FromLsoResult(lst *cmn.LsoRes, lsmsg *apc.LsoMsg) {
+	r.Delimiter = lsmsg.Delim()
 	r.KeyCount = len(lst.Entries)
 	r.IsTruncated = lst.ContinuationToken != ""
 	r.NextContinuationToken = lst.ContinuationToken
@@ -0,0 +1,124 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// List object versions
+// - https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListObjectVersions.html
+// - backed by list-objects with apc.LsVersions: remote backend's version listing
+//   (aws://, gs://, az://), or in-cluster objects (each listed as its latest and only version);
+// - paging: `NextVersionIdMarker` carries the (opaque) list-objects continuation token,
+//   `NextKeyMarker` - the last listed name; a `key-marker` without `version-id-marker`
+//   is treated as `start-after`
+
+type (
+	ListVersionsResult struct {
+		XMLName             xml.Name        `xml:"ListVersionsResult"`
+		Ns                  string          `xml:"xmlns,attr"`
+		Name                string          `xml:"Name"`
+		Prefix              string          `xml:"Prefix"`
+		Delimiter           string          `xml:"Delimiter,omitempty"`
+		KeyMarker           string          `xml:"KeyMarker"`
+		VersionIDMarker     string          `xml:"VersionIdMarker"`
+		NextKeyMarker       string          `xml:"NextKeyMarker,omitempty"`
+		NextVersionIDMarker string          `xml:"NextVersionIdMarker,omitempty"`
+		MaxKeys             int             `xml:"MaxKeys"`
+		IsTruncated         bool            `xml:"IsTruncated"`
+		Versions            []*ObjVersion   `xml:",any"` // <Version> and <DeleteMarker>, in the listed order
+		CommonPrefixes      []*CommonPrefix `xml:"CommonPrefixes,omitempty"`
+	}
+	ObjVersion struct {
+		XMLName      xml.Name
+		Key          string `xml:"Key"`
+		VersionID    string `xml:"VersionId"`
+		IsLatest     bool   `xml:"IsLatest"`
+		LastModified string `xml:"LastModified"`
+		ETag         string `xml:"ETag,omitempty"`
+		Size         *int64 `xml:"Size,omitempty"`
+		Class        string `xml:"StorageClass,omitempty"`
+	}
+)
+
+func FillLsoVersionsMsg(query url.Values, msg *apc.LsoMsg) (keyMarker, vidMarker string) {
+	msg.SetFlag(apc.LsVersions)
+	msg.PageSize = apc.MaxPageSizeAWS
+	if pageSize, err := strconv.Atoi(query.Get(QparamMaxKeys)); err == nil && pageSize > 0 {
+		msg.PageSize = int64(pageSize)
+	}
+	msg.Prefix = query.Get(QparamPrefix)
+	msg.Delimiter = query.Get(QparamDelimiter)
+
+	keyMarker, vidMarker = query.Get(QparamKeyMarker), query.Get(QparamVersionIDMarker)
+	switch {
+	case vidMarker != "":
+		msg.ContinuationToken = vidMarker
+	case keyMarker != "":
+		msg.StartAfter = keyMarker
+	}
+	return keyMarker, vidMarker
+}
+
+func NewListVersionsResult(bucket string, lsmsg *apc.LsoMsg) *ListVersionsResult {
+	return &ListVersionsResult{
+		Name:      bucket,
+		Ns:        s3Namespace,
+		Prefix:    lsmsg.Prefix,
+		Delimiter: lsmsg.Delim(),
+		MaxKeys:   int(lsmsg.PageSize),
+	}
+}
+
+func (r *ListVersionsResult) FromLsoResult(lst *cmn.LsoRes, lsmsg *apc.LsoMsg) {
+	r.Versions = make([]*ObjVersion, 0, len(lst.Entries))
+	for _, e := range lst.Entries {
+		if e.IsDir() {
+			prefix := e.Name
+			if !lsmsg.IsDelimAny() && !cos.IsLastB(prefix, '/') {
+				prefix += "/"
+			}
+			r.CommonPrefixes = append(r.CommonPrefixes, &CommonPrefix{Prefix: prefix})
+			continue
+		}
+		v := &ObjVersion{
+			Key:          e.Name,
+			VersionID:    cos.Left(e.Version, nullVersion),
+			IsLatest:     e.IsLatest(),
+			LastModified: entryToS3(e, lsmsg).LastModified,
+		}
+		if e.IsDelMarker() {
+			v.XMLName.Local = "DeleteMarker"
+		} else {
+			v.XMLName.Local = "Version"
+			v.Size = &e.Size
+			v.Class = StorageClass
+			if etag := cmn.S2CustomVal(e.Custom, cmn.ETag); etag != "" {
+				v.ETag = `"` + cmn.UnquoteCEV(etag) + `"`
+			}
+		}
+		r.Versions = append(r.Versions, v)
+	}
+	if lst.ContinuationToken != "" && len(lst.Entries) > 0 {
+		r.IsTruncated = true
+		r.NextKeyMarker = lst.Entries[len(lst.Entries)-1].Name
+		r.NextVersionIDMarker = lst.ContinuationToken
+	}
+}
+
+func (r *ListVersionsResult) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(r)
+	debug.AssertNoErr(err)
+}
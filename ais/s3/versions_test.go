@@ -0,0 +1,75 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3_test
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Object versions and attributes", func() {
+	It("should list versions and delete markers in order", func() {
+		lsmsg := &apc.LsoMsg{TimeFormat: "2006-01-02T15:04:05Z07:00"}
+		s3.FillLsoVersionsMsg(map[string][]string{s3.QparamMaxKeys: {"3"}, s3.QparamKeyMarker: {"a"}}, lsmsg)
+		Expect(lsmsg.IsFlagSet(apc.LsVersions)).To(BeTrue())
+		Expect(lsmsg.PageSize).To(BeEquivalentTo(3))
+		Expect(lsmsg.StartAfter).To(Equal("a"))
+
+		lst := &cmn.LsoRes{
+			Entries: cmn.LsoEntries{
+				{Name: "b", Version: "v2", Size: 10, Custom: cmn.CustomMD2S(cos.StrKVs{cmn.ETag: `"abc"`})},
+				{Name: "b", Version: "v1", Flags: apc.EntryNotLatest | apc.EntryDelMarker},
+				{Name: "c", Size: 1},
+			},
+			ContinuationToken: "next",
+		}
+		resp := s3.NewListVersionsResult("bucket", lsmsg)
+		resp.FromLsoResult(lst, lsmsg)
+		Expect(resp.IsTruncated).To(BeTrue())
+		Expect(resp.NextKeyMarker).To(Equal("c"))
+		Expect(resp.NextVersionIDMarker).To(Equal("next"))
+
+		b, err := xml.Marshal(resp)
+		Expect(err).NotTo(HaveOccurred())
+		out := string(b)
+		Expect(strings.Count(out, "<Version>")).To(Equal(2))
+		Expect(strings.Count(out, "<DeleteMarker>")).To(Equal(1))
+		Expect(strings.Index(out, "<DeleteMarker>")).To(BeNumerically("<", strings.LastIndex(out, "<Version>")))
+		Expect(out).To(ContainSubstring("<VersionId>null</VersionId>"))
+		Expect(out).To(ContainSubstring("<ETag>&#34;abc&#34;</ETag>"))
+	})
+
+	It("should parse and report object attributes", func() {
+		hdr := http.Header{}
+		_, err := s3.ParseObjAttrsReq(hdr)
+		Expect(err).To(HaveOccurred())
+		hdr.Set(cos.S3HdrObjAttrs, "ETag,Size")
+		_, err = s3.ParseObjAttrsReq(hdr)
+		Expect(err).To(HaveOccurred())
+
+		hdr.Set(cos.S3HdrObjAttrs, "ETag, Checksum,ObjectParts,ObjectSize")
+		req, err := s3.ParseObjAttrsReq(hdr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Has(s3.ObjAttrStorageClass)).To(BeFalse())
+
+		oa := &cmn.ObjAttrs{Size: 1024, Cksum: cos.NewCksum(cos.ChecksumCRC32C, "e3069283")}
+		oa.SetCustomKey(cmn.ETag, `"0123456789abcdef-3"`)
+		res, err := s3.NewObjAttrsResult(req, oa, "" /*remote*/)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.ETag).To(Equal("0123456789abcdef-3"))
+		Expect(res.Checksum.ChecksumCRC32C).To(Equal("4waSgw=="))
+		Expect(*res.ObjectSize).To(BeEquivalentTo(1024))
+		Expect(res.ObjectParts.TotalPartsCount).To(Equal(3))
+		Expect(res.StorageClass).To(BeEmpty())
+	})
+})
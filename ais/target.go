@@ -70,6 +70,11 @@ type (
 		res          *res.Res
 		transactions transactions
 		regstate     regstate
+		mdidx        mdIndex       // custom metadata search index (see tgtmdidx.go)
+		usage        usageTracker  // usage accounting (see usage.go)
+		batch        batchMgr      // batch GET (see tgtbatch.go)
+		events       evMgr         // object event notifications (see tgtevents.go)
+		ratelim      ratelimDebits // GET bandwidth charged post-hoc (see tgtratelim.go)
 	}
 )
 
@@ -250,6 +255,61 @@ func (t *target) init(config *cmn.Config) {
 	if err := ts.InitCDF(config); err != nil {
 		cos.ExitLog(err)
 	}
+	t.initWeight(config)
+}
+
+// capacity-weighted HRW (see core/meta/hrw.go)
+func (t *target) initWeight(config *cmn.Config) {
+	switch w := config.HrwWeight; {
+	case w == 0:
+		return
+	case w == cmn.HrwWeightCapacity:
+		t.si.Weight = capWeight(fs.Cap())
+	default:
+		t.si.Weight = uint64(w)
+	}
+	nlog.Infoln(t.String(), "HRW weight:", t.si.Weight)
+}
+
+// recompute capacity-derived weight upon adding or removing mountpaths;
+// if changed, the next keepalive will carry the new weight to primary (see talive)
+func (t *target) reweigh() {
+	config := cmn.GCO.Get()
+	if config.HrwWeight != cmn.HrwWeightCapacity {
+		return
+	}
+	cs, err, _ := fs.CapRefresh(config, nil /*tcdf*/)
+	if err != nil {
+		nlog.Warningln(t.String(), "failed to recompute HRW weight:", err)
+		return
+	}
+	w := capWeight(cs)
+	if w == t.si.Weight {
+		return
+	}
+	nlog.Infoln(t.String(), "HRW weight:", t.si.Weight, "=>", w)
+	t.si.Weight = w
+	if tkr, ok := t.keepalive.(*talive); ok {
+		tkr.reweighed.Store(true)
+	}
+}
+
+func capWeight(cs fs.CapStatus) uint64 {
+	return hrwWeightQuantize(max((cs.TotalUsed+cs.TotalAvail)/cos.GiB, 1))
+}
+
+// round capacity-derived weight (GiB) to 7 significant bits (e.g., 7452GiB => 7424), so that
+// minor fluctuations in the reported capacity don't result in a new Smap and rebalance;
+// powers of two are exact - in particular, 1TiB => 1024 (cmn.DefaultHrwWeight)
+func hrwWeightQuantize(w uint64) uint64 {
+	var shift uint
+	for w>>shift >= 128 {
+		shift++
+	}
+	if shift == 0 {
+		return w
+	}
+	return (w + 1<<(shift-1)) >> shift << shift
 }
 
 func (t *target) initHostIP(config *cmn.Config) {
@@ -390,6 +450,8 @@ func (t *target) Run() error {
 		nlog.Errorln(t.String(), "failed to initialize kvdb:", err)
 		return err
 	}
+	t.mdidx.init(db)
+	t.usage.init(t.statsT, db)
 
 	t.transactions.init(t)
 
@@ -399,8 +461,11 @@ func (t *target) Run() error {
 	// register storage target's handler(s) and start listening
 	t.initRecvHandlers()
 
-	ec.Init()
+	ec.Init(t.statsT)
 	mirror.Init()
+	t.batch.init(t)
+	t.ratelim.init(t)
+	t.events.init(t, config)
 
 	xreg.RegWithHK()
 
@@ -497,6 +562,8 @@ func (t *target) initRecvHandlers() {
 	networkHandlers := []networkHandler{
 		{r: apc.Buckets, h: t.bucketHandler, net: accessNetAll},
 		{r: apc.Objects, h: t.objectHandler, net: accessNetAll},
+		{r: apc.Batch, h: t.batchHandler, net: accessNetAll},
+		{r: apc.Events, h: t.eventsHandler, net: accessNetIntraControl},
 		{r: apc.Daemon, h: t.daemonHandler, net: accessNetPublicControl},
 		{r: apc.Metasync, h: t.metasyncHandler, net: accessNetIntraControl},
 		{r: apc.Health, h: t.healthHandler, net: accessNetPublicControl},
@@ -732,6 +799,10 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 	}
 
 	// GET: regular | archive | range
+	precond, err := cmn.ParsePrecond(r.Header)
+	if err != nil {
+		return lom, err
+	}
 	goi := allocGOI()
 	{
 		goi.atime = time.Now().UnixNano()
@@ -744,6 +815,7 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 		goi.t = t
 		goi.lom = lom
 		goi.dpq = dpq
+		goi.precond = precond
 		goi.req = r
 		goi.w = w
 		goi.ctx = context.Background()
@@ -758,9 +830,16 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 		if dpq.arch.path != "" {
 			if strings.HasPrefix(dpq.arch.path, lom.ObjName) {
 				if rel, err := filepath.Rel(lom.ObjName, dpq.arch.path); err == nil {
+					switch {
+					case rel == ".":
+						rel = "" // the entire shard
+					case cos.IsLastB(dpq.arch.path, '/'):
+						rel += "/"
+					}
 					dpq.arch.path = rel
 				}
 			}
+			dpq.archDir()
 		}
 	}
 
@@ -933,7 +1012,8 @@ func (t *target) httpobjdelete(w http.ResponseWriter, r *http.Request, apireq *a
 		return
 	}
 	objName := apireq.items[1]
-	if isRedirect(apireq.query) == "" {
+	if isRedirect(apireq.query) == "" && t.checkIntraCall(r.Header, false /*from primary*/) != nil {
+		// (other than redirected: intra-cluster replication - see tgtrepl.go)
 		t.writeErrf(w, r, "%s: %s(obj) is expected to be redirected", t.si, r.Method)
 		return
 	}
@@ -950,6 +1030,9 @@ func (t *target) httpobjdelete(w http.ResponseWriter, r *http.Request, apireq *a
 	if err == nil && ecode == 0 {
 		// EC cleanup if EC is enabled
 		ec.ECM.CleanupObject(lom)
+		if !evict {
+			t.usage.add(apireq.query.Get(apc.QparamUser), lom.Bck(), usageDel, 1, 0)
+		}
 	} else {
 		if ecode == http.StatusNotFound {
 			t.writeErrSilentf(w, r, http.StatusNotFound, "%s doesn't exist", lom.Cname())
@@ -993,6 +1076,40 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *api
 				cos.NamedVal64{Name: stats.ErrRenameCount, Value: 1, VarLabs: vlabs},
 			)
 		}
+	case apc.ActComposeObject:
+		composeMsg := &cmn.ComposeMsg{}
+		lom = core.AllocLOM(apireq.items[1])
+		if err = lom.InitBck(apireq.bck.Bucket()); err != nil {
+			break
+		}
+		if err = cos.MorphMarshal(msg.Value, composeMsg); err != nil {
+			err = fmt.Errorf(cmn.FmtErrMorphUnmarshal, t, msg.Action, msg.Value, err)
+			break
+		}
+		if err = composeMsg.Init(apireq.bck.Bucket()); err != nil {
+			break
+		}
+		if err = t.compose(lom, composeMsg); err == nil {
+			core.FreeLOM(lom)
+			lom = nil
+		}
+	case apc.ActCopyRange:
+		crMsg := &cmn.CopyRangeMsg{}
+		lom = core.AllocLOM(apireq.items[1])
+		if err = lom.InitBck(apireq.bck.Bucket()); err != nil {
+			break
+		}
+		if err = cos.MorphMarshal(msg.Value, crMsg); err != nil {
+			err = fmt.Errorf(cmn.FmtErrMorphUnmarshal, t, msg.Action, msg.Value, err)
+			break
+		}
+		if err = crMsg.Init(apireq.bck.Bucket()); err != nil {
+			break
+		}
+		if err = t.copyRange(lom, crMsg); err == nil {
+			core.FreeLOM(lom)
+			lom = nil
+		}
 	case apc.ActBlobDl:
 		// TODO: add stats.GetBlobCount and *ErrCount
 		var (
@@ -1045,18 +1162,27 @@ func (t *target) httpobjhead(w http.ResponseWriter, r *http.Request, apireq *api
 	lom := core.AllocLOM(objName)
 	ecode, err := t.objHead(r, w.Header(), query, bck, lom)
 	core.FreeLOM(lom)
-	if err != nil {
+	switch {
+	case err != nil:
 		t._erris(w, r, err, ecode, cos.IsParseBool(query.Get(apc.QparamSilent)))
+	case ecode == http.StatusNotModified:
+		w.WriteHeader(ecode)
 	}
 }
 
 // NOTE: sets whdr.ContentLength = obj-size, with no response body
+// (returns http.StatusNotModified with nil error when conditional HEAD evaluates as such)
 func (t *target) objHead(r *http.Request, whdr http.Header, q url.Values, bck *meta.Bck, lom *core.LOM) (ecode int, err error) {
 	var (
+		precond     *cmn.Precond
+		mtime       time.Time
 		fltPresence int
 		hasEC       bool
 		exists      = true
 	)
+	if precond, err = cmn.ParsePrecond(r.Header); err != nil {
+		return http.StatusBadRequest, err
+	}
 	if tmp := q.Get(apc.QparamFltPresence); tmp != "" {
 		var erp error
 		fltPresence, erp = strconv.Atoi(tmp)
@@ -1155,6 +1281,23 @@ func (t *target) objHead(r *http.Request, whdr http.Header, q url.Values, bck *m
 		}
 	}
 
+	// conditional HEAD
+	if precond != nil {
+		var hdr string
+		if exists {
+			mtime = lomMtime(lom)
+		} else {
+			mtime = cmn.ObjLastModified(&op.ObjAttrs)
+		}
+		ecode, hdr = precond.Eval(http.MethodHead, &op.ObjAttrs, mtime)
+		if ecode == http.StatusPreconditionFailed {
+			return ecode, cmn.NewErrPrecondFailed(lom.Cname(), hdr)
+		}
+		if !mtime.IsZero() {
+			whdr.Set(cos.HdrLastModified, mtime.UTC().Format(http.TimeFormat))
+		}
+	}
+
 	// to header
 	cmn.ToHeader(&op.ObjAttrs, whdr, op.ObjAttrs.Size)
 	if op.ObjAttrs.Cksum == nil {
@@ -1196,6 +1339,23 @@ func (t *target) httpobjpatch(w http.ResponseWriter, r *http.Request, apireq *ap
 		}
 	}
 
+	if s := apireq.query.Get(apc.QparamPatchOffset); s != "" {
+		off, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || off < 0 {
+			t.writeErrf(w, r, "%s: invalid %s=%q", t.si, apc.QparamPatchOffset, s)
+			return
+		}
+		lom := core.AllocLOM(apireq.items[1] /*objName*/)
+		if err = lom.InitBck(apireq.bck.Bucket()); err == nil {
+			err = t.patchObj(lom, off, r.Body, r.ContentLength)
+		}
+		if err != nil {
+			t.writeErr(w, r, err)
+		}
+		core.FreeLOM(lom)
+		return
+	}
+
 	msg, err := t.readActionMsg(w, r)
 	if err != nil {
 		return
@@ -1229,6 +1389,7 @@ func (t *target) httpobjpatch(w http.ResponseWriter, r *http.Request, apireq *ap
 		}
 	}
 	lom.Persist()
+	t.mdidx.update(lom)
 }
 
 // called under lock
@@ -1283,8 +1444,20 @@ func (t *target) putApndArch(r *http.Request, lom *core.LOM, started int64, dpq
 }
 
 func (t *target) DeleteObject(lom *core.LOM, evict bool) (code int, err error) {
+	return t.deleteObjectIf(lom, evict, nil)
+}
+
+// cond (optional): evaluated under write lock - a non-nil error is returned as is,
+// with nothing deleted
+func (t *target) deleteObjectIf(lom *core.LOM, evict bool, cond func(*core.LOM) error) (code int, err error) {
 	var isback bool
 	lom.Lock(true)
+	if cond != nil {
+		if err = cond(lom); err != nil {
+			lom.Unlock(true)
+			return 0, err
+		}
+	}
 	code, err, isback = t.delobj(lom, evict)
 	lom.Unlock(true)
 
@@ -1306,6 +1479,12 @@ func (t *target) DeleteObject(lom *core.LOM, evict bool) (code int, err error) {
 		t.statsT.AddWith(
 			cos.NamedVal64{Name: stats.DeleteCount, Value: 1, VarLabs: vlabs},
 		)
+		t.usage.uncharge(lom)
+		if evict {
+			t.events.emit(lom, cmn.EventObjEvicted)
+		} else {
+			t.events.emit(lom, cmn.EventObjRemoved)
+		}
 	case cos.IsNotExist(err, code) || cmn.IsErrObjNought(err):
 		if !evict {
 			t.statsT.AddWith(
@@ -1373,10 +1552,13 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 				return 0, aisErr, false
 			}
 			debug.Assert(aisErr == nil) // expecting lom.RemoveObj() to return nil when IsNotExist
-		} else if evict {
-			debug.Assert(lom.Bck().IsRemote())
-			t.statsT.Inc(stats.LruEvictCount)
-			t.statsT.Add(stats.LruEvictSize, size)
+		} else {
+			t.mdidx.remove(lom)
+			if evict {
+				debug.Assert(lom.Bck().IsRemote())
+				t.statsT.Inc(stats.LruEvictCount)
+				t.statsT.Add(stats.LruEvictSize, size)
+			}
 		}
 	}
 	if backendErr != nil {
@@ -1419,6 +1601,8 @@ func (t *target) objMv(lom *core.LOM, msg *apc.ActMsg) (err error) {
 	lom.Lock(true)
 	if err := lom.RemoveObj(); err != nil {
 		nlog.Warningf("%s: failed to delete renamed object %s (new name %s): %v", t, lom, msg.Name, err)
+	} else {
+		t.events.emit(lom, cmn.EventObjRemoved)
 	}
 	lom.Unlock(true)
 	return nil
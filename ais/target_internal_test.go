@@ -0,0 +1,32 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HRW weight", func() {
+	DescribeTable("capacity-derived",
+		func(gib, expected uint64) {
+			Expect(hrwWeightQuantize(gib)).To(Equal(expected))
+		},
+		Entry("small: as is", uint64(100), uint64(100)),
+		Entry("1TiB: default weight", uint64(1024), uint64(meta.DefaultHrwWeight)),
+		Entry("1TB", uint64(1000), uint64(1000)),
+		Entry("rounded up", uint64(1023), uint64(1024)),
+		Entry("rounded down", uint64(7452), uint64(7424)),
+		Entry("16TiB", uint64(16*1024), uint64(16*1024)),
+	)
+
+	It("should not be zero", func() {
+		Expect(capWeight(fs.CapStatus{TotalAvail: cos.MiB})).To(Equal(uint64(1)))
+	})
+})
@@ -2266,6 +2266,65 @@ func TestECBucketEncode(t *testing.T) {
 	//
 }
 
+// Erasure-codes a bucket, and then changes the number of data and parity slices,
+// to make sure that all objects get re-encoded while remaining readable
+func TestECBucketReencode(t *testing.T) {
+	var (
+		proxyURL = tools.RandomProxyURL()
+		m        = ioContext{
+			t:        t,
+			num:      100,
+			proxyURL: proxyURL,
+		}
+	)
+
+	m.initAndSaveState(true /*cleanup*/)
+	baseParams := tools.BaseAPIParams(proxyURL)
+
+	if nt := m.smap.CountActiveTs(); nt < 4 {
+		t.Skipf("%s: not enough targets (%d): (d=2, p=1) requires at least 4", t.Name(), nt)
+	}
+
+	initMountpaths(t, proxyURL)
+	tools.CreateBucket(t, proxyURL, m.bck, nil, true /*cleanup*/)
+
+	m.puts()
+
+	encode := func(dataCnt, parityCnt int) {
+		tlog.Logf("Erasure-coding %s for (D=%d, P=%d)\n", m.bck, dataCnt, parityCnt)
+		bckPropsToUpate := &cmn.BpropsToSet{
+			EC: &cmn.ECConfToSet{
+				Enabled:      apc.Ptr(true),
+				ObjSizeLimit: apc.Ptr[int64](1),
+				DataSlices:   apc.Ptr(dataCnt),
+				ParitySlices: apc.Ptr(parityCnt),
+			},
+		}
+		_, err := api.SetBucketProps(baseParams, m.bck, bckPropsToUpate)
+		tassert.CheckFatal(t, err)
+
+		xargs := xact.ArgsMsg{Kind: apc.ActECEncode, Bck: m.bck, Timeout: tools.RebalanceTimeout}
+		_, err = api.WaitForXactionIC(baseParams, &xargs)
+		tassert.CheckFatal(t, err)
+
+		for _, objName := range m.objNames {
+			hargs := api.HeadArgs{FltPresence: apc.FltPresent}
+			props, err := api.HeadObject(baseParams, m.bck, objName, hargs)
+			tassert.CheckFatal(t, err)
+			if props.EC.DataSlices != dataCnt || props.EC.ParitySlices != parityCnt {
+				t.Fatalf("%s: expected (D=%d, P=%d), got (D=%d, P=%d)",
+					objName, dataCnt, parityCnt, props.EC.DataSlices, props.EC.ParitySlices)
+			}
+		}
+	}
+
+	encode(1, 1)
+	encode(2, 1)
+
+	m.gets(nil, true /*with validation*/)
+	m.ensureNoGetErrors()
+}
+
 // Creates two buckets (with EC enabled and disabled), fill them with data,
 // and then runs two parallel rebalances
 func TestECAndRegularRebalance(t *testing.T) {
@@ -6,6 +6,7 @@ package integration_test
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"sync"
 	"testing"
@@ -14,12 +15,14 @@ import (
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/tools"
 	"github.com/NVIDIA/aistore/tools/readers"
 	"github.com/NVIDIA/aistore/tools/tassert"
 	"github.com/NVIDIA/aistore/tools/tlog"
+	"github.com/NVIDIA/aistore/tools/trand"
 	"github.com/NVIDIA/aistore/xact"
 )
 
@@ -619,3 +622,45 @@ func testListObjects(t *testing.T, proxyURL string, bck cmn.Bck, msg *apc.LsoMsg
 	tassert.Fatalf(t, err == nil, "%s: list-objects failed: %v", bck, err)
 	return resList
 }
+
+func TestObjPropsSearchMD(t *testing.T) {
+	var (
+		proxyURL   = tools.RandomProxyURL()
+		baseParams = tools.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{Name: trand.String(10), Provider: apc.AIS}
+		props      = &cmn.BpropsToSet{Features: apc.Ptr(feat.IndexCustomMD)}
+		objNames   = []string{"a/obj1", "a/obj2", "b/obj3", "b/obj4"}
+	)
+	tools.CreateBucket(t, proxyURL, bck, props, true /*cleanup*/)
+	for i, objName := range objNames {
+		r, err := readers.NewRand(cos.KiB, cos.ChecksumNone)
+		tassert.CheckFatal(t, err)
+		tools.PutObject(t, bck, objName, r)
+		custom := cos.StrKVs{"label": "cat", "split": "val"}
+		if i%2 == 1 {
+			custom["label"] = "dog"
+		}
+		err = api.SetObjectCustomProps(baseParams, bck, objName, custom, false /*set new*/)
+		tassert.CheckFatal(t, err)
+	}
+
+	search := func(msg *apc.MDSearchMsg, expected ...string) {
+		names, err := api.SearchObjectsMD(baseParams, bck, msg)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, slices.Equal(names, expected), "search %+v: expected %v, got %v", msg, expected, names)
+	}
+	search(&apc.MDSearchMsg{Key: "label", Value: "cat"}, "a/obj1", "b/obj3")
+	search(&apc.MDSearchMsg{Key: "label", Value: "dog", Prefix: "b/"}, "b/obj4")
+	search(&apc.MDSearchMsg{Key: "split"}, objNames...)
+	search(&apc.MDSearchMsg{Key: "label", Value: "bird"})
+
+	// update and delete
+	err := api.SetObjectCustomProps(baseParams, bck, "a/obj1", cos.StrKVs{"label": "bird"}, true /*set new*/)
+	tassert.CheckFatal(t, err)
+	err = api.DeleteObject(baseParams, bck, "b/obj3")
+	tassert.CheckFatal(t, err)
+
+	search(&apc.MDSearchMsg{Key: "label", Value: "cat"})
+	search(&apc.MDSearchMsg{Key: "label", Value: "bird"}, "a/obj1")
+	search(&apc.MDSearchMsg{Key: "split"}, "a/obj2", "b/obj4")
+}
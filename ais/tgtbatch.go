@@ -0,0 +1,741 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/transport"
+	"github.com/NVIDIA/aistore/transport/bundle"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Batch GET (GET /v1/batch/<bucket-name>; see cmn.GetBatchMsg):
+// - gateway redirects the request to a designated target (DT) - the owner of the first entry;
+// - DT requests remote entries from their respective owners over intra-cluster transport
+//   (trnameBatchReq) - not all at once but within a sliding window (batchWindow entries ahead
+//   of the one being written, and no more than batchMaxBuffered bytes buffered per work item);
+// - owners read the requested objects, archived files, and ranges, and send them back to DT
+//   (trnameBatchResp);
+// - DT writes all entries into the response archive (.tar by default) in the original order:
+//   local entries are read directly, remote ones are buffered (SGL) until their turn;
+// - a missing (or otherwise failed) entry does not fail the batch - it is written as an empty
+//   (or error message) file prefixed with cmn.GetBatchPrefixNotFound (or cmn.GetBatchPrefixErr).
+// Streams are opened on demand and closed when idle (compare with ec.Manager).
+
+const (
+	trnameBatchReq  = "batch-req"
+	trnameBatchResp = "batch-resp"
+
+	batchIdle = 10 * time.Minute // close streams when idle
+
+	batchWindow      = 64            // max number of entries requested ahead of the current one
+	batchMaxBuffered = 256 * cos.MiB // stop requesting (but keep receiving) when exceeded
+)
+
+// response opcodes (other than 0 - ok)
+const (
+	opcodeBatchNotFound = iota + 31415
+	opcodeBatchErr
+)
+
+// request opcodes (other than 0 - read and send back)
+const (
+	opcodeBatchDel = iota + 27182 // delete (compose with cmn.ComposeMsg.DeleteSources)
+)
+
+type (
+	// DT => owner
+	batchReq struct {
+		UUID    string              `json:"uuid"`
+		Idx     []int               `json:"idx"`
+		Entries []cmn.GetBatchEntry `json:"entries"`
+		Tags    []string            `json:"tags,omitempty"` // opcodeBatchDel: delete only if unchanged
+	}
+	// remote entry
+	batchSlot struct {
+		sgl      *memsys.SGL
+		err      error
+		tag      string // identifies the source as read (see composeTag)
+		done     chan struct{}
+		mu       sync.Mutex
+		fin      bool
+		notFound bool
+	}
+	batchwi struct {
+		smap     *smapX
+		uuid     string              // empty when all entries are local
+		slots    []*batchSlot        // nil for local entries
+		entries  []cmn.GetBatchEntry // (normalized)
+		owners   []*meta.Snode       // nil for local entries
+		buffered atomic.Int64        // received and not yet consumed
+		next     int                 // next entry to request
+	}
+	// opened local entry
+	batchReader struct {
+		r    io.Reader
+		fh   cos.LomReader
+		csl  cos.ReadCloseSizer // archived file
+		lom  *core.LOM
+		size int64
+	}
+	// bundle.Streams (compare with loopback in unit tests)
+	batchStreams interface {
+		Send(obj *transport.Obj, roc cos.ReadOpenCloser, nodes ...*meta.Snode) error
+		Close(gracefully bool)
+	}
+	batchMgr struct {
+		t       *target
+		pending sync.Map // uuid => *batchwi
+		streams struct {
+			req   batchStreams // DT => owners
+			resp  batchStreams // owners => DT
+			atime atomic.Int64
+			sync.RWMutex
+		}
+	}
+)
+
+// interface guard
+var _ cos.ReadOpenCloser = (*batchReader)(nil)
+
+func (bm *batchMgr) init(t *target) {
+	bm.t = t
+	err := transport.Handle(trnameBatchReq, bm.recvReq)
+	debug.AssertNoErr(err)
+	err = transport.Handle(trnameBatchResp, bm.recvResp)
+	debug.AssertNoErr(err)
+	hk.Reg("batch-streams"+hk.NameSuffix, bm.housekeep, batchIdle)
+}
+
+//
+// streams
+//
+
+// open on demand; return with streams read-locked
+func (bm *batchMgr) rlock() {
+	for {
+		bm.streams.atime.Store(mono.NanoTime())
+		bm.streams.RLock()
+		if bm.streams.req != nil {
+			return
+		}
+		bm.streams.RUnlock()
+
+		bm.streams.Lock()
+		if bm.streams.req == nil {
+			var (
+				client = transport.NewIntraDataClient()
+				config = cmn.GCO.Get()
+			)
+			bm.streams.req = bundle.New(client, bundle.Args{
+				Net:    cmn.NetIntraControl,
+				Trname: trnameBatchReq,
+				Extra:  &transport.Extra{Config: config},
+			})
+			bm.streams.resp = bundle.New(client, bundle.Args{
+				Net:    cmn.NetIntraData,
+				Trname: trnameBatchResp,
+				Extra:  &transport.Extra{Config: config},
+			})
+		}
+		bm.streams.Unlock()
+	}
+}
+
+func (bm *batchMgr) housekeep(now int64) time.Duration {
+	if time.Duration(now-bm.streams.atime.Load()) < batchIdle {
+		return batchIdle
+	}
+	if !bm.streams.TryLock() { // (busy sending)
+		return batchIdle
+	}
+	if bm.streams.req != nil {
+		bm.streams.req.Close(true /*gracefully*/)
+		bm.streams.resp.Close(true)
+		bm.streams.req, bm.streams.resp = nil, nil
+	}
+	bm.streams.Unlock()
+	return batchIdle
+}
+
+//
+// designated target (DT)
+//
+
+// GET /v1/batch/<bucket-name>
+func (t *target) batchHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.WriteErr405(w, r, http.MethodGet)
+		return
+	}
+	apireq := apiReqAlloc(1, apc.URLPathBatch.L, false /*dpq*/)
+	err := t.parseReq(w, r, apireq)
+	bck, isRedirect := apireq.bck, apireq.query.Get(apc.QparamProxyID) != ""
+	apiReqFree(apireq)
+	if err != nil {
+		return
+	}
+	if cmn.Rom.Features().IsSet(feat.EnforceIntraClusterAccess) {
+		if !isRedirect && t.checkIntraCall(r.Header, false /*from primary*/) != nil {
+			t.writeErrf(w, r, "%s: get-batch is expected to be redirected (remaddr=%s)", t.si, r.RemoteAddr)
+			return
+		}
+	}
+	msg := &cmn.GetBatchMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	if err := msg.Init(bck.Bucket()); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	mime, err := archive.Mime(msg.Mime, "")
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	t.batch.do(w, r, msg, mime)
+}
+
+func (bm *batchMgr) do(w http.ResponseWriter, r *http.Request, msg *cmn.GetBatchMsg, mime string) {
+	t := bm.t
+
+	// 1. resolve owners and request remote entries
+	wi, err := bm.start(msg.Entries)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	defer bm.fini(wi)
+
+	// 2. write all entries in order
+	var (
+		size    int64
+		timeout = cmn.GCO.Get().Timeout.SendFile.D()
+		aw      = archive.NewWriter(mime, w, nil /*cksum*/, nil /*opts*/)
+	)
+	w.Header().Set(cos.HdrContentType, batchContentType(mime))
+	for i := range msg.Entries {
+		var (
+			n          int64
+			err, errTx error
+			notFound   bool
+			e          = &msg.Entries[i]
+			name       = e.NameInArch(msg.OnlyObjName)
+		)
+		bm.pull(wi, i)
+		if wi.slots[i] == nil {
+			var br *batchReader
+			if br, err = bm.open(e); err == nil {
+				n = br.size
+				errTx = aw.Write(name, &cmn.ObjAttrs{Size: n, Atime: br.lom.AtimeUnix()}, br)
+				br.Close()
+			} else {
+				notFound = cos.IsNotExist(err, 0)
+			}
+		} else {
+			var sgl *memsys.SGL
+			if sgl, notFound, err = wi.recv(i, name, timeout); err == nil {
+				n = sgl.Size()
+				errTx = aw.Write(name, &cmn.ObjAttrs{Size: n, Atime: time.Now().UnixNano()}, sgl)
+				sgl.Free()
+			}
+		}
+		if err != nil {
+			errTx = batchWriteErr(aw, name, err, notFound)
+			n = 0
+		}
+		if errTx != nil {
+			// (e.g., client disconnected)
+			nlog.Warningln(t.String(), "get-batch: failed to write", name, "[", errTx, "]")
+			aw.Fini()
+			return
+		}
+		size += n
+	}
+	aw.Fini()
+
+	t.statsT.Inc(stats.GetBatchCount)
+	t.statsT.Add(stats.GetBatchObjCount, int64(len(msg.Entries)))
+	t.statsT.Add(stats.GetBatchSize, size)
+}
+
+// resolve buckets and owners; remote entries are requested later - see pull()
+func (bm *batchMgr) start(entries []cmn.GetBatchEntry) (*batchwi, error) {
+	smap := bm.t.owner.smap.get()
+	reqs, err := bm.resolve(entries, smap)
+	if err != nil {
+		return nil, err
+	}
+	wi := &batchwi{smap: smap, slots: make([]*batchSlot, len(entries)), entries: entries}
+	if len(reqs) == 0 {
+		return wi, nil
+	}
+	wi.uuid = cos.GenUUID()
+	wi.owners = make([]*meta.Snode, len(entries))
+	for tid, req := range reqs {
+		tsi := smap.GetTarget(tid)
+		for _, idx := range req.Idx {
+			wi.slots[idx] = &batchSlot{done: make(chan struct{})}
+			wi.owners[idx] = tsi
+		}
+	}
+	bm.pending.Store(wi.uuid, wi)
+	return wi, nil
+}
+
+// request remote entries ahead of the current one (cur) - within the window
+func (bm *batchMgr) pull(wi *batchwi, cur int) {
+	if reqs := wi.window(cur); len(reqs) > 0 {
+		bm.send(reqs, wi.smap, 0 /*opcode*/, wi)
+	}
+}
+
+func (bm *batchMgr) fini(wi *batchwi) {
+	if wi.uuid != "" {
+		bm.pending.Delete(wi.uuid)
+		wi.cleanup()
+	}
+}
+
+// (normalizes entries' buckets in place); returns remote entries grouped by owner ID
+func (bm *batchMgr) resolve(entries []cmn.GetBatchEntry, smap *smapX) (reqs map[string]*batchReq, _ error) {
+	t := bm.t
+	for i := range entries {
+		e := &entries[i]
+		bck := meta.CloneBck(&e.Bck)
+		if err := bck.Init(t.owner.bmd); err != nil {
+			return nil, err
+		}
+		e.Bck = cmn.Bck{Name: bck.Name, Provider: bck.Provider, Ns: bck.Ns}
+		tsi, err := smap.HrwName2T(bck.MakeUname(e.ObjName))
+		if err != nil {
+			return nil, err
+		}
+		if tsi.ID() == t.SID() {
+			continue
+		}
+		if reqs == nil {
+			reqs = make(map[string]*batchReq, 4)
+		}
+		req, ok := reqs[tsi.ID()]
+		if !ok {
+			req = &batchReq{}
+			reqs[tsi.ID()] = req
+		}
+		req.Idx = append(req.Idx, i)
+		req.Entries = append(req.Entries, *e)
+	}
+	return reqs, nil
+}
+
+// given work item, failure to send is delivered to the respective slots; otherwise, logged
+func (bm *batchMgr) send(reqs map[string]*batchReq, smap *smapX, opcode int, wi *batchwi) {
+	t := bm.t
+	bm.rlock()
+	for tid, req := range reqs {
+		body := cos.MustMarshal(req)
+		o := transport.AllocSend()
+		o.Hdr.SID = t.SID()
+		o.Hdr.Opcode = opcode
+		o.Hdr.ObjAttrs.Size = int64(len(body))
+		err := bm.streams.req.Send(o, cos.NewByteHandle(body), smap.GetTarget(tid))
+		if err == nil {
+			continue
+		}
+		if wi == nil {
+			nlog.Warningln(t.String(), "batch", req.UUID, "=>", tid, "[", err, "]")
+			continue
+		}
+		for _, idx := range req.Idx {
+			wi.slots[idx].deliver(nil, err, false)
+		}
+	}
+	bm.streams.RUnlock()
+}
+
+func batchContentType(mime string) string {
+	switch mime {
+	case archive.ExtTar:
+		return cos.ContentTar
+	case archive.ExtZip:
+		return cos.ContentZip
+	default:
+		return cos.ContentBinary
+	}
+}
+
+// missing or failed entry
+func batchWriteErr(aw archive.Writer, name string, err error, notFound bool) error {
+	oah := &cmn.ObjAttrs{Atime: time.Now().UnixNano()}
+	if notFound {
+		return aw.Write(cmn.GetBatchPrefixNotFound+name, oah, bytes.NewReader(nil))
+	}
+	s := err.Error()
+	oah.Size = int64(len(s))
+	return aw.Write(cmn.GetBatchPrefixErr+name, oah, strings.NewReader(s))
+}
+
+/////////////
+// batchwi //
+/////////////
+
+// returns remote entries to request next, grouped by owner ID:
+//   - refill when the window is (at least) half empty;
+//   - the current entry is always requested, the ones that follow - only while buffered bytes
+//     remain under the limit
+func (wi *batchwi) window(cur int) (reqs map[string]*batchReq) {
+	if wi.uuid == "" || wi.next >= len(wi.slots) || wi.next-cur > batchWindow/2 {
+		return nil
+	}
+	for ; wi.next < len(wi.slots) && wi.next-cur < batchWindow; wi.next++ {
+		if wi.next > cur && wi.buffered.Load() >= batchMaxBuffered {
+			break
+		}
+		tsi := wi.owners[wi.next]
+		if tsi == nil {
+			continue
+		}
+		if reqs == nil {
+			reqs = make(map[string]*batchReq, 4)
+		}
+		req, ok := reqs[tsi.ID()]
+		if !ok {
+			req = &batchReq{UUID: wi.uuid}
+			reqs[tsi.ID()] = req
+		}
+		req.Idx = append(req.Idx, wi.next)
+		req.Entries = append(req.Entries, wi.entries[wi.next])
+	}
+	return reqs
+}
+
+// wait for the i-th (remote) entry and take over its content
+func (wi *batchwi) recv(i int, name string, timeout time.Duration) (sgl *memsys.SGL, notFound bool, err error) {
+	slot := wi.slots[i]
+	slot.wait(name, timeout)
+	slot.mu.Lock()
+	sgl, notFound, err = slot.sgl, slot.notFound, slot.err
+	slot.sgl = nil
+	slot.mu.Unlock()
+	if sgl != nil {
+		wi.buffered.Sub(sgl.Size())
+	}
+	return sgl, notFound, err
+}
+
+// (after recv)
+func (wi *batchwi) tag(i int) string {
+	slot := wi.slots[i]
+	slot.mu.Lock()
+	tag := slot.tag
+	slot.mu.Unlock()
+	return tag
+}
+
+func (wi *batchwi) cleanup() {
+	for _, slot := range wi.slots {
+		if slot == nil {
+			continue
+		}
+		slot.mu.Lock()
+		slot.fin = true
+		if slot.sgl != nil {
+			slot.sgl.Free()
+			slot.sgl = nil
+		}
+		slot.mu.Unlock()
+	}
+}
+
+///////////////
+// batchSlot //
+///////////////
+
+// returns false if the slot is already done (timed out, delivered, or abandoned)
+func (slot *batchSlot) deliver(sgl *memsys.SGL, err error, notFound bool) bool {
+	slot.mu.Lock()
+	if slot.fin {
+		slot.mu.Unlock()
+		return false
+	}
+	slot.sgl, slot.err, slot.notFound = sgl, err, notFound
+	slot.fin = true
+	close(slot.done)
+	slot.mu.Unlock()
+	return true
+}
+
+func (slot *batchSlot) wait(name string, timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	select {
+	case <-slot.done:
+		timer.Stop()
+	case <-timer.C:
+		slot.deliver(nil, fmt.Errorf("timed out waiting for %s (%v)", name, timeout), false)
+	}
+}
+
+// owner => DT
+func (bm *batchMgr) recvResp(hdr *transport.ObjHdr, objReader io.Reader, err error) error {
+	if err != nil && !cos.IsEOF(err) {
+		nlog.Errorln(bm.t.String(), "get-batch: recv-resp:", err)
+		return err
+	}
+	err = bm.resp(hdr, objReader)
+	transport.DrainAndFreeReader(objReader)
+	return err
+}
+
+func (bm *batchMgr) resp(hdr *transport.ObjHdr, r io.Reader) error {
+	var (
+		uuid string
+		idx  int32
+		err  error
+		unp  = cos.NewUnpacker(hdr.Opaque)
+	)
+	if uuid, err = unp.ReadString(); err == nil {
+		idx, err = unp.ReadInt32()
+	}
+	if err != nil {
+		return err
+	}
+	v, ok := bm.pending.Load(uuid)
+	if !ok {
+		return nil // (done or failed)
+	}
+	wi := v.(*batchwi)
+	if int(idx) >= len(wi.slots) || wi.slots[idx] == nil {
+		return fmt.Errorf("get-batch %s: invalid entry index %d", uuid, idx)
+	}
+	bm.recvEntry(wi, int(idx), hdr, r)
+	return nil
+}
+
+func (bm *batchMgr) recvEntry(wi *batchwi, idx int, hdr *transport.ObjHdr, r io.Reader) {
+	slot := wi.slots[idx]
+	switch hdr.Opcode {
+	case opcodeBatchNotFound:
+		slot.deliver(nil, cos.NewErrNotFound(nil, hdr.ObjName), true)
+	case opcodeBatchErr:
+		slot.deliver(nil, errors.New(hdr.ObjName), false)
+	default:
+		sgl := bm.t.gmm.NewSGL(hdr.ObjAttrs.Size)
+		if _, err := io.Copy(sgl, r); err != nil {
+			sgl.Free()
+			slot.deliver(nil, err, false)
+			return
+		}
+		size := sgl.Size()
+		wi.buffered.Add(size)
+		if tag, ok := hdr.ObjAttrs.GetCustomKey(composeTagMD); ok {
+			slot.mu.Lock()
+			slot.tag = tag
+			slot.mu.Unlock()
+		}
+		if !slot.deliver(sgl, nil, false) { // (timed out or abandoned)
+			wi.buffered.Sub(size)
+			sgl.Free()
+		}
+	}
+}
+
+//
+// owner
+//
+
+// DT => owner
+func (bm *batchMgr) recvReq(hdr *transport.ObjHdr, objReader io.Reader, err error) error {
+	if err != nil && !cos.IsEOF(err) {
+		nlog.Errorln(bm.t.String(), "get-batch: recv-req:", err)
+		return err
+	}
+	req := &batchReq{}
+	err = jsoniter.NewDecoder(objReader).Decode(req)
+	transport.DrainAndFreeReader(objReader)
+	if err != nil {
+		return err
+	}
+	return bm.req(hdr, req)
+}
+
+func (bm *batchMgr) req(hdr *transport.ObjHdr, req *batchReq) error {
+	smap := bm.t.owner.smap.get()
+	tsi := smap.GetTarget(hdr.SID)
+	if tsi == nil {
+		return &errNodeNotFound{bm.t.si, smap, "get-batch: unknown DT", hdr.SID}
+	}
+	if hdr.Opcode == opcodeBatchDel {
+		go bm.del(req, tsi)
+		return nil
+	}
+	go bm.serve(req, tsi)
+	return nil
+}
+
+func (bm *batchMgr) serve(req *batchReq, tsi *meta.Snode) {
+	bm.rlock()
+	defer bm.streams.RUnlock()
+	for i := range req.Entries {
+		var (
+			e = &req.Entries[i]
+			o = transport.AllocSend()
+		)
+		o.Hdr.Opaque = batchOpaque(req.UUID, req.Idx[i])
+
+		br, err := bm.open(e)
+		if err != nil {
+			o.Hdr.Opcode = opcodeBatchErr
+			if cos.IsNotExist(err, 0) {
+				o.Hdr.Opcode = opcodeBatchNotFound
+			}
+			o.Hdr.ObjName = err.Error()
+			err = bm.streams.resp.Send(o, nil, tsi)
+		} else {
+			o.Hdr.ObjName = e.ObjName
+			o.Hdr.ObjAttrs.Size = br.size
+			o.Hdr.ObjAttrs.SetCustomKey(composeTagMD, composeTag(br.lom))
+			err = bm.streams.resp.Send(o, br, tsi) // closes br when done
+		}
+		if err != nil {
+			nlog.Warningln(bm.t.String(), "get-batch", req.UUID, "=>", tsi.StringEx(), "[", err, "]")
+			return
+		}
+	}
+}
+
+// (work item, entry index)
+func batchOpaque(uuid string, idx int) []byte {
+	packer := cos.NewPacker(nil, cos.PackedStrLen(uuid)+cos.SizeofI32)
+	packer.WriteString(uuid)
+	packer.WriteInt32(int32(idx))
+	return packer.Bytes()
+}
+
+//
+// read local entry (both DT and owner)
+//
+
+// the caller must close the returned reader
+func (bm *batchMgr) open(e *cmn.GetBatchEntry) (*batchReader, error) {
+	lom := core.AllocLOM(e.ObjName)
+	if err := lom.InitBck(&e.Bck); err != nil {
+		core.FreeLOM(lom)
+		return nil, err
+	}
+	lom.Lock(false)
+	if err := lom.Load(true /*cache it*/, true /*locked*/); err != nil {
+		if !cos.IsNotExist(err, 0) || !lom.Bck().IsRemote() {
+			lom.Unlock(false)
+			core.FreeLOM(lom)
+			return nil, err
+		}
+		// cold GET
+		lom.Unlock(false)
+		if _, err := bm.t.GetCold(context.Background(), lom, cmn.OwtGetLock); err != nil {
+			core.FreeLOM(lom)
+			return nil, err
+		}
+		lom.Lock(false)
+		if err := lom.Load(true, true); err != nil {
+			lom.Unlock(false)
+			core.FreeLOM(lom)
+			return nil, err
+		}
+	}
+
+	br := &batchReader{lom: lom}
+	fh, err := lom.Open()
+	if err != nil {
+		br.Close()
+		return nil, err
+	}
+	br.fh = fh
+	size := lom.Lsize()
+	switch {
+	case e.ArchPath != "":
+		err = br.openArch(e.ArchPath, bm.t.smm)
+	case e.IsRange():
+		if e.Start > size {
+			err = fmt.Errorf("%s: range start %d is out of bounds (size %d)", lom.Cname(), e.Start, size)
+			break
+		}
+		length := size - e.Start
+		if e.Length > 0 {
+			length = min(length, e.Length)
+		}
+		br.r, br.size = io.NewSectionReader(fh, e.Start, length), length
+	default:
+		br.r, br.size = fh, size
+	}
+	if err != nil {
+		br.Close()
+		return nil, err
+	}
+	return br, nil
+}
+
+/////////////////
+// batchReader //
+/////////////////
+
+func (br *batchReader) openArch(archpath string, smm *memsys.MMSA) error {
+	lom := br.lom
+	mime, err := archive.MimeFile(br.fh, smm, "", lom.ObjName)
+	if err != nil {
+		return err
+	}
+	ar, err := archive.NewReader(mime, br.fh, lom.Lsize())
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", lom.Cname(), err)
+	}
+	csl, err := ar.ReadOne(archpath)
+	if err != nil {
+		return cmn.NewErrFailedTo(core.T, "extract "+archpath+" from", lom.Cname(), err)
+	}
+	if csl == nil {
+		return cos.NewErrNotFound(core.T, archpath+" in "+lom.Cname())
+	}
+	br.csl, br.r, br.size = csl, csl, csl.Size()
+	return nil
+}
+
+func (br *batchReader) Read(b []byte) (int, error)        { return br.r.Read(b) }
+func (br *batchReader) Open() (cos.ReadOpenCloser, error) { return br, nil } // (single destination)
+
+func (br *batchReader) Close() error {
+	if br.csl != nil {
+		br.csl.Close()
+	}
+	if br.fh != nil {
+		cos.Close(br.fh)
+	}
+	br.lom.Unlock(false)
+	core.FreeLOM(br.lom)
+	return nil
+}
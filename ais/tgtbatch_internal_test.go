@@ -0,0 +1,300 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"io"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/transport"
+	jsoniter "github.com/json-iterator/go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Batch GET", func() {
+	var (
+		bm   *batchMgr
+		tsi1 = newSnode("t1", apc.Target, meta.NetInfo{}, meta.NetInfo{}, meta.NetInfo{})
+		tsi2 = newSnode("t2", apc.Target, meta.NetInfo{}, meta.NetInfo{}, meta.NetInfo{})
+	)
+	// remote entries owned by the respective targets; nil owner - local entry
+	newWI := func(owners []*meta.Snode) *batchwi {
+		wi := &batchwi{
+			uuid:    cos.GenUUID(),
+			slots:   make([]*batchSlot, len(owners)),
+			entries: make([]cmn.GetBatchEntry, len(owners)),
+			owners:  owners,
+		}
+		for i, tsi := range owners {
+			wi.entries[i].ObjName = "obj-" + strconv.Itoa(i)
+			if tsi != nil {
+				wi.slots[i] = &batchSlot{done: make(chan struct{})}
+			}
+		}
+		return wi
+	}
+	deliver := func(wi *batchwi, idx int, payload string) {
+		hdr := &transport.ObjHdr{ObjName: wi.entries[idx].ObjName}
+		hdr.ObjAttrs.Size = int64(len(payload))
+		bm.recvEntry(wi, idx, hdr, bytes.NewReader([]byte(payload)))
+	}
+	recv := func(wi *batchwi, idx int, timeout time.Duration) (string, bool, error) {
+		sgl, notFound, err := wi.recv(idx, wi.entries[idx].ObjName, timeout)
+		if err != nil {
+			return "", notFound, err
+		}
+		b, _ := io.ReadAll(sgl)
+		sgl.Free()
+		return string(b), false, nil
+	}
+
+	BeforeEach(func() {
+		bm = &batchMgr{t: t}
+	})
+
+	It("should request remote entries within the window", func() {
+		owners := make([]*meta.Snode, 4*batchWindow)
+		for i := range owners {
+			switch i % 3 {
+			case 0:
+				owners[i] = tsi1
+			case 1:
+				owners[i] = tsi2
+			}
+		}
+		wi := newWI(owners)
+
+		reqs := wi.window(0)
+		Expect(reqs).To(HaveLen(2))
+		var cnt int
+		for tid, req := range reqs {
+			Expect(req.UUID).To(Equal(wi.uuid))
+			Expect(req.Idx).To(HaveLen(len(req.Entries)))
+			for j, idx := range req.Idx {
+				Expect(idx).To(BeNumerically("<", batchWindow))
+				Expect(owners[idx].ID()).To(Equal(tid))
+				Expect(req.Entries[j].ObjName).To(Equal(wi.entries[idx].ObjName))
+				if j > 0 {
+					Expect(idx).To(BeNumerically(">", req.Idx[j-1])) // in order
+				}
+			}
+			cnt += len(req.Idx)
+		}
+		var expected int
+		for _, tsi := range owners[:batchWindow] {
+			if tsi != nil {
+				expected++
+			}
+		}
+		Expect(cnt).To(Equal(expected))
+
+		// refill only when half empty
+		Expect(wi.window(1)).To(BeEmpty())
+		reqs = wi.window(batchWindow / 2)
+		Expect(reqs).NotTo(BeEmpty())
+		for _, req := range reqs {
+			for _, idx := range req.Idx {
+				Expect(idx).To(BeNumerically(">=", batchWindow))
+				Expect(idx).To(BeNumerically("<", batchWindow/2+batchWindow))
+			}
+		}
+	})
+
+	It("should stop requesting ahead when buffered bytes exceed the limit", func() {
+		wi := newWI([]*meta.Snode{tsi1, tsi1, tsi2, tsi2})
+		wi.buffered.Store(batchMaxBuffered)
+
+		// the current entry is always requested
+		reqs := wi.window(0)
+		Expect(reqs).To(HaveLen(1))
+		Expect(reqs[tsi1.ID()].Idx).To(Equal([]int{0}))
+		reqs = wi.window(1)
+		Expect(reqs[tsi1.ID()].Idx).To(Equal([]int{1}))
+
+		wi.buffered.Store(0)
+		reqs = wi.window(2)
+		Expect(reqs).To(HaveLen(1))
+		Expect(reqs[tsi2.ID()].Idx).To(Equal([]int{2, 3}))
+	})
+
+	It("should return out-of-order responses in order", func() {
+		wi := newWI([]*meta.Snode{tsi1, tsi2, tsi1})
+		deliver(wi, 2, "third")
+		deliver(wi, 0, "first")
+		deliver(wi, 1, "second-entry")
+		Expect(wi.buffered.Load()).To(Equal(int64(len("third") + len("first") + len("second-entry"))))
+
+		for idx, expected := range []string{"first", "second-entry", "third"} {
+			s, _, err := recv(wi, idx, time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(s).To(Equal(expected))
+		}
+		Expect(wi.buffered.Load()).To(BeZero())
+	})
+
+	It("should deliver not-found and errors", func() {
+		wi := newWI([]*meta.Snode{tsi1, tsi2})
+		bm.recvEntry(wi, 0, &transport.ObjHdr{Opcode: opcodeBatchNotFound, ObjName: "obj-0"}, nil)
+		bm.recvEntry(wi, 1, &transport.ObjHdr{Opcode: opcodeBatchErr, ObjName: "read failure"}, nil)
+
+		_, notFound, err := recv(wi, 0, time.Second)
+		Expect(err).To(HaveOccurred())
+		Expect(notFound).To(BeTrue())
+		Expect(cos.IsNotExist(err, 0)).To(BeTrue())
+
+		_, notFound, err = recv(wi, 1, time.Second)
+		Expect(err).To(MatchError("read failure"))
+		Expect(notFound).To(BeFalse())
+	})
+
+	It("should time out and drop late responses", func() {
+		wi := newWI([]*meta.Snode{tsi1})
+		_, notFound, err := recv(wi, 0, 10*time.Millisecond)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("timed out"))
+		Expect(notFound).To(BeFalse())
+
+		deliver(wi, 0, "late")
+		Expect(wi.buffered.Load()).To(BeZero())
+		Expect(wi.slots[0].sgl).To(BeNil())
+	})
+
+	It("should free buffered entries upon cleanup", func() {
+		wi := newWI([]*meta.Snode{tsi1, tsi2})
+		deliver(wi, 1, "never read")
+		wi.cleanup()
+		Expect(wi.slots[1].sgl).To(BeNil())
+
+		// (late delivery after cleanup)
+		deliver(wi, 0, "late")
+		Expect(wi.slots[0].sgl).To(BeNil())
+	})
+
+	It("should open local entries: whole, range, not found", func() {
+		testPutObj(&testBck, "batch-local", []byte("0123456789"))
+
+		e := &cmn.GetBatchEntry{Bck: cmn.Bck{Name: testBucket, Provider: apc.AIS}, ObjName: "batch-local"}
+		br, err := bm.open(e)
+		Expect(err).NotTo(HaveOccurred())
+		b, _ := io.ReadAll(br)
+		br.Close()
+		Expect(string(b)).To(Equal("0123456789"))
+
+		e.Start, e.Length = 3, 4
+		br, err = bm.open(e)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(br.size).To(Equal(int64(4)))
+		b, _ = io.ReadAll(br)
+		br.Close()
+		Expect(string(b)).To(Equal("3456"))
+
+		e.Start, e.Length = 11, 0
+		_, err = bm.open(e)
+		Expect(err).To(HaveOccurred())
+
+		_, err = bm.open(&cmn.GetBatchEntry{Bck: e.Bck, ObjName: "batch-missing"})
+		Expect(err).To(HaveOccurred())
+		Expect(cos.IsNotExist(err, 0)).To(BeTrue())
+	})
+})
+
+// the test bucket (see TestMain)
+var testBck = cmn.Bck{Name: testBucket, Provider: apc.AIS, Ns: cmn.NsGlobal}
+
+// PUT (overwrite) object
+func testPutObj(bck *cmn.Bck, objName string, content []byte) {
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	Expect(lom.InitBck(bck)).NotTo(HaveOccurred())
+	poi := &putOI{
+		atime:   time.Now().UnixNano(),
+		t:       t,
+		lom:     lom,
+		r:       io.NopCloser(bytes.NewReader(content)),
+		workFQN: path.Join(testMountpath, objName+".work"),
+		config:  cmn.GCO.Get(),
+		owt:     cmn.OwtPut,
+		skipVC:  true,
+	}
+	_, err := poi.putObject()
+	Expect(err).NotTo(HaveOccurred())
+}
+
+// in-process DT <=> owner streams (compare with bundle.Streams)
+type batchLoopback struct {
+	bm      *batchMgr
+	resp    bool
+	dropDel bool // owner: ignore delete requests
+}
+
+func (lb *batchLoopback) Send(obj *transport.Obj, roc cos.ReadOpenCloser, _ ...*meta.Snode) (err error) {
+	hdr := obj.Hdr
+	if lb.resp {
+		var r io.Reader = bytes.NewReader(nil)
+		if roc != nil {
+			r = roc
+		}
+		err = lb.bm.resp(&hdr, r)
+	} else if !lb.dropDel || hdr.Opcode != opcodeBatchDel {
+		req := &batchReq{}
+		if err = jsoniter.NewDecoder(roc).Decode(req); err == nil {
+			err = lb.bm.req(&hdr, req)
+		}
+	}
+	if roc != nil {
+		roc.Close()
+	}
+	return err
+}
+
+func (*batchLoopback) Close(bool) {}
+
+// the target (see TestMain) and another one, both served in-process via loopback;
+// returns object names owned by the respective targets, and the cleanup function
+func batchLoopbackSetup(bm *batchMgr, prefix string, num int) (local, remote []string, cleanup func()) {
+	var (
+		prev = t.owner.smap.get()
+		smap = newSmap()
+		tsi2 = newSnode("t2", apc.Target, meta.NetInfo{}, meta.NetInfo{}, meta.NetInfo{})
+		bck  = meta.NewBck(testBucket, apc.AIS, cmn.NsGlobal)
+	)
+	if prev == nil {
+		prev = newSmap()
+	}
+	smap.addTarget(t.si)
+	smap.addTarget(tsi2)
+	smap.Version = prev.Version + 1
+	t.owner.smap.put(smap)
+
+	bm.streams.req = &batchLoopback{bm: bm}
+	bm.streams.resp = &batchLoopback{bm: bm, resp: true}
+
+	for i := 0; len(local) < num || len(remote) < num; i++ {
+		name := prefix + strconv.Itoa(i)
+		tsi, err := smap.HrwName2T(bck.MakeUname(name))
+		Expect(err).NotTo(HaveOccurred())
+		if tsi.ID() == t.SID() {
+			if len(local) < num {
+				local = append(local, name)
+			}
+		} else if len(remote) < num {
+			remote = append(remote, name)
+		}
+	}
+	cleanup = func() {
+		prev.Version = smap.Version + 1
+		t.owner.smap.put(prev)
+	}
+	return local, remote, cleanup
+}
@@ -160,6 +160,22 @@ func (t *target) httpbckget(w http.ResponseWriter, r *http.Request, dpq *dpq) {
 			}
 		}
 		t.bsumm(w, r, phase, bck, &bsumMsg, dpq)
+	case apc.ActSearchMD:
+		if len(apiItems) == 0 {
+			t.writeErrURL(w, r)
+			return
+		}
+		qbck, err := newQbckFromQ(apiItems[0], nil, dpq)
+		if err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		bck := (*meta.Bck)(qbck)
+		if err := bck.Init(t.owner.bmd); err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		t.searchMD(w, r, bck, &msg.ActMsg)
 	default:
 		t.writeErrAct(w, r, msg.Action)
 	}
@@ -410,9 +426,23 @@ func (t *target) httpbckdelete(w http.ResponseWriter, r *http.Request, apireq *a
 			t.writeErr(w, r, rns.Err)
 			return
 		}
-		xctn := rns.Entry.Get()
+		var (
+			xctn = rns.Entry.Get()
+			fn   = t.notifyTerm
+		)
+		if msg.Action == apc.ActDeleteObjects && usageEnabled() {
+			// usage accounting: the requesting user gets to count deleted objects
+			// (stored bytes are uncharged from the owners - see DeleteObject)
+			user := apireq.query.Get(apc.QparamUser)
+			fn = func(n core.Notif, err error, aborted bool) {
+				if objs := xctn.Objs(); objs > 0 {
+					t.usage.add(user, apireq.bck, usageDel, objs, 0)
+				}
+				t.notifyTerm(n, err, aborted)
+			}
+		}
 		notif := &xact.NotifXact{
-			Base: nl.Base{When: core.UponTerm, Dsts: []string{equalIC}, F: t.notifyTerm},
+			Base: nl.Base{When: core.UponTerm, Dsts: []string{equalIC}, F: fn},
 			Xact: xctn,
 		}
 		xctn.AddNotif(notif)
@@ -0,0 +1,293 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/transport"
+)
+
+// Compose (POST /v1/objects/<bucket-name>/<object-name> with apc.ActComposeObject; see cmn.ComposeMsg):
+// - gateway redirects the request to the destination's owner;
+// - the latter reuses get-batch machinery (see tgtbatch.go) to read all sources in order:
+//   local sources are read directly, remote ones are requested from their respective owners
+//   (within a sliding window) and buffered (SGL) until their turn;
+// - the resulting stream is then written as a regular PUT (checksum, versioning, copies, EC, remote backend);
+// - upon success and if requested, sources get deleted: local ones - directly, remote ones -
+//   by their owners (opcodeBatchDel) that report back; a source that has changed since it was
+//   read (see composeTag) does not get deleted; failure to delete any of the sources (including
+//   the changed ones) fails the request (with the destination in place).
+
+type composeReader struct {
+	bm      *batchMgr
+	wi      *batchwi
+	sources []cmn.GetBatchEntry
+	tags    []string     // sources as read (see composeTag)
+	br      *batchReader // current local source
+	sgl     *memsys.SGL  // current remote source
+	timeout time.Duration
+	idx     int
+}
+
+// (internal) source tag, owner => DT (see composeTag)
+const composeTagMD = "compose-tag"
+
+// interface guard
+var _ io.ReadCloser = (*composeReader)(nil)
+
+func (t *target) compose(lom *core.LOM, msg *cmn.ComposeMsg) error {
+	bm := &t.batch
+	wi, err := bm.start(msg.Sources)
+	if err != nil {
+		return err
+	}
+	defer bm.fini(wi)
+
+	cr := &composeReader{bm: bm, wi: wi, sources: msg.Sources, timeout: cmn.GCO.Get().Timeout.SendFile.D()}
+	params := core.AllocPutParams()
+	{
+		params.WorkTag = fs.WorkfileCompose
+		params.Reader = cr
+		params.Atime = time.Now()
+		params.OWT = cmn.OwtPut
+	}
+	err = t.PutObject(lom, params)
+	core.FreePutParams(params)
+	cr.Close()
+	if err != nil {
+		return err
+	}
+	if msg.DeleteSources {
+		return bm.delSources(lom, msg.Sources, cr.tags)
+	}
+	return nil
+}
+
+///////////////////
+// composeReader //
+///////////////////
+
+// reads sources back to back; a missing (or otherwise failed) source fails the read
+func (cr *composeReader) Read(b []byte) (n int, err error) {
+	for {
+		if cr.br == nil && cr.sgl == nil {
+			if cr.idx >= len(cr.sources) {
+				return 0, io.EOF
+			}
+			if err = cr.next(); err != nil {
+				return 0, err
+			}
+		}
+		if cr.br != nil {
+			n, err = cr.br.Read(b)
+		} else {
+			n, err = cr.sgl.Read(b)
+		}
+		if err != io.EOF {
+			return n, err
+		}
+		cr.closeCur()
+		cr.idx++
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+func (cr *composeReader) next() (err error) {
+	e := &cr.sources[cr.idx]
+	if cr.tags == nil {
+		cr.tags = make([]string, len(cr.sources))
+	}
+	cr.bm.pull(cr.wi, cr.idx)
+	if cr.wi.slots[cr.idx] == nil {
+		if cr.br, err = cr.bm.open(e); err == nil {
+			cr.tags[cr.idx] = composeTag(cr.br.lom) // (under rlock)
+		}
+		return err
+	}
+	if cr.sgl, _, err = cr.wi.recv(cr.idx, e.Bck.Cname(e.ObjName), cr.timeout); err == nil {
+		cr.tags[cr.idx] = cr.wi.tag(cr.idx)
+	}
+	return err
+}
+
+// (is called by PUT upon completion, and again by the caller)
+func (cr *composeReader) Close() error {
+	cr.closeCur()
+	return nil
+}
+
+func (cr *composeReader) closeCur() {
+	if cr.br != nil {
+		cr.br.Close()
+		cr.br = nil
+	}
+	if cr.sgl != nil {
+		cr.sgl.Free()
+		cr.sgl = nil
+	}
+}
+
+//
+// delete sources
+//
+
+// skip duplicates and the destination itself; local sources are deleted directly, remote ones -
+// by their respective owners that report back (opcodeBatchDel); a source gets deleted only
+// if unchanged since it was read (tags); returns an error that counts (and names up to a few of)
+// the sources that were changed or failed to get deleted - the destination stays
+func (bm *batchMgr) delSources(lom *core.LOM, sources []cmn.GetBatchEntry, tags []string) error {
+	var (
+		dels  = make([]cmn.GetBatchEntry, 0, len(sources))
+		dtags = make([]string, 0, len(sources))
+		seen  = make(map[string]int, len(sources)) // uname => index in dels
+	)
+	seen[lom.Uname()] = -1
+	for i := range sources {
+		var (
+			e     = &sources[i]
+			uname = cos.UnsafeS(e.Bck.MakeUname(e.ObjName))
+			tag   string
+		)
+		if i < len(tags) {
+			tag = tags[i]
+		}
+		if j, ok := seen[uname]; ok {
+			if j >= 0 && dtags[j] != tag {
+				dtags[j] = "" // read twice, and changed in between
+			}
+			continue
+		}
+		seen[uname] = len(dels)
+		dels = append(dels, cmn.GetBatchEntry{Bck: e.Bck, ObjName: e.ObjName})
+		dtags = append(dtags, tag)
+	}
+	if len(dels) == 0 {
+		return nil
+	}
+	smap := bm.t.owner.smap.get()
+	reqs, err := bm.resolve(dels, smap)
+	if err != nil {
+		return fmt.Errorf("compose %s: failed to delete sources: %w", lom.Cname(), err)
+	}
+	wi := &batchwi{smap: smap, slots: make([]*batchSlot, len(dels)), entries: dels}
+	if len(reqs) > 0 {
+		wi.uuid = cos.GenUUID()
+		for _, req := range reqs {
+			req.UUID = wi.uuid
+			req.Tags = make([]string, len(req.Idx))
+			for i, idx := range req.Idx {
+				wi.slots[idx] = &batchSlot{done: make(chan struct{})}
+				req.Tags[i] = dtags[idx]
+			}
+		}
+		bm.pending.Store(wi.uuid, wi)
+		defer bm.fini(wi)
+		bm.send(reqs, smap, opcodeBatchDel, wi)
+	}
+
+	var (
+		errs    cos.Errs
+		failed  int
+		timeout = cmn.GCO.Get().Timeout.SendFile.D()
+	)
+	for i := range dels {
+		var (
+			e    = &dels[i]
+			name = e.Bck.Cname(e.ObjName)
+		)
+		if wi.slots[i] == nil {
+			err = bm.delOne(e, dtags[i])
+		} else {
+			var sgl *memsys.SGL
+			if sgl, _, err = wi.recv(i, name, timeout); sgl != nil {
+				sgl.Free()
+			}
+		}
+		if err != nil && !cos.IsNotExist(err, 0) {
+			nlog.Warningln(bm.t.String(), "compose", lom.Cname(), "- failed to delete", name, "[", err, "]")
+			errs.Add(err)
+			failed++
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("compose %s: failed to delete %d (out of %d) source%s: %v", lom.Cname(), failed, len(dels), cos.Plural(len(dels)), &errs)
+}
+
+// owner: delete and report back to DT (see delSources)
+func (bm *batchMgr) del(req *batchReq, tsi *meta.Snode) {
+	bm.rlock()
+	defer bm.streams.RUnlock()
+	for i := range req.Entries {
+		var (
+			e = &req.Entries[i]
+			o = transport.AllocSend()
+		)
+		o.Hdr.Opaque = batchOpaque(req.UUID, req.Idx[i])
+		o.Hdr.ObjName = e.ObjName
+		var tag string
+		if i < len(req.Tags) {
+			tag = req.Tags[i]
+		}
+		if err := bm.delOne(e, tag); err != nil {
+			o.Hdr.Opcode = opcodeBatchErr
+			if cos.IsNotExist(err, 0) {
+				o.Hdr.Opcode = opcodeBatchNotFound
+			}
+			o.Hdr.ObjName = err.Error()
+		}
+		if err := bm.streams.resp.Send(o, nil, tsi); err != nil {
+			nlog.Warningln(bm.t.String(), "compose", req.UUID, "=>", tsi.StringEx(), "[", err, "]")
+			return
+		}
+	}
+}
+
+// delete unless changed since read
+func (bm *batchMgr) delOne(e *cmn.GetBatchEntry, tag string) error {
+	lom := core.AllocLOM(e.ObjName)
+	err := lom.InitBck(&e.Bck)
+	if err == nil {
+		_, err = bm.t.deleteObjectIf(lom, false /*evict*/, func(lom *core.LOM) error {
+			if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+				return err
+			}
+			if tag == "" || composeTag(lom) != tag {
+				return fmt.Errorf("%s: changed since read (not deleted)", lom.Cname())
+			}
+			return nil
+		})
+	}
+	core.FreeLOM(lom)
+	return err
+}
+
+// identifies the source object's content as read: size, version, checksum, and
+// the modification time of its file (an overwrite always results in a new one)
+func composeTag(lom *core.LOM) string {
+	var cksum, mtime string
+	if ck := lom.Checksum(); ck != nil {
+		cksum = ck.Value()
+	}
+	if finfo, err := os.Stat(lom.FQN); err == nil {
+		mtime = strconv.FormatInt(finfo.ModTime().UnixNano(), 10)
+	}
+	return strconv.FormatInt(lom.Lsize(), 10) + "|" + lom.Version() + "|" + cksum + "|" + mtime
+}
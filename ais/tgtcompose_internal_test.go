@@ -0,0 +1,175 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// read the entire object
+func testGetObj(bck *cmn.Bck, objName string) ([]byte, error) {
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck); err != nil {
+		return nil, err
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(false, true); err != nil {
+		return nil, err
+	}
+	fh, err := lom.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer cos.Close(fh)
+	return io.ReadAll(fh)
+}
+
+var _ = Describe("Compose", func() {
+	var (
+		bm            *batchMgr
+		local, remote []string
+		cleanup       func()
+	)
+	compose := func(dst string, msg *cmn.ComposeMsg) error {
+		Expect(msg.Init(&testBck)).NotTo(HaveOccurred())
+		lom := core.AllocLOM(dst)
+		defer core.FreeLOM(lom)
+		Expect(lom.InitBck(&testBck)).NotTo(HaveOccurred())
+		return t.compose(lom, msg)
+	}
+	src := func(name string) cmn.GetBatchEntry { return cmn.GetBatchEntry{ObjName: name} }
+	setTimeout := func(timeout time.Duration) (prev cos.Duration) {
+		config := cmn.GCO.BeginUpdate()
+		prev = config.Timeout.SendFile
+		config.Timeout.SendFile = cos.Duration(timeout)
+		cmn.GCO.CommitUpdate(config)
+		return prev
+	}
+
+	BeforeEach(func() {
+		prev := setTimeout(10 * time.Second)
+		DeferCleanup(func() { setTimeout(prev.D()) })
+
+		// (compose uses the target's batch manager)
+		bm = &t.batch
+		bm.t = t
+		local, remote, cleanup = batchLoopbackSetup(bm, "compose-src-", 2)
+		for _, name := range append(local, remote...) {
+			testPutObj(&testBck, name, []byte(name+";"))
+		}
+	})
+	AfterEach(func() {
+		cleanup()
+		bm.streams.req, bm.streams.resp = nil, nil
+	})
+
+	It("should compose local and remote sources in order", func() {
+		msg := &cmn.ComposeMsg{Sources: []cmn.GetBatchEntry{src(remote[0]), src(local[0]), src(remote[1]), src(local[1])}}
+		Expect(compose("composed", msg)).NotTo(HaveOccurred())
+
+		b, err := testGetObj(&testBck, "composed")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(remote[0] + ";" + local[0] + ";" + remote[1] + ";" + local[1] + ";"))
+	})
+
+	It("should compose ranges", func() {
+		var (
+			r0 = cmn.GetBatchEntry{ObjName: remote[0], Start: 1, Length: 3}
+			l0 = cmn.GetBatchEntry{ObjName: local[0], Start: int64(len(local[0]))} // the trailing ';'
+		)
+		msg := &cmn.ComposeMsg{Sources: []cmn.GetBatchEntry{r0, l0}}
+		Expect(compose("composed-ranges", msg)).NotTo(HaveOccurred())
+
+		b, err := testGetObj(&testBck, "composed-ranges")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(remote[0][1:4] + ";"))
+	})
+
+	It("should append to the destination when it is one of the sources; delete the other sources", func() {
+		dst := local[1]
+		msg := &cmn.ComposeMsg{
+			Sources:       []cmn.GetBatchEntry{src(dst), src(remote[0]), src(local[0]), src(remote[0])},
+			DeleteSources: true,
+		}
+		Expect(compose(dst, msg)).NotTo(HaveOccurred())
+
+		b, err := testGetObj(&testBck, dst)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(dst + ";" + remote[0] + ";" + local[0] + ";" + remote[0] + ";"))
+
+		for _, name := range []string{remote[0], local[0]} {
+			_, err := testGetObj(&testBck, name)
+			Expect(cos.IsNotExist(err, 0)).To(BeTrue(), name)
+		}
+		_, err = testGetObj(&testBck, remote[1])
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should fail when a source is missing", func() {
+		msg := &cmn.ComposeMsg{Sources: []cmn.GetBatchEntry{src(local[0]), src("compose-missing")}}
+		Expect(compose("composed-missing", msg)).To(HaveOccurred())
+		_, err := testGetObj(&testBck, "composed-missing")
+		Expect(cos.IsNotExist(err, 0)).To(BeTrue())
+	})
+
+	It("should not delete sources that changed since read", func() {
+		tag := func(name string) string {
+			lom := core.AllocLOM(name)
+			defer core.FreeLOM(lom)
+			Expect(lom.InitBck(&testBck)).NotTo(HaveOccurred())
+			Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+			return composeTag(lom)
+		}
+		var (
+			bsrc    = func(name string) cmn.GetBatchEntry { return cmn.GetBatchEntry{Bck: testBck, ObjName: name} }
+			sources = []cmn.GetBatchEntry{bsrc(local[0]), bsrc(remote[0]), bsrc(local[1])}
+			tags    = []string{tag(local[0]), tag(remote[0]), tag(local[1])}
+		)
+		time.Sleep(10 * time.Millisecond) // (distinct mtime)
+		testPutObj(&testBck, local[0], []byte("overwritten"))
+		testPutObj(&testBck, remote[0], []byte("overwritten"))
+
+		lom := core.AllocLOM("composed-changed")
+		defer core.FreeLOM(lom)
+		Expect(lom.InitBck(&testBck)).NotTo(HaveOccurred())
+		err := bm.delSources(lom, sources, tags)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("failed to delete 2 (out of 3) sources"))
+
+		for _, name := range []string{local[0], remote[0]} {
+			b, err := testGetObj(&testBck, name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal("overwritten"))
+		}
+		_, err = testGetObj(&testBck, local[1])
+		Expect(cos.IsNotExist(err, 0)).To(BeTrue())
+	})
+
+	It("should report sources that failed to get deleted", func() {
+		setTimeout(100 * time.Millisecond)
+		bm.streams.req.(*batchLoopback).dropDel = true
+
+		msg := &cmn.ComposeMsg{Sources: []cmn.GetBatchEntry{src(local[0]), src(remote[0]), src(remote[1])}, DeleteSources: true}
+		err := compose("composed-partial", msg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("failed to delete 2 (out of 3) sources"))
+
+		// the destination stays, local source is deleted
+		b, err := testGetObj(&testBck, "composed-partial")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(local[0] + ";" + remote[0] + ";" + remote[1] + ";"))
+		_, err = testGetObj(&testBck, local[0])
+		Expect(cos.IsNotExist(err, 0)).To(BeTrue())
+	})
+})
@@ -290,8 +290,11 @@ func (t *target) headObjectETL(w http.ResponseWriter, r *http.Request) {
 	lom := core.AllocLOM(objName)
 	ecode, err := t.objHead(r, w.Header(), r.URL.Query(), bck, lom)
 	core.FreeLOM(lom)
-	if err != nil {
+	switch {
+	case err != nil:
 		// always silent (compare w/ httpobjhead)
 		t.writeErr(w, r, err, ecode, Silent)
+	case ecode == http.StatusNotModified:
+		w.WriteHeader(ecode)
 	}
 }
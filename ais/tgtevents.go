@@ -0,0 +1,715 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/stats"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Object event notifications (see cmn/events.go):
+// - for each (bucket, rule) target maintains a journal: a directory under
+//   config-dir/.ais.events/<BID>.<rule-ID>/ containing numbered segments
+//   of (at most evSegEvents) JSON-encoded events, one per line;
+// - each appended event is fsync-ed (group commit) before the corresponding write (or delete)
+//   gets acknowledged;
+// - retention: the oldest segment gets removed when the number of segments exceeds evMaxSegs -
+//   unless it contains events that have not yet been acknowledged (webhook and replication: delivered;
+//   local queue: long-polled with the token that follows), in which case the journal may grow up to
+//   evMaxSegsUnacked segments; discarded unacknowledged events are counted (stats.EventLostCount);
+// - webhook rules: journal is delivered by a dedicated goroutine that
+//   persists its position ("cursor") upon each acknowledged (2xx) batch
+//   and retries with exponential backoff otherwise;
+// - local queue: long-polled via proxy (see prxevents.go) that broadcasts
+//   GET /v1/events to all targets and merges the results;
+// - housekeeping: resumes webhook deliveries (e.g., upon restart) and removes the journals
+//   of deleted rules (and buckets);
+// - the same journal (and delivery) mechanism is used for bucket-to-bucket replication (see tgtrepl.go).
+
+const (
+	evSegEvents      = 1024             // events per journal segment
+	evMaxSegs        = 64               // max segments per journal (see also evMaxSegsUnacked)
+	evMaxSegsUnacked = 1024             // max segments per journal with unacknowledged events
+	evPollMax        = 1000             // max events per target per long-poll
+	evHookBatch      = 100              // max events per webhook POST
+	evHookIdle       = 10 * time.Second // webhook: wait for new events before re-checking the rule
+	evBackoffMin     = time.Second
+	evBackoffMax     = time.Minute
+	evHkIval         = time.Minute
+	evHkStartup      = 10 * time.Second
+
+	evSegSuffix = ".log"
+	evCursor    = "cursor"
+	evBucket    = "bucket"
+)
+
+type (
+	evMgr struct {
+		t      *target
+		client *http.Client
+		jrnls  map[string]*evJournal // by (journal) directory name
+		dir    string
+		mu     sync.Mutex
+	}
+	evJournal struct {
+		mgr    *evMgr
+		fh     *os.File      // current (last) segment
+		notify chan struct{} // closed and replaced upon append
+		bck    cmn.Bck
+		ruleID string
+		dir    string
+		bid    uint64
+		seq    int64 // last appended
+		oldest int64 // oldest (retained) segment
+		synced int64 // last fsync-ed
+		mu     sync.Mutex
+		cursor atomic.Int64 // last acknowledged; -1 when none
+		hook   atomic.Bool  // webhook delivery (or replication) is running
+		// replication only
+		replDest string      // destination as of the last catch-up scan
+		lag      int64       // last reported replication lag
+		scan     atomic.Bool // catch-up scan is running
+	}
+)
+
+func (em *evMgr) init(t *target, config *cmn.Config) {
+	em.t = t
+	em.dir = filepath.Join(config.ConfigDir, fname.EventsDir)
+	em.jrnls = make(map[string]*evJournal, 4)
+	em.client = cmn.NewClient(cmn.TransportArgs{Timeout: config.Client.Timeout.D(), UseHTTPProxyEnv: true})
+	hk.Reg("events"+hk.NameSuffix, em.housekeep, 0 /*right away*/)
+}
+
+func evJdir(bid uint64, ruleID string) string { return strconv.FormatUint(bid, 16) + "." + ruleID }
+
+// PUT (and friends), DELETE, evict, cold GET
+func (em *evMgr) emit(lom *core.LOM, event string) {
+	var (
+		ev     *cmn.ObjEvent
+		bprops = lom.Bprops()
+	)
+	if bprops.Replication.Enabled && (event == cmn.EventObjCreated || event == cmn.EventObjRemoved) {
+		ev = em.newEvent(lom, event)
+		em.append(lom, replJournal, ev, true /*deliver*/)
+	}
+	conf := &bprops.Events
+	if !conf.IsSet() {
+		return
+	}
+	for i := range conf.Rules {
+		rule := &conf.Rules[i]
+		if !rule.Match(event, lom.ObjName) {
+			continue
+		}
+		if ev == nil {
+			ev = em.newEvent(lom, event)
+		}
+		em.append(lom, rule.ID, ev, rule.Webhook != "")
+	}
+}
+
+func (em *evMgr) newEvent(lom *core.LOM, event string) *cmn.ObjEvent {
+	return &cmn.ObjEvent{
+		Event:   event,
+		ObjName: lom.ObjName,
+		Version: lom.Version(true),
+		Node:    em.t.SID(),
+		Bck:     *lom.Bucket(),
+		Size:    lom.Lsize(true),
+		Time:    time.Now().UnixNano(),
+	}
+}
+
+func (em *evMgr) append(lom *core.LOM, ruleID string, ev *cmn.ObjEvent, deliver bool) {
+	j, err := em.journal(lom.Bck(), ruleID)
+	if err == nil {
+		err = j.append(ev)
+	}
+	if err != nil {
+		nlog.Errorln(em.t.String(), "failed to journal", ev.Event, "event for", lom.Cname(), "[", err, "]")
+		em.lost(lom.Bucket(), 1)
+		return
+	}
+	if deliver {
+		j.deliver()
+	}
+}
+
+// get or open (create)
+func (em *evMgr) journal(bck *meta.Bck, ruleID string) (*evJournal, error) {
+	name := evJdir(bck.Props.BID, ruleID)
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	if j, ok := em.jrnls[name]; ok {
+		return j, nil
+	}
+	j := &evJournal{mgr: em, bck: *bck.Bucket(), ruleID: ruleID, dir: filepath.Join(em.dir, name), bid: bck.Props.BID}
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+	em.jrnls[name] = j
+	return j, nil
+}
+
+// current bucket props or nil (deleted bucket)
+func (em *evMgr) bprops(bck *cmn.Bck, bid uint64) *cmn.Bprops {
+	bprops, present := em.t.owner.bmd.get().Get(meta.CloneBck(bck))
+	if !present || bprops.BID != bid {
+		return nil
+	}
+	return bprops
+}
+
+// delivery callback (returns the number of delivered events) or nil when there's nothing to deliver
+// (deleted bucket or rule, local-queue rule, disabled replication)
+func (em *evMgr) sender(j *evJournal) func([]cmn.ObjEvent) (int, error) {
+	bprops := em.bprops(&j.bck, j.bid)
+	switch {
+	case bprops == nil:
+		return nil
+	case j.ruleID == replJournal:
+		if !bprops.Replication.Enabled {
+			return nil
+		}
+		return em.replicator(j, bprops)
+	}
+	rule := bprops.Events.Rule(j.ruleID)
+	if rule == nil || rule.Webhook == "" {
+		return nil
+	}
+	webhook := rule.Webhook
+	return func(evs []cmn.ObjEvent) (int, error) {
+		if err := em.post(webhook, evs); err != nil {
+			return 0, err
+		}
+		return len(evs), nil
+	}
+}
+
+func (em *evMgr) housekeep(int64) time.Duration {
+	if !em.t.ClusterStarted() {
+		return evHkStartup
+	}
+	dents, err := os.ReadDir(em.dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			nlog.Errorln(em.t.String(), "events:", err)
+		}
+		return evHkIval
+	}
+	for _, dent := range dents {
+		if !dent.IsDir() {
+			continue
+		}
+		name := dent.Name()
+		bck, bid, ruleID, err := em.parse(name)
+		if err != nil {
+			nlog.Warningln(em.t.String(), "events: removing invalid journal", name, "[", err, "]")
+			em.remove(name)
+			continue
+		}
+		var (
+			rule   *cmn.EventRule
+			bprops = em.bprops(bck, bid)
+		)
+		switch {
+		case bprops == nil:
+		case ruleID == replJournal:
+			if bprops.Replication.Enabled {
+				continue // (see below)
+			}
+		default:
+			rule = bprops.Events.Rule(ruleID)
+		}
+		if rule == nil {
+			nlog.Infoln(em.t.String(), "events: removing journal", bck.Cname(""), ruleID)
+			em.remove(name)
+			continue
+		}
+		if rule.Webhook == "" {
+			continue
+		}
+		// resume delivery (catch up)
+		mbck := meta.CloneBck(bck)
+		mbck.Props = bprops
+		j, err := em.journal(mbck, ruleID)
+		if err != nil {
+			nlog.Errorln(em.t.String(), "events:", err)
+			continue
+		}
+		j.deliver()
+	}
+
+	// replication: resume (or start) replaying journals - and catch up
+	em.t.owner.bmd.get().Range(nil, nil, func(bck *meta.Bck) bool {
+		if !bck.Props.Replication.Enabled {
+			return false
+		}
+		j, err := em.journal(bck, replJournal)
+		if err != nil {
+			nlog.Errorln(em.t.String(), "replication:", err)
+			return false
+		}
+		j.deliver()
+		return false
+	})
+	return evHkIval
+}
+
+func (em *evMgr) lost(bck *cmn.Bck, n int64) {
+	vlabs := map[string]string{stats.VarlabBucket: bck.Cname("")}
+	em.t.statsT.AddWith(cos.NamedVal64{Name: stats.EventLostCount, Value: n, VarLabs: vlabs})
+}
+
+func (em *evMgr) parse(name string) (bck *cmn.Bck, bid uint64, ruleID string, err error) {
+	i := strings.IndexByte(name, '.')
+	if i <= 0 {
+		return nil, 0, "", fmt.Errorf("unexpected name %q", name)
+	}
+	if bid, err = strconv.ParseUint(name[:i], 16, 64); err != nil {
+		return nil, 0, "", err
+	}
+	ruleID = name[i+1:]
+	b, err := os.ReadFile(filepath.Join(em.dir, name, evBucket))
+	if err != nil {
+		return nil, 0, "", err
+	}
+	bck = &cmn.Bck{}
+	err = jsoniter.Unmarshal(b, bck)
+	return bck, bid, ruleID, err
+}
+
+func (em *evMgr) remove(name string) {
+	em.mu.Lock()
+	if j, ok := em.jrnls[name]; ok {
+		j.close()
+		delete(em.jrnls, name)
+	}
+	em.mu.Unlock()
+	if err := os.RemoveAll(filepath.Join(em.dir, name)); err != nil {
+		nlog.Errorln(em.t.String(), "events:", err)
+	}
+}
+
+func (em *evMgr) post(url string, evs []cmn.ObjEvent) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(cos.MustMarshal(evs)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(cos.HdrContentType, cos.ContentJSON)
+	resp, err := em.client.Do(req)
+	if err != nil {
+		return err
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook %s: %s", url, resp.Status)
+	}
+	return nil
+}
+
+///////////////
+// evJournal //
+///////////////
+
+func (j *evJournal) segPath(idx int64) string {
+	return filepath.Join(j.dir, fmt.Sprintf("%012d", idx)+evSegSuffix)
+}
+
+// create or recover (the last segment's partially written event, if any, gets truncated)
+func (j *evJournal) open() error {
+	j.notify = make(chan struct{})
+	if err := cos.CreateDir(j.dir); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(j.dir, evBucket), cos.MustMarshal(&j.bck), cos.PermRWR); err != nil {
+		return err
+	}
+	dents, err := os.ReadDir(j.dir)
+	if err != nil {
+		return err
+	}
+	j.cursor.Store(j.loadCursor())
+	first, last := int64(-1), int64(-1)
+	for _, dent := range dents {
+		name := dent.Name()
+		if !strings.HasSuffix(name, evSegSuffix) {
+			continue
+		}
+		if idx, err := strconv.ParseInt(strings.TrimSuffix(name, evSegSuffix), 10, 64); err == nil {
+			last = max(last, idx)
+			if first < 0 || idx < first {
+				first = idx
+			}
+		}
+	}
+	if last < 0 {
+		return nil
+	}
+	j.oldest = first
+	fqn := j.segPath(last)
+	b, err := os.ReadFile(fqn)
+	if err != nil {
+		return err
+	}
+	n := bytes.LastIndexByte(b, '\n') + 1
+	if n < len(b) {
+		if err := os.Truncate(fqn, int64(n)); err != nil {
+			return err
+		}
+	}
+	cnt := bytes.Count(b[:n], []byte{'\n'})
+	j.seq = last*evSegEvents + int64(cnt)
+	j.synced = j.seq
+	if cnt < evSegEvents {
+		j.fh, err = os.OpenFile(fqn, os.O_WRONLY|os.O_APPEND, cos.PermRWR)
+	}
+	return err
+}
+
+func (j *evJournal) close() {
+	j.mu.Lock()
+	if j.fh != nil {
+		if err := j._sync(); err != nil {
+			nlog.Errorln("events:", j.bck.Cname(""), j.ruleID, "[", err, "]")
+		}
+		cos.Close(j.fh)
+		j.fh = nil
+	}
+	j.mu.Unlock()
+}
+
+// append and commit
+func (j *evJournal) append(ev *cmn.ObjEvent) error {
+	seq, err := j._append(ev)
+	if err != nil {
+		return err
+	}
+	return j.commit(seq)
+}
+
+func (j *evJournal) _append(ev *cmn.ObjEvent) (int64, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.fh == nil || j.seq%evSegEvents == 0 {
+		if err := j.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	ev.Seq, ev.RuleID = j.seq+1, j.ruleID
+	b := cos.MustMarshal(ev)
+	if _, err := j.fh.Write(append(b, '\n')); err != nil {
+		return 0, err
+	}
+	j.seq++
+	close(j.notify)
+	j.notify = make(chan struct{})
+	return j.seq, nil
+}
+
+// group commit: returns once the event `seq` is fsync-ed - by this or another caller,
+// along with all the events appended in the meantime
+func (j *evJournal) commit(seq int64) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.synced >= seq {
+		return nil
+	}
+	return j._sync()
+}
+
+// under lock
+func (j *evJournal) _sync() error {
+	if j.fh == nil || j.synced == j.seq {
+		return nil
+	}
+	if err := j.fh.Sync(); err != nil {
+		return err
+	}
+	j.synced = j.seq
+	return nil
+}
+
+// under lock
+func (j *evJournal) rotate() (err error) {
+	if j.fh != nil {
+		if err := j._sync(); err != nil {
+			return err
+		}
+		cos.Close(j.fh)
+		j.fh = nil
+	}
+	idx := j.seq / evSegEvents
+	if j.fh, err = os.OpenFile(j.segPath(idx), os.O_CREATE|os.O_WRONLY|os.O_APPEND, cos.PermRWR); err != nil {
+		return err
+	}
+	j.retain(idx)
+	return nil
+}
+
+// under lock; remove the oldest segments (see "retention" above)
+func (j *evJournal) retain(idx int64) {
+	var (
+		cursor = j.cursor.Load()
+		lost   int64
+	)
+	for ; j.oldest <= idx-evMaxSegs; j.oldest++ {
+		if end := (j.oldest + 1) * evSegEvents; cursor < end {
+			if cursor >= 0 && idx-j.oldest < evMaxSegsUnacked {
+				break
+			}
+			lost += end - max(cursor, j.oldest*evSegEvents)
+		}
+		if err := os.Remove(j.segPath(j.oldest)); err != nil && !os.IsNotExist(err) {
+			nlog.Errorln("events:", err)
+		}
+	}
+	if lost > 0 && j.mgr != nil {
+		nlog.Errorln(j.mgr.t.String(), "events", j.bck.Cname(""), j.ruleID, "- discarding", lost,
+			"unacknowledged event(s): exceeded max journal size")
+		j.mgr.lost(&j.bck, lost)
+	}
+}
+
+func (j *evJournal) changed() <-chan struct{} {
+	j.mu.Lock()
+	ch := j.notify
+	j.mu.Unlock()
+	return ch
+}
+
+// events with sequence numbers greater than `after`
+// (when `after` is ahead of the journal - e.g., the latter was removed and re-created - from the beginning)
+func (j *evJournal) read(after int64, limit int) ([]cmn.ObjEvent, error) {
+	j.mu.Lock()
+	last := j.seq
+	j.mu.Unlock()
+	if after > last {
+		after = 0
+	}
+	if after == last {
+		return nil, nil
+	}
+	evs := make([]cmn.ObjEvent, 0, min(int64(limit), last-after))
+	for idx := after / evSegEvents; idx <= (last-1)/evSegEvents && len(evs) < limit; idx++ {
+		fh, err := os.Open(j.segPath(idx))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue // (retention)
+			}
+			return evs, err
+		}
+		scanner := bufio.NewScanner(fh)
+		for scanner.Scan() && len(evs) < limit {
+			var ev cmn.ObjEvent
+			if err := jsoniter.Unmarshal(scanner.Bytes(), &ev); err != nil {
+				break // (being written)
+			}
+			if ev.Seq > after && ev.Seq <= last {
+				evs = append(evs, ev)
+			}
+		}
+		cos.Close(fh)
+	}
+	return evs, nil
+}
+
+// wait for new events (up to the specified duration)
+func (j *evJournal) poll(after int64, limit int, wait time.Duration) ([]cmn.ObjEvent, error) {
+	deadline := mono.NanoTime() + int64(wait)
+	for {
+		ch := j.changed()
+		evs, err := j.read(after, limit)
+		if len(evs) > 0 || err != nil {
+			return evs, err
+		}
+		left := time.Duration(deadline - mono.NanoTime())
+		if left <= 0 {
+			return evs, nil
+		}
+		timer := time.NewTimer(left)
+		select {
+		case <-ch:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+//
+// webhook delivery
+//
+
+func (j *evJournal) deliver() {
+	if j.hook.CAS(false, true) {
+		go j._deliver()
+	}
+}
+
+func (j *evJournal) _deliver() {
+	defer j.undeliver()
+	var (
+		em      = j.mgr
+		cursor  = max(j.cursor.Load(), 0)
+		backoff = evBackoffMin
+	)
+	if j.cursor.Load() < 0 {
+		j.saveCursor(cursor) // from now on, retain unacknowledged events
+	}
+	for {
+		send := em.sender(j)
+		if send == nil {
+			return
+		}
+		ch := j.changed()
+		evs, err := j.read(cursor, evHookBatch)
+		if err == nil && len(evs) == 0 {
+			timer := time.NewTimer(evHookIdle)
+			select {
+			case <-ch:
+			case <-timer.C:
+			}
+			timer.Stop()
+			continue
+		}
+		var n int
+		if err == nil {
+			if evs[0].Seq > cursor+1 {
+				nlog.Warningln(em.t.String(), "events", j.bck.Cname(""), j.ruleID, "- lost", evs[0].Seq-cursor-1,
+					"event(s) due to journal retention")
+				cursor = evs[0].Seq - 1
+				if j.ruleID == replJournal {
+					nlog.Warningln(em.t.String(), "replication", j.bck.Cname(""),
+						"- lost deletions (if any) will not be propagated; catch-up scan copies only existing objects")
+					j.catchup()
+				}
+			}
+			n, err = send(evs)
+		}
+		if n > 0 {
+			cursor = evs[n-1].Seq
+			j.saveCursor(cursor)
+		}
+		if err != nil {
+			nlog.Warningln(em.t.String(), "events", j.bck.Cname(""), j.ruleID, "- retrying in", backoff, "[", err, "]")
+			time.Sleep(backoff)
+			backoff = min(2*backoff, evBackoffMax)
+			continue
+		}
+		backoff = evBackoffMin
+	}
+}
+
+func (j *evJournal) undeliver() {
+	if j.ruleID == replJournal {
+		j.setLag(0)
+		j.replDest = "" // to catch up when re-enabled
+	}
+	j.hook.Store(false)
+}
+
+// -1 when nothing's been acknowledged yet
+func (j *evJournal) loadCursor() int64 {
+	b, err := os.ReadFile(filepath.Join(j.dir, evCursor))
+	if err != nil {
+		return -1
+	}
+	cursor, _ := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+	return cursor
+}
+
+// local queue: the token acknowledges all events up to (and including) `after`
+func (j *evJournal) ack(after int64) {
+	j.mu.Lock()
+	if after > j.cursor.Load() && after <= j.seq {
+		j.saveCursor(after)
+	}
+	j.mu.Unlock()
+}
+
+func (j *evJournal) saveCursor(cursor int64) {
+	j.cursor.Store(cursor)
+	if err := os.WriteFile(filepath.Join(j.dir, evCursor), []byte(strconv.FormatInt(cursor, 10)), cos.PermRWR); err != nil {
+		nlog.Errorln(j.mgr.t.String(), "events:", err)
+	}
+}
+
+//
+// GET /v1/events/<bucket-name> (from proxy; see prxevents.go)
+//
+
+func (t *target) eventsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.WriteErr405(w, r, http.MethodGet)
+		return
+	}
+	apireq := apiReqAlloc(1, apc.URLPathEvents.L, false /*dpq*/)
+	err := t.parseReq(w, r, apireq)
+	bck, query := apireq.bck, apireq.query
+	apiReqFree(apireq)
+	if err != nil {
+		return
+	}
+	if err := bck.Init(t.owner.bmd); err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	ruleID := query.Get(apc.QparamEventRule)
+	rule := bck.Props.Events.Rule(ruleID)
+	if rule == nil {
+		t.writeErr(w, r, cos.NewErrNotFound(t, "event rule "+strconv.Quote(ruleID)+" in bucket "+bck.Cname("")))
+		return
+	}
+	seqs, err := cmn.DecodeEventsToken(query.Get(apc.QparamEventToken))
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	var wait time.Duration
+	if s := query.Get(apc.QparamEventWait); s != "" {
+		if wait, err = time.ParseDuration(s); err != nil {
+			t.writeErr(w, r, err)
+			return
+		}
+		wait = min(max(wait, 0), evMaxWait) // (direct calls included)
+	}
+	j, err := t.events.journal(bck, ruleID)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	after := seqs[t.SID()]
+	if rule.Webhook == "" {
+		j.ack(after)
+	}
+	evs, err := j.poll(after, evPollMax, wait)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	t.writeJSON(w, r, evs, "events")
+}
@@ -0,0 +1,210 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Event journal", func() {
+	var (
+		dir string
+		bck = cmn.Bck{Name: "events", Provider: apc.AIS}
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "events")
+		Expect(err).NotTo(HaveOccurred())
+	})
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	newJournal := func() *evJournal {
+		j := &evJournal{bck: bck, ruleID: "r1", dir: dir, bid: 1}
+		Expect(j.open()).NotTo(HaveOccurred())
+		return j
+	}
+	appendN := func(j *evJournal, n int) {
+		for i := range n {
+			ev := &cmn.ObjEvent{Event: cmn.EventObjCreated, ObjName: "obj", Size: int64(i)}
+			Expect(j.append(ev)).NotTo(HaveOccurred())
+		}
+	}
+
+	It("should read events after a given sequence number", func() {
+		j := newJournal()
+		appendN(j, evSegEvents+10)
+		evs, err := j.read(0, 5)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evs).To(HaveLen(5))
+		Expect(evs[0].Seq).To(Equal(int64(1)))
+		Expect(evs[0].RuleID).To(Equal("r1"))
+
+		evs, err = j.read(evSegEvents-2, evPollMax)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evs).To(HaveLen(12)) // across segments
+		Expect(evs[11].Seq).To(Equal(int64(evSegEvents + 10)))
+
+		evs, err = j.read(evSegEvents+10, evPollMax)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evs).To(BeEmpty())
+		j.close()
+	})
+
+	It("should recover and truncate partially written event", func() {
+		j := newJournal()
+		appendN(j, 3)
+		j.close()
+		fh, err := os.OpenFile(j.segPath(0), os.O_WRONLY|os.O_APPEND, 0o644)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = fh.WriteString(`{"event":"ObjectCr`)
+		Expect(err).NotTo(HaveOccurred())
+		fh.Close()
+
+		j = newJournal()
+		Expect(j.seq).To(Equal(int64(3)))
+		appendN(j, 1)
+		evs, err := j.read(0, evPollMax)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evs).To(HaveLen(4))
+		Expect(evs[3].Seq).To(Equal(int64(4)))
+		j.close()
+	})
+
+	It("should restart from the beginning when ahead of the journal", func() {
+		j := newJournal()
+		appendN(j, 2)
+		evs, err := j.read(100, evPollMax)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evs).To(HaveLen(2))
+		j.close()
+	})
+
+	It("should wake up long-poll upon append", func() {
+		j := newJournal()
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			appendN(j, 1)
+		}()
+		evs, err := j.poll(0, evPollMax, 10*time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evs).To(HaveLen(1))
+		j.close()
+	})
+
+	It("should remove the oldest segments", func() {
+		j := newJournal()
+		appendN(j, evMaxSegs*evSegEvents+1) // (evMaxSegs+1) segments
+		_, err := os.Stat(j.segPath(0))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+		_, err = os.Stat(j.segPath(1))
+		Expect(err).NotTo(HaveOccurred())
+		evs, err := j.read(0, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evs[0].Seq).To(Equal(int64(evSegEvents + 1)))
+		j.close()
+	})
+
+	It("should retain unacknowledged segments", func() {
+		j := newJournal()
+		j.saveCursor(evSegEvents + 5)           // acknowledged: segment #0 and part of #1
+		appendN(j, (evMaxSegs+2)*evSegEvents+1) // (evMaxSegs+3) segments
+		_, err := os.Stat(j.segPath(0))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+		_, err = os.Stat(j.segPath(1))
+		Expect(err).NotTo(HaveOccurred())
+		evs, err := j.read(j.cursor.Load(), 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evs[0].Seq).To(Equal(int64(evSegEvents + 6)))
+
+		// acknowledge all - and rotate
+		j.saveCursor(j.seq)
+		appendN(j, evSegEvents)
+		for idx := range int64(4) {
+			_, err = os.Stat(j.segPath(idx))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		}
+		_, err = os.Stat(j.segPath(4))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(j.oldest).To(Equal(int64(4)))
+		j.close()
+	})
+
+	It("should fsync upon append (group commit)", func() {
+		j := newJournal()
+		appendN(j, 3)
+		Expect(j.synced).To(Equal(int64(3)))
+
+		// concurrent appenders
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				appendN(j, 10)
+			}()
+		}
+		wg.Wait()
+		Expect(j.seq).To(Equal(int64(83)))
+		Expect(j.synced).To(Equal(j.seq))
+		j.close()
+	})
+
+	It("should retain events not yet acknowledged by long-poll", func() {
+		j := newJournal()
+		j.ack(1) // (ahead of the journal)
+		Expect(j.cursor.Load()).To(Equal(int64(-1)))
+
+		appendN(j, 10)
+		j.ack(5)
+		Expect(j.cursor.Load()).To(Equal(int64(5)))
+		j.ack(3) // (older token)
+		Expect(j.cursor.Load()).To(Equal(int64(5)))
+
+		appendN(j, (evMaxSegs+1)*evSegEvents) // (evMaxSegs+2) segments
+		_, err := os.Stat(j.segPath(0))
+		Expect(err).NotTo(HaveOccurred())
+		j.close()
+	})
+
+	It("should compute replication lag from the oldest pending event", func() {
+		j := newJournal()
+		now := time.Now().UnixNano()
+		for i := range 3 {
+			ev := &cmn.ObjEvent{Event: cmn.EventObjCreated, ObjName: "obj", Time: now - int64(3-i)*int64(time.Hour)}
+			Expect(j.append(ev)).NotTo(HaveOccurred())
+		}
+		lag := j.pendingLag(1)
+		Expect(lag).To(BeNumerically(">=", 2*int64(time.Hour)))
+		Expect(lag).To(BeNumerically("<", 3*int64(time.Hour)))
+		Expect(j.pendingLag(3)).To(BeZero()) // caught up
+		j.close()
+	})
+
+	It("should parse replication journal directory", func() {
+		var (
+			em   = &evMgr{dir: dir}
+			name = evJdir(0x1f, replJournal)
+			j    = &evJournal{bck: bck, ruleID: replJournal, dir: filepath.Join(dir, name), bid: 0x1f}
+		)
+		Expect(j.open()).NotTo(HaveOccurred())
+		pbck, bid, ruleID, err := em.parse(name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*pbck).To(Equal(bck))
+		Expect(bid).To(Equal(uint64(0x1f)))
+		Expect(ruleID).To(Equal(replJournal))
+		j.close()
+	})
+})
@@ -0,0 +1,201 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// Secondary (per-target) index of user-defined custom metadata, maintained for the buckets
+// that have feat.IndexCustomMD enabled:
+// - updated upon PUT (including rebalance, copy, and promote), DELETE, and set-custom;
+// - stored in the target's kvdb as two sets of keys:
+//   "o/<bucket>/<object>"              => indexed custom metadata (to update and remove)
+//   "i/<bucket>/<key>=<value>/<object>" => "" (to search)
+// Search results are validated against the actual (current) objects' metadata,
+// with stale entries (e.g., objects rebalanced away) removed on the fly.
+
+const mdidxCollection = "mdindex"
+
+const (
+	mdidxObjs = "o"
+	mdidxKVs  = "i"
+)
+
+type mdIndex struct {
+	db kvdb.Driver
+	mu sync.Mutex
+}
+
+// escape key and value (in particular, '/', '=', and kvdb wildcards)
+func mdesc(s string) string { return strings.ReplaceAll(url.QueryEscape(s), "*", "%2A") }
+
+func mdObjKey(bck *meta.Bck, objName string) string {
+	return mdidxObjs + "/" + bck.Cname("") + "/" + objName
+}
+
+func mdKVPrefix(bck *meta.Bck, key string) string {
+	return mdidxKVs + "/" + bck.Cname("") + "/" + mdesc(key) + "="
+}
+
+func mdKVKey(bck *meta.Bck, key, value, objName string) string {
+	return mdKVPrefix(bck, key) + mdesc(value) + "/" + objName
+}
+
+func (idx *mdIndex) init(db kvdb.Driver) { idx.db = db }
+
+// PUT, set-custom
+func (idx *mdIndex) update(lom *core.LOM) {
+	if !lom.IsFeatureSet(feat.IndexCustomMD) {
+		return
+	}
+	md := make(cos.StrKVs, 4)
+	for k, v := range lom.GetCustomMD() {
+		if !cmn.IsStdCustom(k) {
+			md[k] = v
+		}
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx._remove(lom.Bck(), lom.ObjName)
+	if len(md) == 0 {
+		return
+	}
+	bck := lom.Bck()
+	for k, v := range md {
+		if err := idx.db.SetString(mdidxCollection, mdKVKey(bck, k, v, lom.ObjName), ""); err != nil {
+			nlog.Errorln("failed to index", lom.Cname(), "custom metadata:", err)
+			return
+		}
+	}
+	if err := idx.db.Set(mdidxCollection, mdObjKey(bck, lom.ObjName), md); err != nil {
+		nlog.Errorln("failed to index", lom.Cname(), "custom metadata:", err)
+	}
+}
+
+// DELETE
+func (idx *mdIndex) remove(lom *core.LOM) {
+	if !lom.IsFeatureSet(feat.IndexCustomMD) {
+		return
+	}
+	idx.mu.Lock()
+	idx._remove(lom.Bck(), lom.ObjName)
+	idx.mu.Unlock()
+}
+
+func (idx *mdIndex) _remove(bck *meta.Bck, objName string) {
+	var (
+		md  cos.StrKVs
+		key = mdObjKey(bck, objName)
+	)
+	if err := idx.db.Get(mdidxCollection, key, &md); err != nil {
+		return // not indexed
+	}
+	for k, v := range md {
+		_ = idx.db.Delete(mdidxCollection, mdKVKey(bck, k, v, objName))
+	}
+	_ = idx.db.Delete(mdidxCollection, key)
+}
+
+// destroyed (or evicted) buckets
+func (idx *mdIndex) rmbcks(bcks ...*meta.Bck) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, bck := range bcks {
+		for _, prefix := range []string{mdidxObjs, mdidxKVs} {
+			keys, err := idx.db.List(mdidxCollection, prefix+"/"+bck.Cname("")+"/")
+			if err != nil {
+				continue
+			}
+			for _, key := range keys {
+				_ = idx.db.Delete(mdidxCollection, key)
+			}
+		}
+	}
+}
+
+func (idx *mdIndex) search(bck *meta.Bck, msg *apc.MDSearchMsg) ([]string, error) {
+	prefix := mdKVPrefix(bck, msg.Key)
+	if msg.Value != "" {
+		prefix += mdesc(msg.Value) + "/"
+	}
+	keys, err := idx.db.List(mdidxCollection, prefix)
+	if err != nil {
+		if cos.IsErrNotFound(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		// value (escaped) followed by object name
+		rest := strings.TrimPrefix(key, prefix)
+		if msg.Value == "" {
+			i := strings.IndexByte(rest, '/')
+			if i < 0 {
+				continue
+			}
+			rest = rest[i+1:]
+		}
+		objName := rest
+		if msg.Prefix != "" && !cmn.ObjHasPrefix(objName, msg.Prefix) {
+			continue
+		}
+		if idx.validate(bck, objName, msg) {
+			names = append(names, objName)
+		}
+	}
+	return names, nil
+}
+
+// validate against the object's current metadata; remove stale entries
+func (idx *mdIndex) validate(bck *meta.Bck, objName string, msg *apc.MDSearchMsg) bool {
+	lom := core.AllocLOM(objName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		return false
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		if cos.IsNotExist(err, 0) {
+			idx.mu.Lock()
+			idx._remove(bck, objName)
+			idx.mu.Unlock()
+		}
+		return false
+	}
+	v, ok := lom.GetCustomKey(msg.Key)
+	if ok && (msg.Value == "" || v == msg.Value) {
+		return true
+	}
+	idx.update(lom) // re-index
+	return false
+}
+
+// GET /v1/buckets/<bucket-name> (apc.ActSearchMD)
+func (t *target) searchMD(w http.ResponseWriter, r *http.Request, bck *meta.Bck, msg *apc.ActMsg) {
+	var smsg apc.MDSearchMsg
+	if err := cos.MorphMarshal(msg.Value, &smsg); err != nil {
+		t.writeErrf(w, r, cmn.FmtErrMorphUnmarshal, t.si, msg.Action, msg.Value, err)
+		return
+	}
+	names, err := t.mdidx.search(bck, &smsg)
+	if err != nil {
+		t.writeErr(w, r, err)
+		return
+	}
+	t.writeJSON(w, r, names, msg.Action)
+}
@@ -55,6 +55,7 @@ type (
 		t          *target       // this
 		lom        *core.LOM     // obj
 		cksumToUse *cos.Cksum    // if available (not `none`), can be validated and will be stored
+		precond    *cmn.Precond  // conditional PUT (If-Match, If-None-Match, If-Unmodified-Since)
 		config     *cmn.Config   // (during this request)
 		resphdr    http.Header   // as implied
 		workFQN    string        // temp fqn to be renamed
@@ -63,6 +64,7 @@ type (
 		rltime     int64         // mono.NanoTime, to measure remote bucket latency
 		size       int64         // aka Content-Length
 		owt        cmn.OWT       // object write transaction enum { OwtPut, ..., OwtGet* }
+		user       string        // AuthN user ID (usage accounting)
 		restful    bool          // being invoked via RESTful API
 		t2t        bool          // by another target
 		skipEC     bool          // do not erasure-encode when finalizing
@@ -78,18 +80,19 @@ type (
 		t          *target         // this
 		lom        *core.LOM       // obj
 		dpq        *dpq
-		ranges     byteRanges // range read (see https://www.rfc-editor.org/rfc/rfc7233#section-2.1)
-		atime      int64      // access time.Now()
-		ltime      int64      // mono.NanoTime, to measure latency
-		rstarttime int64      // mono.NanoTime, mark start of remote GET to measure latency
-		rltime     int64      // mono.NanoTime, to measure remote bucket latency
-		chunked    bool       // chunked transfer (en)coding: https://tools.ietf.org/html/rfc7230#page-36
-		unlocked   bool       // internal
-		verchanged bool       // version changed
-		retry      bool       // once
-		cold       bool       // true if executed backend.Get
-		latestVer  bool       // QparamLatestVer || 'versioning.*_warm_get'
-		isIOErr    bool       // to count GET error as a "IO error"; see `Trunner._softErrs()`
+		precond    *cmn.Precond // conditional GET (If-Match, If-None-Match, If-Modified-Since, ...)
+		ranges     byteRanges   // range read (see https://www.rfc-editor.org/rfc/rfc7233#section-2.1)
+		atime      int64        // access time.Now()
+		ltime      int64        // mono.NanoTime, to measure latency
+		rstarttime int64        // mono.NanoTime, mark start of remote GET to measure latency
+		rltime     int64        // mono.NanoTime, to measure remote bucket latency
+		chunked    bool         // chunked transfer (en)coding: https://tools.ietf.org/html/rfc7230#page-36
+		unlocked   bool         // internal
+		verchanged bool         // version changed
+		retry      bool         // once
+		cold       bool         // true if executed backend.Get
+		latestVer  bool         // QparamLatestVer || 'versioning.*_warm_get'
+		isIOErr    bool         // to count GET error as a "IO error"; see `Trunner._softErrs()`
 	}
 	_uplock struct {
 		config  *cmn.Config
@@ -147,6 +150,20 @@ type (
 
 // poi.restful entry point
 func (poi *putOI) do(resphdr http.Header, r *http.Request, dpq *dpq) (int, error) {
+	if !poi.t2t {
+		precond, err := cmn.ParsePrecond(r.Header)
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+		if precond != nil {
+			// fail early (optimistically, without locking)
+			poi.precond = precond
+			if ecode, err := poi.precondition(false /*locked*/); err != nil {
+				cos.DrainReader(r.Body)
+				return ecode, err
+			}
+		}
+	}
 	{
 		poi.oreq = r
 		poi.r = r.Body
@@ -154,6 +171,7 @@ func (poi *putOI) do(resphdr http.Header, r *http.Request, dpq *dpq) (int, error
 		poi.workFQN = fs.CSM.Gen(poi.lom, fs.WorkfileType, fs.WorkfilePut)
 		poi.cksumToUse = poi.lom.ObjAttrs().FromHeader(r.Header)
 		poi.owt = cmn.OwtPut // default
+		poi.user = dpq.user
 	}
 	if dpq.owt != "" {
 		poi.owt.FromS(dpq.owt)
@@ -207,10 +225,10 @@ func (poi *putOI) putObject() (ecode int, err error) {
 		if poi.owt == cmn.OwtPromote {
 			poi.xctn.InObjsAdd(1, poi.lom.Lsize())
 		}
-	} else if !poi.t2t && poi.owt == cmn.OwtPut && poi.restful {
-		// user PUT
+	} else if poi.isUserPut() {
 		debug.Assert(cos.IsValidAtime(poi.atime), poi.atime)
 		poi.stats()
+		poi.t.usage.add(poi.user, poi.lom.Bck(), usagePut, 1, poi.lom.Lsize())
 		// response header
 		if poi.resphdr != nil {
 			cmn.ToHeader(poi.lom.ObjAttrs(), poi.resphdr, 0 /*skip setting content-length*/)
@@ -225,7 +243,7 @@ rerr:
 	if poi.owt == cmn.OwtPut && poi.restful && !poi.t2t {
 		vlabs := poi._vlabs()
 		if err != cmn.ErrSkip && !poi.remoteErr && err != io.ErrUnexpectedEOF &&
-			!cos.IsRetriableConnErr(err) && !cos.IsErrMvToVirtDir(err) {
+			!cos.IsRetriableConnErr(err) && !cos.IsErrMvToVirtDir(err) && !cmn.IsErrPrecondFailed(err) {
 			poi.t.statsT.AddWith(
 				cos.NamedVal64{Name: stats.ErrPutCount, Value: 1, VarLabs: vlabs},
 				cos.NamedVal64{Name: stats.IOErrPutCount, Value: 1, VarLabs: vlabs},
@@ -264,6 +282,7 @@ func (poi *putOI) stats() {
 		cos.NamedVal64{Name: stats.PutThroughput, Value: size, VarLabs: vlabs},
 		cos.NamedVal64{Name: stats.PutLatency, Value: delta, VarLabs: vlabs},
 		cos.NamedVal64{Name: stats.PutLatencyTotal, Value: delta, VarLabs: vlabs},
+		cos.NamedVal64{Name: stats.PutLatencyHist, Value: delta, VarLabs: vlabs},
 	)
 	if poi.rltime > 0 {
 		debug.Assert(bck.IsRemote())
@@ -330,6 +349,12 @@ func (poi *putOI) finalize() (ecode int, err error) {
 		}
 	}
 	poi.t.putMirror(poi.lom)
+	switch {
+	case poi.owt < cmn.OwtRebalance:
+		poi.t.events.emit(poi.lom, cmn.EventObjCreated)
+	case poi.owt > cmn.OwtRebalance && poi.owt <= cmn.OwtGetPrefetchLock:
+		poi.t.events.emit(poi.lom, cmn.EventColdGet)
+	}
 	return 0, nil
 }
 
@@ -339,6 +364,16 @@ func (poi *putOI) fini() (ecode int, err error) {
 		lom = poi.lom
 		bck = lom.Bck()
 	)
+	// conditional PUT: lock prior to writing remote and evaluate (again) under lock
+	if poi.precond != nil {
+		debug.Assert(poi.owt == cmn.OwtPut, poi.owt.String())
+		lom.Lock(true)
+		defer lom.Unlock(true)
+		if ecode, err = poi.precondition(true /*locked*/); err != nil {
+			return ecode, err
+		}
+	}
+
 	// put remote
 	if bck.IsRemote() && poi.owt < cmn.OwtRebalance {
 		ecode, err = poi.putRemote()
@@ -372,8 +407,10 @@ func (poi *putOI) fini() (ecode int, err error) {
 		defer lom.Unlock(true)
 	default:
 		debug.Assert(cos.IsValidAtime(poi.atime), poi.atime) // expecting valid atime
-		lom.Lock(true)
-		defer lom.Unlock(true)
+		if poi.precond == nil {
+			lom.Lock(true)
+			defer lom.Unlock(true)
+		}
 		lom.SetAtimeUnix(poi.atime)
 	}
 
@@ -391,6 +428,24 @@ func (poi *putOI) fini() (ecode int, err error) {
 		}
 	}
 
+	// usage accounting: overwriting uncharges the previous owner (rebalance: same object, different target);
+	// user PUT charges the new one (see poi.putObject)
+	var (
+		prevOwner string
+		prevSize  int64
+		prevOK    bool
+	)
+	if usageEnabled() {
+		if poi.owt != cmn.OwtRebalance {
+			prevOwner, prevSize, prevOK = usageCharged(lom)
+		}
+		if poi.isUserPut() {
+			lom.SetCustomKey(cmn.OwnerObjMD, usageOwnerMD(bck, poi.user))
+		} else if poi.owt != cmn.OwtRebalance {
+			delete(lom.GetCustomMD(), cmn.OwnerObjMD)
+		}
+	}
+
 	// done
 	if err = lom.RenameFinalize(poi.workFQN); err != nil {
 		return 0, err
@@ -403,7 +458,52 @@ func (poi *putOI) fini() (ecode int, err error) {
 	if lom.AtimeUnix() == 0 { // (is set when migrating within cluster; prefetch special case)
 		lom.SetAtimeUnix(poi.atime)
 	}
-	return 0, lom.PersistMain()
+	if err = lom.PersistMain(); err != nil {
+		return 0, err
+	}
+	if prevOK {
+		poi.t.usage.sub(prevOwner, bck, prevSize)
+	}
+	poi.t.mdidx.update(lom)
+	return 0, nil
+}
+
+func (poi *putOI) isUserPut() bool {
+	return poi.xctn == nil && !poi.t2t && poi.owt == cmn.OwtPut && poi.restful
+}
+
+// evaluate the current (existing) object against PUT preconditions;
+// when not present in-cluster, check remote backend (under lock only)
+func (poi *putOI) precondition(locked bool) (int, error) {
+	var (
+		oah cos.OAH
+		t   = poi.t
+		lom = core.AllocLOM(poi.lom.ObjName)
+	)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(poi.lom.Bucket()); err != nil {
+		return 0, err
+	}
+	mtime := time.Time{}
+	err := lom.Load(false /*cache it*/, locked)
+	switch {
+	case err == nil:
+		oah, mtime = lom, lomMtime(lom)
+	case !cos.IsNotExist(err, 0):
+		return 0, err
+	case locked && lom.Bck().IsRemote():
+		oa, ecode, err := t.HeadCold(lom, poi.oreq)
+		if err == nil {
+			oah, mtime = oa, cmn.ObjLastModified(oa)
+		} else if ecode != http.StatusNotFound {
+			return ecode, err
+		}
+	}
+	ecode, hdr := poi.precond.Eval(http.MethodPut, oah, mtime)
+	if ecode == 0 {
+		return 0, nil
+	}
+	return ecode, cmn.NewErrPrecondFailed(lom.Cname(), hdr)
 }
 
 // via backend.PutObj()
@@ -626,6 +726,11 @@ do:
 			goi.isIOErr = true
 			return 0, err
 		}
+		if goi.precond != nil {
+			if ecode, err = goi.precondition(); ecode != 0 {
+				return ecode, err
+			}
+		}
 		goto fin // ok, done
 	case cold:
 		// have remote backend - use it
@@ -653,6 +758,13 @@ do:
 		}
 	}
 
+	// conditional GET
+	if !cold && goi.precond != nil {
+		if ecode, err = goi.precondition(); ecode != 0 {
+			return ecode, err
+		}
+	}
+
 	// cold-GET: upgrade rlock => wlock and call t.Backend.GetObjReader
 	if cold {
 		var (
@@ -699,6 +811,16 @@ do:
 		}
 		goi.cold = true
 
+		// conditional (cold) GET - given remote metadata
+		if goi.precond != nil {
+			if ecode, err = goi.precondition(); ecode != 0 {
+				cos.Close(res.R)
+				goi.lom.Unlock(true)
+				goi.unlocked = true
+				return ecode, err
+			}
+		}
+
 		// 3 alternative ways to perform cold GET
 		if goi.dpq.arch.path == "" && goi.dpq.arch.regx == "" &&
 			(ckconf.Type == cos.ChecksumNone || (!ckconf.ValidateColdGet && !ckconf.EnableReadRange)) {
@@ -737,6 +859,49 @@ fin:
 	return ecode, err
 }
 
+// returns:
+// - (0, nil) to proceed
+// - (http.StatusNotModified, nil) having written the response header
+// - (http.StatusPreconditionFailed, err)
+func (goi *getOI) precondition() (int, error) {
+	var (
+		lom   = goi.lom
+		mtime time.Time
+	)
+	if goi.cold {
+		mtime = cmn.ObjLastModified(lom) // (not yet stored)
+	} else {
+		mtime = lomMtime(lom)
+	}
+	ecode, hdr := goi.precond.Eval(http.MethodGet, lom, mtime)
+	switch ecode {
+	case 0:
+		return 0, nil
+	case http.StatusNotModified:
+		whdr := goi.w.Header()
+		cmn.ToHeader(lom.ObjAttrs(), whdr, 0 /*skip setting content-length*/)
+		if goi.dpq.isS3 {
+			s3.SetEtag(whdr, lom)
+		}
+		if !mtime.IsZero() {
+			whdr.Set(cos.HdrLastModified, mtime.UTC().Format(http.TimeFormat))
+		}
+		goi.w.WriteHeader(http.StatusNotModified)
+		return ecode, nil
+	default:
+		return ecode, cmn.NewErrPrecondFailed(lom.Cname(), hdr)
+	}
+}
+
+// last-modified: remote (custom metadata), if available, or else the time the object was written in-cluster
+func lomMtime(lom *core.LOM) time.Time {
+	if mtime := cmn.ObjLastModified(lom); !mtime.IsZero() {
+		return mtime
+	}
+	_, _, mtime, _ := lom.Fstat(false /*get atime*/)
+	return mtime
+}
+
 func (goi *getOI) _coldPut(res *core.GetReaderResult) (int, error) {
 	var (
 		t, lom = goi.t, goi.lom
@@ -1208,7 +1373,14 @@ func (goi *getOI) stats(written int64) {
 		cos.NamedVal64{Name: stats.GetThroughput, Value: written, VarLabs: vlabs}, // vis-à-vis user (as written m.b. range)
 		cos.NamedVal64{Name: stats.GetLatency, Value: delta, VarLabs: vlabs},      // see also: per-backend *LatencyTotal below
 		cos.NamedVal64{Name: stats.GetLatencyTotal, Value: delta, VarLabs: vlabs}, // ditto
+		cos.NamedVal64{Name: stats.GetLatencyHist, Value: delta, VarLabs: vlabs},  // distribution (buckets), to compute percentiles
 	)
+	if !goi.dpq.isGFN {
+		goi.t.usage.add(goi.dpq.user, goi.lom.Bck(), usageGet, 1, written)
+		if goi.dpq.ptime != "" { // redirected by gateway (and rate-limited there)
+			goi.t.ratelim.get(goi.lom.Bck(), goi.dpq.user, written)
+		}
+	}
 	if goi.verchanged {
 		goi.t.statsT.AddWith(
 			cos.NamedVal64{Name: stats.VerChangeCount, Value: 1, VarLabs: vlabs},
@@ -1224,6 +1396,7 @@ func (goi *getOI) stats(written int64) {
 			cos.NamedVal64{Name: backend.MetricName(stats.GetE2ELatencyTotal), Value: delta, VarLabs: vlabs},
 			cos.NamedVal64{Name: backend.MetricName(stats.GetLatencyTotal), Value: goi.rltime, VarLabs: vlabs},
 			cos.NamedVal64{Name: backend.MetricName(stats.GetSize), Value: written, VarLabs: vlabs},
+			cos.NamedVal64{Name: stats.GetColdLatencyHist, Value: delta, VarLabs: vlabs},
 		)
 		if goi.verchanged {
 			goi.t.statsT.AddWith(
@@ -1598,6 +1771,9 @@ func (coi *coi) _regular(t *target, lom, dst *core.LOM) (size int64, _ error) {
 		if coi.Finalize {
 			t.putMirror(dst2)
 		}
+		if !lcopy && coi.OWT < cmn.OwtRebalance {
+			t.events.emit(dst2, cmn.EventObjCreated)
+		}
 	}
 	if dst2 != nil {
 		core.FreeLOM(dst2)
@@ -1739,6 +1915,14 @@ func (coi *coi) put(t *target, sargs *sendArgs) error {
 	if err != nil {
 		return cmn.NewErrFailedTo(t, "coi.put "+sargs.bckTo.Name+"/"+sargs.objNameTo, sargs.tsi, err)
 	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		err = fmt.Errorf("%s: %s", sargs.tsi, resp.Status)
+		if b, e := io.ReadAll(io.LimitReader(resp.Body, 1024)); e == nil && len(b) > 0 {
+			err = fmt.Errorf("%s: %s: %s", sargs.tsi, resp.Status, strings.TrimSpace(string(b)))
+		}
+		resp.Body.Close()
+		return cmn.NewErrFailedTo(t, "coi.put "+sargs.bckTo.Name+"/"+sargs.objNameTo, sargs.tsi, err)
+	}
 	cos.DrainReader(resp.Body)
 	resp.Body.Close()
 	return nil
@@ -1901,6 +2085,7 @@ func (a *putA2I) finalize(size int64, cksum *cos.Cksum, fqn string) error {
 		}
 	}
 	a.t.putMirror(a.lom)
+	a.t.events.emit(a.lom, cmn.EventObjCreated)
 	return nil
 }
 
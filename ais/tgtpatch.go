@@ -0,0 +1,198 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// In-place partial overwrite of an existing ais:// object (feat.AllowObjectPatch):
+// - PATCH /v1/objects/<bucket-name>/<object-name>?patch_offset=N - writes the request body;
+// - POST with apc.ActCopyRange - writes (a range of) another object or archived file,
+//   possibly owned by another target (see cmn.CopyRangeMsg and tgtcompose.go);
+// the offset must not exceed the current size (no holes) - writing past the end extends the object.
+// The patch is first received into a work file (and its size validated against Content-Length);
+// only then is the object updated under its write lock, and before unlocking:
+// - checksum is recomputed (by reading the entire object) and version incremented (if enabled);
+// - mirror copies are deleted and then re-created (as per bucket's mirroring config);
+// - erasure-coded object gets re-encoded (overwriting its slices and replicas) or, if the
+//   latter fails, its stale slices get cleaned up.
+// Applying the (received) patch is not atomic: a local I/O error midway leaves the object
+// partially updated (with its size and checksum reflecting the actual content).
+
+func checkPatch(bck *meta.Bck) error {
+	if bck.IsRemote() {
+		err := fmt.Errorf("in-place object patch is not supported for remote buckets (%s)", bck)
+		return cmn.NewErrUnsuppErr(err)
+	}
+	if !bck.Props.Features.IsSet(feat.AllowObjectPatch) {
+		return fmt.Errorf("bucket %s does not have %q feature enabled", bck.Cname(""),
+			feat.Flags(feat.AllowObjectPatch).Names()[0])
+	}
+	return nil
+}
+
+// size: the expected number of bytes (Content-Length), or -1 when unknown
+func (t *target) patchObj(lom *core.LOM, off int64, r io.Reader, size int64) error {
+	if err := checkPatch(lom.Bck()); err != nil {
+		return err
+	}
+	wfqn, n, err := t.patchRecv(lom, r, size)
+	if err != nil || n == 0 {
+		return err
+	}
+	return t.patchApply(lom, off, wfqn, n)
+}
+
+// receive the patch into a work file on the object's mountpath; the object itself remains intact
+// if the sender fails (or disconnects) midway; an empty patch is a no-op
+func (t *target) patchRecv(lom *core.LOM, r io.Reader, size int64) (wfqn string, n int64, err error) {
+	wfqn = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfilePatch)
+	wfh, err := lom.CreateWork(wfqn)
+	if err != nil {
+		return "", 0, err
+	}
+	buf, slab := t.gmm.AllocSize(memsys.DefaultBufSize)
+	n, err = cos.CopyBuffer(wfh, r, buf)
+	slab.Free(buf)
+	if errc := wfh.Close(); errc != nil && err == nil {
+		err = errc
+	}
+	if err == nil && size >= 0 && n != size {
+		err = fmt.Errorf("%s: patch size mismatch: received %d bytes, expected %d", lom.Cname(), n, size)
+	}
+	if err != nil || n == 0 {
+		if errr := cos.RemoveFile(wfqn); errr != nil && !os.IsNotExist(errr) {
+			nlog.Errorf(fmtNested, t, err, "remove", wfqn, errr)
+		}
+		return "", 0, err
+	}
+	return wfqn, n, nil
+}
+
+// write the received patch under wlock; re-encode and mirror prior to unlocking
+// so that neither races with a concurrent write
+func (t *target) patchApply(lom *core.LOM, off int64, wfqn string, size int64) error {
+	defer func() {
+		if errr := cos.RemoveFile(wfqn); errr != nil && !os.IsNotExist(errr) {
+			nlog.Errorln("patch", lom.Cname(), "failed to remove", wfqn, "[", errr, "]")
+		}
+	}()
+	lom.Lock(true)
+	n, err := t._patch(lom, off, wfqn, size)
+	if n == 0 {
+		lom.Unlock(true)
+		return err
+	}
+	if ecErr := ec.ECM.EncodeObject(lom, nil); ecErr != nil && ecErr != ec.ErrorECDisabled {
+		// stale slices (and replicas) must not outlive the patched object
+		ec.ECM.CleanupObject(lom)
+		if err == nil {
+			err = ecErr
+		}
+	}
+	t.putMirror(lom)
+	lom.Unlock(true)
+
+	t.events.emit(lom, cmn.EventObjCreated)
+	return err
+}
+
+// under wlock; returns the number of bytes written
+func (t *target) _patch(lom *core.LOM, off int64, wfqn string, size int64) (int64, error) {
+	if err := lom.Load(true /*cache it*/, true /*locked*/); err != nil {
+		return 0, err
+	}
+	osize := lom.Lsize()
+	if off > osize {
+		err := fmt.Errorf("%s: patch offset %d is out of bounds (size %d)", lom.Cname(), off, osize)
+		return 0, cmn.NewErrRangeNotSatisfiable(err, nil, osize)
+	}
+	wfh, err := os.Open(wfqn)
+	if err != nil {
+		return 0, err
+	}
+	fh, err := os.OpenFile(lom.FQN, os.O_WRONLY, cos.PermRWR)
+	if err != nil {
+		wfh.Close()
+		return 0, err
+	}
+	buf, slab := t.gmm.AllocSize(memsys.DefaultBufSize)
+	n, err := cos.CopyBuffer(io.NewOffsetWriter(fh, off), io.LimitReader(wfh, size), buf)
+	slab.Free(buf)
+	wfh.Close()
+	if err == nil && lom.IsFeatureSet(feat.FsyncPUT) {
+		err = fh.Sync()
+	}
+	if errc := fh.Close(); errc != nil && err == nil {
+		err = errc
+	}
+	if n == 0 {
+		return 0, err
+	}
+
+	// update metadata
+	lom.SetSize(max(osize, off+n))
+	if lom.HasCopies() {
+		if errdc := lom.DelAllCopies(); errdc != nil {
+			nlog.Errorln("patch", lom.Cname(), "failed to delete old copies [", errdc, "], proceeding anyway...")
+		}
+	}
+	if _, errc := lom.ComputeSetCksum(); errc != nil && err == nil {
+		err = errc
+	}
+	if lom.VersionConf().Enabled {
+		if errv := lom.IncVersion(); errv != nil {
+			nlog.Errorln(errv) // (unlikely)
+		}
+	}
+	lom.SetAtimeUnix(time.Now().UnixNano())
+	if errp := lom.PersistMain(); errp != nil && err == nil {
+		err = errp
+	}
+	t.mdidx.update(lom)
+	return n, err
+}
+
+// POST with apc.ActCopyRange
+func (t *target) copyRange(lom *core.LOM, msg *cmn.CopyRangeMsg) error {
+	if err := checkPatch(lom.Bck()); err != nil {
+		return err
+	}
+	var (
+		bm      = &t.batch
+		sources = []cmn.GetBatchEntry{msg.Source}
+	)
+	wi, err := bm.start(sources)
+	if err != nil {
+		return err
+	}
+	defer bm.fini(wi)
+	if e := &sources[0]; cos.UnsafeS(e.Bck.MakeUname(e.ObjName)) == lom.Uname() {
+		return fmt.Errorf("copy-range: %s is both source and destination", lom.Cname())
+	}
+
+	// receive the source and release it (and its read lock) prior to locking the destination
+	cr := &composeReader{bm: bm, wi: wi, sources: sources, timeout: cmn.GCO.Get().Timeout.SendFile.D()}
+	wfqn, n, err := t.patchRecv(lom, cr, -1)
+	cr.Close()
+	if err != nil || n == 0 {
+		return err
+	}
+	return t.patchApply(lom, msg.Offset, wfqn, n)
+}
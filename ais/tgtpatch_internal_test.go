@@ -0,0 +1,177 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Patch", func() {
+	const content = "0123456789"
+	var (
+		patchBck = cmn.Bck{Name: "patch-bck", Provider: apc.AIS, Ns: cmn.NsGlobal}
+		objName  = "patched"
+	)
+	patch := func(off int64, data string) error {
+		lom := core.AllocLOM(objName)
+		defer core.FreeLOM(lom)
+		Expect(lom.InitBck(&patchBck)).NotTo(HaveOccurred())
+		return t.patchObj(lom, off, bytes.NewReader([]byte(data)), int64(len(data)))
+	}
+	load := func() (cksum *cos.Cksum, version string) {
+		lom := core.AllocLOM(objName)
+		defer core.FreeLOM(lom)
+		Expect(lom.InitBck(&patchBck)).NotTo(HaveOccurred())
+		Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+		return lom.Checksum().Clone(), lom.Version()
+	}
+	expectContent := func(expected string) {
+		b, err := testGetObj(&patchBck, objName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(expected))
+	}
+
+	BeforeEach(func() {
+		bck := meta.CloneBck(&patchBck)
+		bmd := t.owner.bmd.get().clone()
+		if bmd.add(bck, &cmn.Bprops{
+			Cksum:      cmn.CksumConf{Type: cos.ChecksumXXHash},
+			Versioning: cmn.VersionConf{Enabled: true},
+			Features:   feat.AllowObjectPatch,
+		}) {
+			t.owner.bmd.putPersist(bmd, nil)
+			fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+		}
+		testPutObj(&patchBck, objName, []byte(content))
+	})
+
+	DescribeTable("should overwrite in place",
+		func(off int64, data, expected string) {
+			Expect(patch(off, data)).NotTo(HaveOccurred())
+			expectContent(expected)
+		},
+		Entry("at offset zero", int64(0), "ab", "ab23456789"),
+		Entry("in the middle", int64(4), "abc", "0123abc789"),
+		Entry("at the end (append)", int64(len(content)), "abc", content+"abc"),
+		Entry("extending the object", int64(8), "abcd", "01234567abcd"),
+	)
+
+	It("should reject offset beyond the current size", func() {
+		err := patch(int64(len(content))+1, "abc")
+		Expect(err).To(HaveOccurred())
+		Expect(cmn.IsErrRangeNotSatisfiable(err)).To(BeTrue())
+		expectContent(content)
+	})
+
+	It("should leave the object intact when the body is short", func() {
+		cksum, version := load()
+		lom := core.AllocLOM(objName)
+		defer core.FreeLOM(lom)
+		Expect(lom.InitBck(&patchBck)).NotTo(HaveOccurred())
+		Expect(t.patchObj(lom, 2, bytes.NewReader([]byte("xyz")), 8 /*Content-Length*/)).To(HaveOccurred())
+
+		expectContent(content)
+		ncksum, nversion := load()
+		Expect(ncksum.Equal(cksum)).To(BeTrue())
+		Expect(nversion).To(Equal(version))
+	})
+
+	It("should recompute checksum and increment version", func() {
+		cksum, version := load()
+		Expect(patch(2, "xyz")).NotTo(HaveOccurred())
+
+		ncksum, nversion := load()
+		expected, err := cos.ChecksumBytes([]byte("01xyz56789"), cos.ChecksumXXHash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ncksum.Equal(expected)).To(BeTrue())
+		Expect(ncksum.Equal(cksum)).To(BeFalse())
+		Expect(nversion).NotTo(Equal(version))
+		Expect(nversion).NotTo(BeEmpty())
+	})
+
+	It("should reject remote buckets and buckets without the feature", func() {
+		remote := meta.NewBck("patch-aws", apc.AWS, cmn.NsGlobal)
+		err := checkPatch(remote)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(BeAssignableToTypeOf(&cmn.ErrUnsupp{}))
+
+		backend := meta.NewBck("patch-backend", apc.AIS, cmn.NsGlobal, &cmn.Bprops{
+			BackendBck: cmn.Bck{Name: "patch-aws", Provider: apc.AWS},
+			Features:   feat.AllowObjectPatch,
+		})
+		err = checkPatch(backend)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(BeAssignableToTypeOf(&cmn.ErrUnsupp{}))
+
+		// feature not enabled
+		lom := core.AllocLOM("obj")
+		defer core.FreeLOM(lom)
+		Expect(lom.InitBck(&testBck)).NotTo(HaveOccurred())
+		Expect(t.patchObj(lom, 0, bytes.NewReader([]byte("abc")), 3)).To(HaveOccurred())
+	})
+
+	Describe("copy-range", func() {
+		var (
+			local, remote []string
+			cleanup       func()
+		)
+		copyRange := func(msg *cmn.CopyRangeMsg) error {
+			Expect(msg.Init(&testBck)).NotTo(HaveOccurred())
+			lom := core.AllocLOM(objName)
+			defer core.FreeLOM(lom)
+			Expect(lom.InitBck(&patchBck)).NotTo(HaveOccurred())
+			return t.copyRange(lom, msg)
+		}
+
+		BeforeEach(func() {
+			config := cmn.GCO.BeginUpdate()
+			prev := config.Timeout.SendFile
+			config.Timeout.SendFile = cos.Duration(10 * time.Second)
+			cmn.GCO.CommitUpdate(config)
+			DeferCleanup(func() {
+				config := cmn.GCO.BeginUpdate()
+				config.Timeout.SendFile = prev
+				cmn.GCO.CommitUpdate(config)
+			})
+
+			// (copy-range uses the target's batch manager)
+			bm := &t.batch
+			bm.t = t
+			local, remote, cleanup = batchLoopbackSetup(bm, "copy-range-src-", 1)
+			testPutObj(&testBck, local[0], []byte("LOCAL"))
+			testPutObj(&testBck, remote[0], []byte("REMOTE"))
+		})
+		AfterEach(func() {
+			cleanup()
+			t.batch.streams.req, t.batch.streams.resp = nil, nil
+		})
+
+		It("should copy from a local source", func() {
+			msg := &cmn.CopyRangeMsg{Source: cmn.GetBatchEntry{ObjName: local[0]}, Offset: 3}
+			Expect(copyRange(msg)).NotTo(HaveOccurred())
+			expectContent("012LOCAL89")
+		})
+
+		It("should copy a range of a remote source", func() {
+			msg := &cmn.CopyRangeMsg{
+				Source: cmn.GetBatchEntry{ObjName: remote[0], Start: 1, Length: 4},
+				Offset: int64(len(content)),
+			}
+			Expect(copyRange(msg)).NotTo(HaveOccurred())
+			expectContent(content + "EMOT")
+		})
+	})
+})
@@ -0,0 +1,76 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+)
+
+// GET bandwidth is charged post-hoc: targets accumulate the bytes sent in response
+// to (gateway-redirected) GET requests and periodically report them to all gateways
+// that, in turn, debit their per-bucket and per-user token buckets (see prxratelim.go)
+
+type ratelimDebits struct {
+	t  *target
+	m  map[string]*ratelimUsage // key (as in rateLimiters) => bytes sent
+	mu sync.Mutex
+}
+
+func (rd *ratelimDebits) init(t *target) {
+	rd.t = t
+	rd.m = make(map[string]*ratelimUsage, 4)
+	hk.Reg("rate-limit-debits"+hk.NameSuffix, rd.sync, ratelimSyncIval)
+}
+
+// (GET) user: AuthN user ID added by the redirecting gateway, if any
+func (rd *ratelimDebits) get(bck *meta.Bck, user string, size int64) {
+	if size <= 0 {
+		return
+	}
+	if conf := &bck.Props.RateLimit; conf.IsSet() && conf.MaxBps > 0 {
+		rd.add(ratelimKeyBck(bck), int64(conf.MaxBps), size)
+	}
+	if user != "" && cmn.Rom.AuthEnabled() {
+		if config := cmn.GCO.Get(); ratelimUserBps(config) {
+			rd.add(ratelimKeyUser(user), int64(config.RateLimit.User.MaxBps), size)
+		}
+	}
+}
+
+func (rd *ratelimDebits) add(key string, bps, size int64) {
+	rd.mu.Lock()
+	u, ok := rd.m[key]
+	if !ok {
+		u = &ratelimUsage{Key: key}
+		rd.m[key] = u
+	}
+	u.Bytes += float64(size)
+	u.Bps = float64(bps)
+	rd.mu.Unlock()
+}
+
+func (rd *ratelimDebits) sync(int64) time.Duration {
+	rd.mu.Lock()
+	if len(rd.m) == 0 {
+		rd.mu.Unlock()
+		return ratelimSyncIval
+	}
+	usages := make([]ratelimUsage, 0, len(rd.m))
+	for _, u := range rd.m {
+		usages = append(usages, *u)
+	}
+	clear(rd.m)
+	rd.mu.Unlock()
+
+	if rd.t.ClusterStarted() {
+		rd.t.bcastRatelim(rd.t.owner.smap.get(), usages)
+	}
+	return ratelimSyncIval
+}
@@ -0,0 +1,286 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/xact/xs"
+)
+
+// Bucket-to-bucket continuous replication (see cmn/repl.go):
+// - source writes and deletions are journaled (see evMgr.emit) in a per-bucket replication journal
+//   (same format and retention as event notifications, hidden rule ID `replJournal`);
+// - the journal is replayed in order by a dedicated goroutine that persists its position
+//   upon each replicated event and retries with exponential backoff otherwise;
+// - replaying an event means reconciling the destination with the _current_ state of
+//   the source object: copy it if present (and not yet replicated), delete it otherwise;
+// - replicated objects get marked (custom metadata ReplObjMD), which in turn is used by
+//   list-objects (apc.EntryReplicated, apc.EntryReplPending) and to skip no-op copies;
+// - catch-up scan: walks all local objects of the source bucket and replicates those
+//   that are not yet marked; runs when replication is (re)enabled, when this target restarts,
+//   when the destination changes, and when the journal loses events (retention);
+// - the scan is not a full resync: it only copies what exists at the source - deletions
+//   that never got journaled or replayed (lost to retention, or while replication was disabled)
+//   are not propagated, and the destination may retain objects deleted at the source.
+
+const replJournal = ".repl" // (cannot collide with user-defined rule IDs - see cmn.EventRule)
+
+func (em *evMgr) replicator(j *evJournal, bprops *cmn.Bprops) func([]cmn.ObjEvent) (int, error) {
+	repl := bprops.Replication
+	if repl.Dest != j.replDest {
+		j.replDest = repl.Dest
+		j.catchup()
+	}
+	src := meta.CloneBck(&j.bck)
+	src.Props = bprops
+	return func(evs []cmn.ObjEvent) (int, error) {
+		dst, err := em.replDst(&repl)
+		if err != nil {
+			j.setLag(time.Now().UnixNano() - evs[0].Time)
+			return 0, err
+		}
+		for i := range evs {
+			if err := em.replEvent(src, dst, &repl, &evs[i]); err != nil {
+				j.setLag(time.Now().UnixNano() - evs[i].Time)
+				return i, err
+			}
+		}
+		j.setLag(j.pendingLag(evs[len(evs)-1].Seq))
+		return len(evs), nil
+	}
+}
+
+func (em *evMgr) replDst(repl *cmn.ReplConf) (*meta.Bck, error) {
+	bck, err := repl.DestBck()
+	if err != nil {
+		return nil, err
+	}
+	dst := meta.CloneBck(&bck)
+	if err := dst.Init(em.t.owner.bmd); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// reconcile destination with the current state of the source object
+func (em *evMgr) replEvent(src, dst *meta.Bck, repl *cmn.ReplConf, ev *cmn.ObjEvent) error {
+	lom := core.AllocLOM(ev.ObjName)
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(src.Bucket()); err != nil {
+		return err
+	}
+	err := lom.Load(true /*cache it*/, false /*locked*/)
+	switch {
+	case err == nil:
+		return em.replObj(lom, dst, repl)
+	case cos.IsNotExist(err, 0):
+		if ev.Event != cmn.EventObjRemoved {
+			return nil // deleted (or renamed) since - see next event(s)
+		}
+		return em.replDel(dst, lom)
+	default:
+		return err
+	}
+}
+
+func (em *evMgr) replObj(lom *core.LOM, dst *meta.Bck, repl *cmn.ReplConf) error {
+	if repl.CanSkip(lom) {
+		return nil
+	}
+	var (
+		t         = em.t
+		tag       = repl.Tag(lom)
+		vlabs     = map[string]string{stats.VarlabBucket: lom.Bck().Cname("")}
+		buf, slab = t.gmm.Alloc()
+		coiParams = xs.AllocCOI()
+	)
+	{
+		coiParams.BckTo = dst
+		coiParams.ObjnameTo = lom.ObjName
+		coiParams.Buf = buf
+		coiParams.Config = cmn.GCO.Get()
+		coiParams.OWT = cmn.OwtCopy
+		coiParams.Finalize = true
+	}
+	size, err := t.CopyObject(lom, nil /*DM*/, coiParams)
+	xs.FreeCOI(coiParams)
+	slab.Free(buf)
+	if err != nil {
+		t.statsT.IncWith(stats.ErrReplCount, vlabs)
+		return err
+	}
+
+	// mark replicated unless modified in the meantime
+	lom.Lock(true)
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err == nil && repl.Tag(lom) == tag {
+		lom.SetCustomKey(cmn.ReplObjMD, tag)
+		if err := lom.Persist(); err != nil {
+			nlog.Warningln(t.String(), "replication: failed to mark", lom.Cname(), "[", err, "]")
+		}
+	}
+	lom.Unlock(true)
+
+	t.statsT.AddWith(
+		cos.NamedVal64{Name: stats.ReplCount, Value: 1, VarLabs: vlabs},
+		cos.NamedVal64{Name: stats.ReplSize, Value: size, VarLabs: vlabs},
+	)
+	return nil
+}
+
+// delete destination object via its owner target (not found is not an error)
+func (em *evMgr) replDel(dst *meta.Bck, lom *core.LOM) error {
+	var (
+		t     = em.t
+		smap  = t.owner.smap.get()
+		vlabs = map[string]string{stats.VarlabBucket: lom.Bck().Cname("")}
+	)
+	tsi, err := smap.HrwName2T(dst.MakeUname(lom.ObjName))
+	if err != nil {
+		return err
+	}
+	if tsi.ID() == t.SID() {
+		dlom := core.AllocLOM(lom.ObjName)
+		defer core.FreeLOM(dlom)
+		if err := dlom.InitBck(dst.Bucket()); err != nil {
+			return err
+		}
+		ecode, err := t.DeleteObject(dlom, false /*evict*/)
+		switch {
+		case err == nil:
+			ec.ECM.CleanupObject(dlom)
+		case !cos.IsNotExist(err, ecode):
+			t.statsT.IncWith(stats.ErrReplCount, vlabs)
+			return err
+		}
+	} else {
+		cargs := allocCargs()
+		{
+			cargs.si = tsi
+			cargs.req = cmn.HreqArgs{
+				Method: http.MethodDelete,
+				Base:   tsi.URL(cmn.NetIntraData),
+				Path:   apc.URLPathObjects.Join(dst.Name, lom.ObjName),
+				Query:  dst.NewQuery(),
+			}
+			cargs.timeout = cmn.GCO.Get().Client.Timeout.D()
+		}
+		res := t.call(cargs, smap)
+		freeCargs(cargs)
+		err, status := res.toErr(), res.status
+		freeCR(res)
+		if err != nil && status != http.StatusNotFound {
+			t.statsT.IncWith(stats.ErrReplCount, vlabs)
+			return err
+		}
+	}
+	t.statsT.IncWith(stats.ReplDelCount, vlabs)
+	return nil
+}
+
+//
+// catch-up scan
+//
+
+func (j *evJournal) catchup() {
+	if j.scan.CAS(false, true) {
+		go j._catchup()
+	}
+}
+
+func (j *evJournal) _catchup() {
+	defer j.scan.Store(false)
+	var (
+		em      = j.mgr
+		backoff = evBackoffMin
+	)
+	for {
+		bprops := em.bprops(&j.bck, j.bid)
+		if bprops == nil || !bprops.Replication.Enabled {
+			return
+		}
+		n, err := em.replScan(&j.bck, bprops)
+		if err == nil {
+			nlog.Infoln(em.t.String(), "replication", j.bck.Cname(""), "=>", bprops.Replication.Dest,
+				"- catch-up scan done, replicated", n, "object(s)")
+			if cur := em.bprops(&j.bck, j.bid); cur == nil || cur.Replication.Dest == bprops.Replication.Dest {
+				return
+			}
+			continue // destination changed during scan
+		}
+		nlog.Warningln(em.t.String(), "replication", j.bck.Cname(""), "catch-up scan - retrying in", backoff, "[", err, "]")
+		time.Sleep(backoff)
+		backoff = min(2*backoff, evBackoffMax)
+	}
+}
+
+// replicate all local objects that are not marked (the first error stops the scan);
+// does not delete anything at the destination (see above)
+func (em *evMgr) replScan(bck *cmn.Bck, bprops *cmn.Bprops) (n int, _ error) {
+	repl := &bprops.Replication
+	dst, err := em.replDst(repl)
+	if err != nil {
+		return 0, err
+	}
+	smap := em.t.owner.smap.get()
+	cb := func(fqn string, de fs.DirEntry) error {
+		if de.IsDir() {
+			return nil
+		}
+		lom := core.AllocLOM("")
+		defer core.FreeLOM(lom)
+		if err := lom.InitFQN(fqn, bck); err != nil || !lom.IsHRW() {
+			return nil
+		}
+		if _, local, err := lom.HrwTarget(&smap.Smap); err != nil || !local {
+			return nil
+		}
+		if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil || repl.CanSkip(lom) {
+			return nil
+		}
+		if err := em.replObj(lom, dst, repl); err != nil {
+			return err
+		}
+		n++
+		return nil
+	}
+	for _, mi := range fs.GetAvail() {
+		opts := &fs.WalkOpts{Mi: mi, Bck: *bck, CTs: []string{fs.ObjectType}, Callback: cb}
+		if err := fs.Walk(opts); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// age of the oldest pending (not yet replayed) event, if any
+func (j *evJournal) pendingLag(cursor int64) int64 {
+	evs, err := j.read(cursor, 1)
+	if err != nil || len(evs) == 0 {
+		return 0
+	}
+	return time.Now().UnixNano() - evs[0].Time
+}
+
+// replication lag gauge (updated by the delivering goroutine)
+func (j *evJournal) setLag(lag int64) {
+	lag = max(lag, 0)
+	if lag == j.lag {
+		return
+	}
+	vlabs := map[string]string{stats.VarlabBucket: j.bck.Cname("")}
+	j.mgr.t.statsT.AddWith(cos.NamedVal64{Name: stats.ReplLag, Value: lag - j.lag, VarLabs: vlabs})
+	j.lag = lag
+}
@@ -5,6 +5,7 @@
 package ais
 
 import (
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/feat"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
@@ -42,19 +44,48 @@ func (t *target) s3Handler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// CORS (redirected cross-origin request; compare with proxy)
+	if r.Header.Get(cos.HdrOrigin) != "" && isRedirect(r.URL.Query()) != "" {
+		if bck, err, _ := meta.InitByNameOnly(apiItems[0], t.owner.bmd); err == nil {
+			s3.SetCORS(w.Header(), r, &bck.Props.CORS)
+		}
+	}
+
+	// payload SHA256 (signed and verified by the redirecting gateway - see s3.NewPayloadReader)
+	if cmn.Rom.AuthEnabled() && r.Body != nil && r.Body != http.NoBody {
+		if sum := s3.PayloadSHA256(r); sum != "" {
+			r.Body = s3.NewPayloadReader(r.Body, sum)
+		}
+	}
+
 	switch r.Method {
 	case http.MethodHead:
 		t.headObjS3(w, r, apiItems)
 	case http.MethodGet:
-		t.getObjS3(w, r, apiItems)
+		q := r.URL.Query()
+		switch {
+		case q.Has(s3.QparamTagging):
+			t.objTaggingS3(w, r, apiItems)
+		case q.Has(s3.QparamAttributes):
+			t.objAttrsS3(w, r, apiItems)
+		default:
+			t.getObjS3(w, r, apiItems)
+		}
 	case http.MethodPut:
+		if r.URL.Query().Has(s3.QparamTagging) {
+			t.objTaggingS3(w, r, apiItems)
+			return
+		}
 		config := cmn.GCO.Get()
 		t.putCopyMpt(w, r, config, apiItems)
 	case http.MethodDelete:
 		q := r.URL.Query()
-		if q.Has(s3.QparamMptUploadID) {
+		switch {
+		case q.Has(s3.QparamMptUploadID):
 			t.abortMpt(w, r, apiItems, q)
-		} else {
+		case q.Has(s3.QparamTagging):
+			t.objTaggingS3(w, r, apiItems)
+		default:
 			t.delObjS3(w, r, apiItems)
 		}
 	case http.MethodPost:
@@ -196,6 +227,15 @@ func (t *target) putObjS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck,
 
 	// TODO: dual checksumming, e.g. lom.SetCustom(apc.AWS, ...)
 
+	if v := r.Header.Get(cos.S3HdrTagging); v != "" {
+		tagging, err := s3.DecodeTagging(v)
+		if err != nil {
+			s3.WriteErr(w, r, err, 0)
+			return
+		}
+		lom.SetCustomKey(cmn.TaggingObjMD, tagging.Encode())
+	}
+
 	dpq := dpqAlloc()
 	if err := dpq.parse(r.URL.RawQuery); err != nil {
 		s3.WriteErr(w, r, err, 0)
@@ -288,6 +328,11 @@ func (t *target) headObjS3(w http.ResponseWriter, r *http.Request, items []strin
 		s3.WriteErr(w, r, err, ecode)
 		return
 	}
+	precond, err := cmn.ParsePrecond(r.Header)
+	if err != nil {
+		s3.WriteErr(w, r, err, http.StatusBadRequest)
+		return
+	}
 	lom := core.AllocLOM(objName)
 	defer core.FreeLOM(lom)
 	if err := lom.InitBck(bck.Bucket()); err != nil {
@@ -352,8 +397,87 @@ func (t *target) headObjS3(w http.ResponseWriter, r *http.Request, items []strin
 	if v, ok := custom[cmn.VersionObjMD]; ok {
 		hdr.Set(cos.S3VersionHeader, v)
 	}
+	if v, ok := custom[cmn.TaggingObjMD]; ok && v != "" {
+		if tagging, err := s3.DecodeTagging(v); err == nil {
+			hdr.Set(cos.S3HdrTaggingCount, strconv.Itoa(len(tagging.TagSet)))
+		}
+	}
 
 	// TODO: add custom user keys, if any
+
+	// conditional HEAD
+	if precond != nil {
+		mtime := cmn.ObjLastModified(&op.ObjAttrs)
+		if exists {
+			mtime = lomMtime(lom)
+		}
+		switch ecode, what := precond.Eval(http.MethodHead, &op.ObjAttrs, mtime); ecode {
+		case http.StatusNotModified:
+			w.WriteHeader(ecode)
+		case http.StatusPreconditionFailed:
+			s3.WriteErr(w, r, cmn.NewErrPrecondFailed(lom.Cname(), what), ecode)
+		}
+	}
+}
+
+// GET /s3/<bucket-name>/<object-name>?attributes
+// See: https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectAttributes.html
+// (compare with `headObjS3`)
+func (t *target) objAttrsS3(w http.ResponseWriter, r *http.Request, items []string) {
+	bck, err, ecode := meta.InitByNameOnly(items[0], t.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	req, err := s3.ParseObjAttrsReq(r.Header)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	lom := core.AllocLOM(s3.ObjName(items))
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+
+	var (
+		oa  *cmn.ObjAttrs
+		fqn string
+	)
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err == nil {
+		oa, fqn = lom.ObjAttrs(), lom.FQN
+	} else {
+		if !cos.IsNotExist(err, 0) {
+			s3.WriteErr(w, r, err, 0)
+			return
+		}
+		if bck.IsAIS() {
+			s3.WriteErr(w, r, cos.NewErrNotFound(t, lom.Cname()), 0)
+			return
+		}
+		// cold HEAD
+		if oa, ecode, err = t.HeadCold(lom, r); err != nil {
+			s3.WriteErr(w, r, err, ecode)
+			return
+		}
+	}
+	res, err := s3.NewObjAttrsResult(req, oa, fqn)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+
+	hdr := w.Header()
+	hdr.Set(cos.S3LastModified, cos.FormatNanoTime(oa.Atime, cos.RFC1123GMT)) // see `headObjS3`
+	if v, ok := oa.GetCustomKey(cmn.VersionObjMD); ok {
+		hdr.Set(cos.S3VersionHeader, v)
+	}
+	sgl := t.gmm.NewSGL(0)
+	res.MustMarshal(sgl)
+	hdr.Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
 }
 
 // DELETE /s3/<bucket-name>/<object-name>
@@ -382,6 +506,83 @@ func (t *target) delObjS3(w http.ResponseWriter, r *http.Request, items []string
 	}
 	// EC cleanup if EC is enabled
 	ec.ECM.CleanupObject(lom)
+	t.usage.add(r.URL.Query().Get(apc.QparamUser), lom.Bck(), usageDel, 1, 0)
+}
+
+// [PUT|GET|DELETE] /s3/<bucket-name>/<object-name>?tagging
+// - tags are stored in-cluster as object's custom metadata (and are not propagated to remote backends)
+// - see also: s3/tagging.go
+func (t *target) objTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	bck, err, ecode := meta.InitByNameOnly(items[0], t.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	lom := core.AllocLOM(s3.ObjName(items))
+	defer core.FreeLOM(lom)
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	var tagging *s3.Tagging
+	if r.Method == http.MethodPut {
+		tagging = &s3.Tagging{}
+		if err := xml.NewDecoder(r.Body).Decode(tagging); err != nil {
+			s3.WriteErr(w, r, &s3.ErrCode{Code: "MalformedXML", Msg: err.Error()}, 0)
+			return
+		}
+		if err := tagging.Validate(); err != nil {
+			s3.WriteErr(w, r, err, 0)
+			return
+		}
+	}
+
+	exclusive := r.Method != http.MethodGet
+	lom.Lock(exclusive)
+	defer lom.Unlock(exclusive)
+	if err := lom.Load(true /*cache it*/, true /*locked*/); err != nil {
+		if cos.IsNotExist(err, 0) {
+			s3.WriteErr(w, r, cos.NewErrNotFound(t, lom.Cname()), http.StatusNotFound)
+		} else {
+			s3.WriteErr(w, r, err, 0)
+		}
+		return
+	}
+	v, exists := lom.GetCustomKey(cmn.TaggingObjMD)
+
+	switch r.Method {
+	case http.MethodGet:
+		tagging = &s3.Tagging{}
+		if exists && v != "" {
+			if tagging, err = s3.DecodeTagging(v); err != nil {
+				s3.WriteErr(w, r, err, http.StatusInternalServerError)
+				return
+			}
+		}
+		sgl := t.gmm.NewSGL(0)
+		tagging.MustMarshal(sgl)
+		w.Header().Set(cos.HdrContentType, cos.ContentXML)
+		sgl.WriteTo2(w)
+		sgl.Free()
+		return
+	case http.MethodPut:
+		lom.SetCustomKey(cmn.TaggingObjMD, tagging.Encode())
+	default:
+		debug.Assert(r.Method == http.MethodDelete, r.Method)
+		if !exists {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		delete(lom.GetCustomMD(), cmn.TaggingObjMD)
+	}
+	if err := lom.Persist(); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	t.mdidx.update(lom)
+	if r.Method == http.MethodDelete {
+		w.WriteHeader(http.StatusNoContent)
+	}
 }
 
 // POST /s3/<bucket-name>/<object-name>
@@ -0,0 +1,318 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Usage accounting (feat.UsageAccounting):
+// - gateways add the requesting (AuthN) user ID to the redirect URL (apc.QparamUser),
+//   and to multi-object delete and evict requests;
+// - targets attribute user GET, PUT, and DELETE requests to (user, bucket namespace) pairs,
+//   export the counters via Prometheus (stats.UsageVarlabs), and persist them in kvdb;
+// - stored bytes are charged to the object's owner - the user that PUT it (cmn.OwnerObjMD);
+//   overwriting, deleting, or evicting the object (by any user, or by an xaction) uncharges the owner;
+// - the primary aggregates GET(what=usage) cluster-wide, optionally grouping by user or namespace.
+// See also apc.UsageStats.
+
+const (
+	usageCollection = "usage"
+	usageKey        = "stats"
+	usageSaveIval   = time.Minute
+)
+
+const (
+	usageGet = iota
+	usagePut
+	usageDel
+)
+
+type (
+	usageID struct {
+		user, ns string
+	}
+	usageEntry struct {
+		getN, getSize atomic.Int64
+		putN, putSize atomic.Int64
+		delN          atomic.Int64
+		stored        atomic.Int64
+	}
+	usageTracker struct {
+		statsT stats.Tracker
+		db     kvdb.Driver
+		m      sync.Map // usageID => *usageEntry
+		dirty  atomic.Bool
+	}
+)
+
+func (u *usageTracker) init(statsT stats.Tracker, db kvdb.Driver) {
+	u.statsT, u.db = statsT, db
+	u.load()
+	hk.Reg(usageCollection+hk.NameSuffix, u.housekeep, usageSaveIval)
+}
+
+func usageEnabled() bool { return cmn.Rom.Features().IsSet(feat.UsageAccounting) }
+
+// usageGet and usagePut: n = 1, size in bytes; usageDel: n = number of deleted objects
+func (u *usageTracker) add(user string, bck *meta.Bck, op int, n, size int64) {
+	if !usageEnabled() {
+		return
+	}
+	var (
+		id    = usageID{user: user, ns: bck.Ns.String()}
+		e     = u.entry(id)
+		vlabs = map[string]string{stats.VarlabUser: id.user, stats.VarlabNamespace: id.ns}
+	)
+	switch op {
+	case usageGet:
+		e.getN.Add(n)
+		e.getSize.Add(size)
+		u.statsT.AddWith(
+			cos.NamedVal64{Name: stats.UsageGetCount, Value: n, VarLabs: vlabs},
+			cos.NamedVal64{Name: stats.UsageGetSize, Value: size, VarLabs: vlabs},
+		)
+	case usagePut:
+		e.putN.Add(n)
+		e.putSize.Add(size)
+		e.stored.Add(size)
+		u.statsT.AddWith(
+			cos.NamedVal64{Name: stats.UsagePutCount, Value: n, VarLabs: vlabs},
+			cos.NamedVal64{Name: stats.UsagePutSize, Value: size, VarLabs: vlabs},
+			cos.NamedVal64{Name: stats.UsageStoredSize, Value: size, VarLabs: vlabs},
+		)
+	case usageDel:
+		e.delN.Add(n)
+		u.statsT.AddWith(
+			cos.NamedVal64{Name: stats.UsageDelCount, Value: n, VarLabs: vlabs},
+		)
+	}
+	u.dirty.Store(true)
+}
+
+// uncharge the owner upon object removal (delete, evict);
+// the caller must have loaded the object's metadata
+func (u *usageTracker) uncharge(lom *core.LOM) {
+	if owner, ok := usageOwner(lom); ok {
+		u.sub(owner, lom.Bck(), lom.Lsize(true))
+	}
+}
+
+func (u *usageTracker) sub(owner string, bck *meta.Bck, size int64) {
+	if !usageEnabled() {
+		return
+	}
+	var (
+		id    = usageID{user: owner, ns: bck.Ns.String()}
+		e     = u.entry(id)
+		vlabs = map[string]string{stats.VarlabUser: id.user, stats.VarlabNamespace: id.ns}
+	)
+	e.stored.Sub(size)
+	u.statsT.AddWith(cos.NamedVal64{Name: stats.UsageStoredSize, Value: -size, VarLabs: vlabs})
+	u.dirty.Store(true)
+}
+
+// (under write lock) the owner and size of the existing object about to be overwritten, if charged
+func usageCharged(lom *core.LOM) (owner string, size int64, ok bool) {
+	prev := core.AllocLOM(lom.ObjName)
+	defer core.FreeLOM(prev)
+	if prev.InitBck(lom.Bucket()) != nil || prev.Load(false /*cache it*/, true /*locked*/) != nil {
+		return "", 0, false
+	}
+	if owner, ok = usageOwner(prev); ok {
+		size = prev.Lsize(true)
+	}
+	return owner, size, ok
+}
+
+// object's owner (custom metadata): "<bucket>|<user>", where the bucket disambiguates
+// copies (e.g., copy-bucket, mirroring) that carry the source's metadata but are not charged
+func usageOwnerMD(bck *meta.Bck, user string) string { return bck.Cname("") + "|" + user }
+
+func usageOwner(lom *core.LOM) (string, bool) {
+	v, ok := lom.GetCustomKey(cmn.OwnerObjMD)
+	if !ok {
+		return "", false
+	}
+	cname, user, ok := strings.Cut(v, "|")
+	if !ok || cname != lom.Bck().Cname("") {
+		return "", false
+	}
+	return user, true
+}
+
+// usage accounting and per-user GET bandwidth limit: targets attribute the request to the (AuthN) user
+func (p *proxy) usageUser(hdr http.Header, query url.Values) {
+	query.Del(apc.QparamUser) // (internal use only)
+	if cmn.Rom.AuthEnabled() && (usageEnabled() || ratelimUserBps(cmn.GCO.Get())) {
+		if uid := p.userID(hdr); uid != "" {
+			query.Set(apc.QparamUser, uid)
+		}
+	}
+}
+
+func (u *usageTracker) entry(id usageID) *usageEntry {
+	if v, ok := u.m.Load(id); ok {
+		return v.(*usageEntry)
+	}
+	v, _ := u.m.LoadOrStore(id, &usageEntry{})
+	return v.(*usageEntry)
+}
+
+func (u *usageTracker) all() []*apc.UsageStats {
+	out := make([]*apc.UsageStats, 0, 8)
+	u.m.Range(func(k, v any) bool {
+		var (
+			id = k.(usageID)
+			e  = v.(*usageEntry)
+		)
+		out = append(out, &apc.UsageStats{
+			User:      id.user,
+			Namespace: id.ns,
+			GetCount:  e.getN.Load(),
+			GetBytes:  e.getSize.Load(),
+			PutCount:  e.putN.Load(),
+			PutBytes:  e.putSize.Load(),
+			DelCount:  e.delN.Load(),
+			Stored:    e.stored.Load(),
+		})
+		return true
+	})
+	return out
+}
+
+func (u *usageTracker) load() {
+	var all []*apc.UsageStats
+	if err := u.db.Get(usageCollection, usageKey, &all); err != nil {
+		if !cos.IsErrNotFound(err) {
+			nlog.Errorln("usage: failed to load:", err)
+		}
+		return
+	}
+	for _, us := range all {
+		e := u.entry(usageID{user: us.User, ns: us.Namespace})
+		e.getN.Store(us.GetCount)
+		e.getSize.Store(us.GetBytes)
+		e.putN.Store(us.PutCount)
+		e.putSize.Store(us.PutBytes)
+		e.delN.Store(us.DelCount)
+		e.stored.Store(us.Stored)
+		if us.Stored != 0 {
+			vlabs := map[string]string{stats.VarlabUser: us.User, stats.VarlabNamespace: us.Namespace}
+			u.statsT.AddWith(cos.NamedVal64{Name: stats.UsageStoredSize, Value: us.Stored, VarLabs: vlabs})
+		}
+	}
+}
+
+func (u *usageTracker) housekeep(int64) time.Duration {
+	if u.dirty.CAS(true, false) {
+		if err := u.db.Set(usageCollection, usageKey, u.all()); err != nil {
+			nlog.Errorln("usage: failed to store:", err)
+			u.dirty.Store(true)
+		}
+	}
+	return usageSaveIval
+}
+
+// group by user or namespace (by == "": by (user, namespace) pairs); sort by bytes read and written
+func usageGroup(all []*apc.UsageStats, by string) []*apc.UsageStats {
+	var (
+		out = make([]*apc.UsageStats, 0, len(all))
+		m   = make(map[usageID]*apc.UsageStats, len(all))
+	)
+	for _, us := range all {
+		var id usageID
+		switch by {
+		case apc.UsageByUser:
+			id.user = us.User
+		case apc.UsageByNamespace:
+			id.ns = us.Namespace
+		default:
+			id.user, id.ns = us.User, us.Namespace
+		}
+		if prev, ok := m[id]; ok {
+			prev.Add(us)
+			continue
+		}
+		group := &apc.UsageStats{User: id.user, Namespace: id.ns}
+		group.Add(us)
+		m[id] = group
+		out = append(out, group)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		si, sj := out[i].GetBytes+out[i].PutBytes, out[j].GetBytes+out[j].PutBytes
+		if si != sj {
+			return si > sj
+		}
+		if out[i].User != out[j].User {
+			return out[i].User < out[j].User
+		}
+		return out[i].Namespace < out[j].Namespace
+	})
+	return out
+}
+
+func validUsageBy(by string) error {
+	switch by {
+	case "", apc.UsageByUser, apc.UsageByNamespace:
+		return nil
+	default:
+		return fmt.Errorf("invalid usage grouping %q (expecting %q or %q)", by, apc.UsageByUser, apc.UsageByNamespace)
+	}
+}
+
+//
+// GET(what=usage)
+//
+
+// primary: aggregate all targets
+func (p *proxy) qcluUsage(w http.ResponseWriter, r *http.Request, what string, query url.Values) {
+	if p.forwardCP(w, r, nil, what) {
+		return
+	}
+	by := query.Get(apc.QparamUsageBy)
+	if err := validUsageBy(by); err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodGet, Path: apc.URLPathDae.S, Query: url.Values{apc.QparamWhat: []string{what}}}
+	args.timeout = cmn.GCO.Get().Client.Timeout.D()
+	args.to = core.Targets
+	args.cresv = cresUsage{}
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+
+	var all []*apc.UsageStats
+	for _, res := range results {
+		if res.err != nil {
+			err := res.toErr()
+			freeBcastRes(results)
+			p.writeErr(w, r, fmt.Errorf("usage: %v", err))
+			return
+		}
+		all = append(all, *res.v.(*[]*apc.UsageStats)...)
+	}
+	freeBcastRes(results)
+	p.writeJSON(w, r, usageGroup(all, by), what)
+}
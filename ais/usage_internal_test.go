@@ -0,0 +1,155 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"path"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools/readers"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Usage accounting", func() {
+	// as reported by two targets
+	all := []*apc.UsageStats{
+		{User: "alice", Namespace: "", GetCount: 1, GetBytes: 100, Stored: 10},
+		{User: "alice", Namespace: "@#ns1", PutCount: 2, PutBytes: 200, Stored: 200},
+		{User: "bob", Namespace: "@#ns1", GetCount: 3, GetBytes: 30},
+		{User: "alice", Namespace: "", GetCount: 4, GetBytes: 400, DelCount: 1, Stored: -5},
+	}
+
+	It("should aggregate by user and namespace", func() {
+		out := usageGroup(all, "")
+		Expect(out).To(HaveLen(3))
+		Expect(*out[0]).To(Equal(apc.UsageStats{User: "alice", GetCount: 5, GetBytes: 500, DelCount: 1, Stored: 5}))
+		Expect(out[1].Namespace).To(Equal("@#ns1"))
+		Expect(out[1].PutBytes).To(Equal(int64(200)))
+		Expect(out[2].User).To(Equal("bob"))
+	})
+
+	It("should group by user", func() {
+		out := usageGroup(all, apc.UsageByUser)
+		Expect(out).To(HaveLen(2))
+		Expect(*out[0]).To(Equal(apc.UsageStats{User: "alice", GetCount: 5, GetBytes: 500,
+			PutCount: 2, PutBytes: 200, DelCount: 1, Stored: 205}))
+		Expect(out[1].User).To(Equal("bob"))
+		Expect(out[1].Namespace).To(BeEmpty())
+	})
+
+	It("should group by namespace", func() {
+		out := usageGroup(all, apc.UsageByNamespace)
+		Expect(out).To(HaveLen(2))
+		Expect(out[0].User).To(BeEmpty())
+		Expect(out[0].GetBytes).To(Equal(int64(500)))
+		Expect(out[1].Namespace).To(Equal("@#ns1"))
+		Expect(out[1].GetCount).To(Equal(int64(3)))
+		Expect(out[1].PutBytes).To(Equal(int64(200)))
+	})
+
+	It("should validate grouping", func() {
+		Expect(validUsageBy("")).NotTo(HaveOccurred())
+		Expect(validUsageBy(apc.UsageByUser)).NotTo(HaveOccurred())
+		Expect(validUsageBy("bucket")).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Usage accounting: stored bytes", func() {
+	var (
+		saved  = cmn.GCO.Get().Features
+		bck    = meta.NewBck(testBucket, apc.AIS, cmn.NsGlobal)
+		stored = func(user string) int64 {
+			for _, us := range t.usage.all() {
+				if us.User == user && us.Namespace == bck.Ns.String() {
+					return us.Stored
+				}
+			}
+			return 0
+		}
+		put = func(objName, user string, size int64) {
+			lom := core.AllocLOM(objName)
+			defer core.FreeLOM(lom)
+			Expect(lom.InitBck(bck.Bucket())).NotTo(HaveOccurred())
+			r, err := readers.NewRand(size, cos.ChecksumNone)
+			Expect(err).NotTo(HaveOccurred())
+			poi := &putOI{
+				atime:   time.Now().UnixNano(),
+				t:       t,
+				lom:     lom,
+				r:       r,
+				workFQN: path.Join(testMountpath, objName+".work"),
+				config:  cmn.GCO.Get(),
+				owt:     cmn.OwtPut,
+				restful: true,
+				user:    user,
+			}
+			_, err = poi.putObject()
+			Expect(err).NotTo(HaveOccurred())
+		}
+	)
+	setFeatures := func(flags feat.Flags) {
+		config := cmn.GCO.BeginUpdate()
+		config.Features = flags
+		cmn.GCO.CommitUpdate(config)
+		cmn.Rom.Set(&config.ClusterConfig)
+	}
+
+	BeforeEach(func() {
+		t.usage = usageTracker{statsT: t.statsT}
+		setFeatures(saved | feat.UsageAccounting)
+	})
+	AfterEach(func() {
+		setFeatures(saved)
+	})
+
+	It("should uncharge the previous owner upon overwrite", func() {
+		put("usage-overwrite", "alice", 100)
+		Expect(stored("alice")).To(Equal(int64(100)))
+
+		put("usage-overwrite", "alice", 30)
+		Expect(stored("alice")).To(Equal(int64(30)))
+
+		put("usage-overwrite", "bob", 50)
+		Expect(stored("alice")).To(BeZero())
+		Expect(stored("bob")).To(Equal(int64(50)))
+	})
+
+	It("should uncharge the owner (not the deleter) upon delete", func() {
+		put("usage-delete", "alice", 64)
+		Expect(stored("alice")).To(Equal(int64(64)))
+
+		lom := core.AllocLOM("usage-delete")
+		defer core.FreeLOM(lom)
+		Expect(lom.InitBck(bck.Bucket())).NotTo(HaveOccurred())
+		_, err := t.DeleteObject(lom, false /*evict*/)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(stored("alice")).To(BeZero())
+		Expect(stored("bob")).To(BeZero())
+	})
+
+	It("should not charge copies carrying the source's owner", func() {
+		put("usage-copy", "alice", 10)
+		lom := core.AllocLOM("usage-copy")
+		defer core.FreeLOM(lom)
+		Expect(lom.InitBck(bck.Bucket())).NotTo(HaveOccurred())
+		Expect(lom.Load(false, false)).NotTo(HaveOccurred())
+		owner, ok := usageOwner(lom)
+		Expect(ok).To(BeTrue())
+		Expect(owner).To(Equal("alice"))
+
+		// same metadata, different bucket
+		lom.SetCustomKey(cmn.OwnerObjMD, usageOwnerMD(meta.NewBck("other", apc.AIS, cmn.NsGlobal), "alice"))
+		_, ok = usageOwner(lom)
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -20,6 +20,7 @@ const (
 	ActResetBprops = "reset-bprops"
 
 	ActSummaryBck = "summary-bck"
+	ActSearchMD   = "search-md" // search objects by custom metadata (see MDSearchMsg)
 
 	ActECEncode  = "ec-encode" // erasure code a bucket
 	ActECGet     = "ec-get"    // read erasure coded objects
@@ -49,6 +50,8 @@ const (
 	ActLRU          = "lru"
 	ActStoreCleanup = "cleanup-store"
 
+	ActComposeObject  = "compose-obj"
+	ActCopyRange      = "copy-range"
 	ActEvictRemoteBck = "evict-remote-bck" // evict remote bucket's data
 	ActInvalListCache = "inval-listobj-cache"
 	ActList           = "list"
@@ -0,0 +1,29 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+import "time"
+
+// audit classes (see config "audit.classes")
+const (
+	AuditCluster = "cluster" // cluster and node management, including configuration
+	AuditBucket  = "bucket"  // bucket create, destroy, rename, copy, properties, etc.
+	AuditObject  = "object"  // data plane (subject to sampling - see "audit.object_sample_pct")
+)
+
+// audit log record (one JSON line); GET(what=audit) returns the most recent ones
+type AuditEvent struct {
+	Time   time.Time `json:"time"`
+	User   string    `json:"user,omitempty"` // user ID from the token (AuthN)
+	Client string    `json:"client"`         // remote address
+	Node   string    `json:"node"`           // proxy that has recorded the event
+	Class  string    `json:"class"`          // AuditCluster, ...
+	Method string    `json:"method"`
+	Path   string    `json:"path"`
+	Action string    `json:"action,omitempty"` // ActMsg.Action, if any
+	Bucket string    `json:"bucket,omitempty"`
+	Object string    `json:"object,omitempty"`
+	Status int       `json:"status"` // HTTP status (note: data plane operations are normally redirected)
+}
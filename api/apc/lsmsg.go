@@ -5,6 +5,7 @@
 package apc
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -78,6 +79,11 @@ const (
 
 	// Do not return virtual subdirectories - do not include them as `cmn.LsoEnt` entries
 	LsNoDirs
+
+	// List all object versions (and delete markers, if any) - remote versioned buckets
+	// (Amazon S3, Google Cloud, Azure) only; in-cluster objects are listed as their latest (and only) versions.
+	// See also: EntryNotLatest, EntryDelMarker
+	LsVersions
 )
 
 // max page sizes
@@ -105,6 +111,12 @@ const (
 	EntryIsArchive  = 1 << (EntryStatusBits + 4)
 	EntryVerChanged = 1 << (EntryStatusBits + 5) // see also: QparamLatestVer, et al.
 	EntryVerRemoved = 1 << (EntryStatusBits + 6) // ditto
+	EntryNotLatest  = 1 << (EntryStatusBits + 7) // non-current object version (see LsVersions)
+	EntryDelMarker  = 1 << (EntryStatusBits + 8) // delete marker (ditto)
+
+	// bucket-to-bucket replication status (when enabled - see bucket property "replication")
+	EntryReplicated  = 1 << (EntryStatusBits + 9)  // current content replicated
+	EntryReplPending = 1 << (EntryStatusBits + 10) // not yet (or not current content)
 )
 
 // ObjEntry.Flags field
@@ -146,12 +158,15 @@ type LsoMsg struct {
 	UUID              string      `json:"uuid"`                  // ID to identify a single multi-page request
 	Props             string      `json:"props"`                 // comma-delimited, e.g. "checksum,size,custom" (see GetProps* enum)
 	TimeFormat        string      `json:"time_format,omitempty"` // RFC822 is the default
-	Prefix            string      `json:"prefix"`                // return obj names starting with prefix (TODO: e.g. "A.tar/tutorials/")
+	Prefix            string      `json:"prefix"`                // return obj names starting with prefix (with LsArchDir, may cross shard boundary, e.g. "A.tar/tutorials/")
 	StartAfter        string      `json:"start_after,omitempty"` // start listing after (AIS buckets only)
 	ContinuationToken string      `json:"continuation_token"`    // => LsoResult.ContinuationToken => LsoMsg.ContinuationToken
 	SID               string      `json:"target"`                // selected target to solely execute backend.list-objects
 	Flags             uint64      `json:"flags,string"`          // enum {LsObjCached, ...} - "LsoMsg flags" above
 	PageSize          int64       `json:"pagesize"`              // max entries returned by list objects call
+	Delimiter         string      `json:"delimiter,omitempty"`   // roll up names into common prefixes (see Delim() below)
+	ArchRegx          string      `json:"archregx,omitempty"`    // with LsArchDir: list only matching archived files (compare with QparamArchregx)
+	ArchMode          string      `json:"archmode,omitempty"`    // how to interpret ArchRegx (QparamArchmode; default: regexp)
 }
 
 ////////////
@@ -206,6 +221,34 @@ func (lsmsg *LsoMsg) PropsSet() (s cos.StrSet) {
 	return s
 }
 
+// Delimiter "/" is the same as (and implies) LsNoRecursion;
+// any other (non-empty) delimiter rolls up object names into common prefixes, S3-style:
+// each common prefix is the name's substring from the beginning up to and including
+// the first occurrence of the delimiter after the (listed) prefix
+func (lsmsg *LsoMsg) Delim() string {
+	if lsmsg.Delimiter == "" && lsmsg.IsFlagSet(LsNoRecursion) {
+		return cos.PathSeparator
+	}
+	return lsmsg.Delimiter
+}
+
+// arbitrary (non-'/') delimiter
+func (lsmsg *LsoMsg) IsDelimAny() bool {
+	return lsmsg.Delimiter != "" && lsmsg.Delimiter != cos.PathSeparator
+}
+
+// normalize and validate (delimiter vs. LsNoRecursion)
+func (lsmsg *LsoMsg) InitDelim() error {
+	if lsmsg.Delimiter == cos.PathSeparator {
+		lsmsg.SetFlag(LsNoRecursion)
+		return nil
+	}
+	if lsmsg.IsDelimAny() && lsmsg.IsFlagSet(LsNoRecursion) {
+		return fmt.Errorf("delimiter %q contradicts no-recursion (which implies %q)", lsmsg.Delimiter, cos.PathSeparator)
+	}
+	return nil
+}
+
 func (lsmsg *LsoMsg) Str(cname string) string {
 	var sb strings.Builder
 	sb.Grow(80)
@@ -215,6 +258,14 @@ func (lsmsg *LsoMsg) Str(cname string) string {
 		sb.WriteString(", props:")
 		sb.WriteString(lsmsg.Props)
 	}
+	if lsmsg.IsDelimAny() {
+		sb.WriteString(", delimiter:")
+		sb.WriteString(lsmsg.Delimiter)
+	}
+	if lsmsg.ArchRegx != "" {
+		sb.WriteString(", archregx:")
+		sb.WriteString(lsmsg.ArchRegx)
+	}
 	if lsmsg.Flags == 0 {
 		return sb.String()
 	}
@@ -241,6 +292,9 @@ func (lsmsg *LsoMsg) Str(cname string) string {
 	if lsmsg.IsFlagSet(LsVerChanged) {
 		sb.WriteString("version-changed,")
 	}
+	if lsmsg.IsFlagSet(LsVersions) {
+		sb.WriteString("versions,")
+	}
 	s := sb.String()
 	return s[:len(s)-1]
 }
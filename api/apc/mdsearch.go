@@ -0,0 +1,14 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+// search objects by their custom metadata (see ActSearchMD)
+// - requires the bucket to have "Index-Custom-Metadata" feature (see cmn/feat) enabled;
+// - objects put (or updated) prior to enabling the feature are not indexed.
+type MDSearchMsg struct {
+	Key    string `json:"key"`              // custom metadata key, e.g. "label"
+	Value  string `json:"value,omitempty"`  // exact value, e.g. "cat"; empty: any value
+	Prefix string `json:"prefix,omitempty"` // optional object name prefix
+}
@@ -62,6 +62,15 @@ const (
 	QparamAppendType   = "append_type"
 	QparamAppendHandle = "append_handle"
 
+	// PATCH(object): overwrite existing ais:// object in place, starting at the specified offset,
+	// with the request body (requires feature flag "Allow-Object-Patch"; see also ActCopyRange)
+	QparamPatchOffset = "patch_offset"
+
+	// GET /v1/events/<bucket-name>: long-poll object events (see cmn.EventsResult)
+	QparamEventRule  = "rule"  // event rule ID (see cmn.EventRule)
+	QparamEventToken = "token" // as returned by the previous call (empty - from the beginning)
+	QparamEventWait  = "wait"  // max time to wait for events, e.g. "30s" (0 or none - do not wait)
+
 	// HTTP bucket support.
 	QparamOrigURL = "original_url"
 
@@ -70,6 +79,12 @@ const (
 	QparamLogOff  = "offset"
 	QparamAllLogs = "all"
 
+	// Get audit events
+	QparamAuditLimit = "limit"
+
+	// Get usage report: group by { UsageByUser, UsageByNamespace }
+	QparamUsageBy = "by"
+
 	// The following 4 (four) QparamArch* parameters are all intended for usage with sharded datasets,
 	// whereby the shards are (.tar, .tgz (or .tar.gz), .zip, and/or .tar.lz4) formatted objects.
 	//
@@ -77,6 +92,8 @@ const (
 	//
 	// "archpath" and "archmime", respectively, specify archived pathname and expected format (mime type)
 	// of the containing shard; the latter is especially usable with non-standard shard name extensions;
+	// "archpath" that ends with '/' selects all files under the respective archive-internal directory
+	// (same as "archregx" + "archmode=prefix" below);
 	QparamArchpath = "archpath"
 	QparamArchmime = "archmime"
 
@@ -86,6 +103,7 @@ const (
 	// In particular, "archregx" specifies prefix, suffix, WebDataset key, _or_ general-purpose regular expression
 	// that can be used to match archived filenames, and select possibly multiple files
 	// (that will be then archived as a TAR and returned in one shot);
+	// "archregx" without "archmode" is a general-purpose regular expression;
 	QparamArchregx = "archregx"
 
 	// "archmode", on the other hand, tells aistore whether to interpret "archregx" (above) as a
@@ -192,6 +210,7 @@ const (
 	QparamRebData          = "rbd" // true: get EC rebalance data (pulling data if push way fails)
 	QparamClusterInfo      = "cii" // true: /Health to return `cos.NodeStateInfo` including cluster metadata versions and state flags
 	QparamOWT              = "owt" // object write transaction enum { OwtPut, ..., OwtGet* }
+	QparamUser             = "usr" // AuthN user ID of the redirected request (usage accounting)
 
 	QparamDontResilver = "dntres" // true: do not resilver data off of mountpaths that are being disabled/detached
 
@@ -244,7 +263,11 @@ const (
 	WhatTargetIPs  = "target_ips" // comma-separated list of all target IPs (compare w/ GetWhatSnode)
 
 	// log
-	WhatLog = "log"
+	WhatLog   = "log"
+	WhatAudit = "audit" // most recent audit events (see AuditEvent)
+
+	// usage accounting
+	WhatUsage = "usage" // per-user and per-namespace usage (see UsageStats)
 
 	// xactions
 	WhatOneXactStatus   = "status"      // IC status by uuid (returns a single matching xaction or none)
@@ -6,12 +6,17 @@ package apc
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/cmn/cos"
 )
 
 // copy & (offline) transform bucket to bucket
+const MaxDryRunNames = 1000 // per target (see DryRunResult)
+
 type (
 	CopyBckMsg struct {
 		Prepend   string `json:"prepend"`     // destination naming, as in: dest-obj-name = Prepend + source-obj-name
@@ -20,6 +25,29 @@ type (
 		Force     bool   `json:"force"`       // force running in presence of "limited coexistence" type conflicts
 		LatestVer bool   `json:"latest-ver"`  // see also: QparamLatestVer, 'versioning.validate_warm_get', PrefetchMsg
 		Sync      bool   `json:"synchronize"` // see also: 'versioning.synchronize'
+		// optional predicates to further select _source_ objects (see ObjFilter below)
+		Filter *ObjFilter `json:"filter,omitempty"`
+	}
+	// Source object selection filter - all specified conditions must hold.
+	// - time bounds are RFC3339 strings (e.g. "2024-12-31T23:59:59Z") that apply to
+	//   the object's last-modified time when known (remote backends), otherwise to its atime
+	// - custom metadata matches when the object has all the specified key/value pairs
+	// - applies to x-tcb and x-tco, in addition to (and after) prefix and list/range selection
+	// - incompatible with the request to synchronize buckets (the `Sync` option above)
+	ObjFilter struct {
+		Regex     string     `json:"regex,omitempty"`      // object name must match (Go regexp syntax)
+		MinSize   int64      `json:"min-size,omitempty"`   // object size must be >= MinSize (bytes)
+		MaxSize   int64      `json:"max-size,omitempty"`   // object size must be <= MaxSize (bytes)
+		NewerThan string     `json:"newer-than,omitempty"` // RFC3339
+		OlderThan string     `json:"older-than,omitempty"` // ditto
+		CustomMD  cos.StrKVs `json:"custom-md,omitempty"`  // user-defined (custom) metadata to match
+	}
+	// Dry-run result: names of the selected source objects (see CopyBckMsg.DryRun)
+	// - returned by each target as its x-tcb (x-tco) snapshot's extended stats (`core.Snap.Ext`)
+	// - at most MaxDryRunNames per target; Truncated is set when there's more
+	DryRunResult struct {
+		Names     []string `json:"names"`
+		Truncated bool     `json:"truncated,omitempty"`
 	}
 	Transform struct {
 		Name    string       `json:"id,omitempty"`
@@ -79,4 +107,102 @@ func (msg *CopyBckMsg) Str(sb *strings.Builder, fromCname, toCname string) {
 	if msg.Sync {
 		sb.WriteString(", sync")
 	}
+	if msg.Filter != nil {
+		msg.Filter.Str(sb)
+	}
+}
+
+func (msg *CopyBckMsg) Validate() error {
+	if msg.Filter == nil {
+		return nil
+	}
+	if msg.Sync {
+		return errors.New("object selection filter is incompatible with the request to synchronize buckets")
+	}
+	return msg.Filter.Validate()
+}
+
+///////////////
+// ObjFilter //
+///////////////
+
+func (flt *ObjFilter) Validate() (err error) {
+	if flt.Regex != "" {
+		if _, err = regexp.Compile(flt.Regex); err != nil {
+			return fmt.Errorf("invalid object filter regex %q: %v", flt.Regex, err)
+		}
+	}
+	if flt.MinSize < 0 || flt.MaxSize < 0 {
+		return fmt.Errorf("invalid object filter size bounds [%d, %d]", flt.MinSize, flt.MaxSize)
+	}
+	if flt.MaxSize > 0 && flt.MinSize > flt.MaxSize {
+		return fmt.Errorf("invalid object filter: min-size %d is greater than max-size %d", flt.MinSize, flt.MaxSize)
+	}
+	newer, err := flt.parseTime(flt.NewerThan)
+	if err != nil {
+		return err
+	}
+	older, err := flt.parseTime(flt.OlderThan)
+	if err != nil {
+		return err
+	}
+	if !newer.IsZero() && !older.IsZero() && !newer.Before(older) {
+		return fmt.Errorf("invalid object filter: newer-than %q is not before older-than %q", flt.NewerThan, flt.OlderThan)
+	}
+	return nil
+}
+
+// returns zero time when not specified
+func (*ObjFilter) parseTime(s string) (t time.Time, err error) {
+	if s == "" {
+		return
+	}
+	if t, err = time.Parse(time.RFC3339, s); err != nil {
+		err = fmt.Errorf("invalid object filter time %q (expecting RFC3339): %v", s, err)
+	}
+	return
+}
+
+// time bounds (nanoseconds since UNIX epoch; zero when not specified)
+// assumes prior validation
+func (flt *ObjFilter) TimeBounds() (newer, older int64) {
+	if t, _ := flt.parseTime(flt.NewerThan); !t.IsZero() {
+		newer = t.UnixNano()
+	}
+	if t, _ := flt.parseTime(flt.OlderThan); !t.IsZero() {
+		older = t.UnixNano()
+	}
+	return
+}
+
+// whether matching requires object metadata (and not only its name)
+func (flt *ObjFilter) NeedsAttrs() bool {
+	return flt.MinSize > 0 || flt.MaxSize > 0 || flt.NewerThan != "" || flt.OlderThan != "" || len(flt.CustomMD) > 0
+}
+
+func (flt *ObjFilter) Str(sb *strings.Builder) {
+	if flt.Regex != "" {
+		sb.WriteString(", regex:")
+		sb.WriteString(flt.Regex)
+	}
+	if flt.MinSize > 0 {
+		sb.WriteString(", min-size:")
+		sb.WriteString(cos.ToSizeIEC(flt.MinSize, 0))
+	}
+	if flt.MaxSize > 0 {
+		sb.WriteString(", max-size:")
+		sb.WriteString(cos.ToSizeIEC(flt.MaxSize, 0))
+	}
+	if flt.NewerThan != "" {
+		sb.WriteString(", newer-than:")
+		sb.WriteString(flt.NewerThan)
+	}
+	if flt.OlderThan != "" {
+		sb.WriteString(", older-than:")
+		sb.WriteString(flt.OlderThan)
+	}
+	if len(flt.CustomMD) > 0 {
+		sb.WriteString(", custom-md:")
+		sb.WriteString(fmt.Sprint(flt.CustomMD))
+	}
 }
@@ -32,6 +32,11 @@ const (
 	Clusters  = "clusters" // AuthN
 	Roles     = "roles"    // AuthN
 	IC        = "ic"       // information center
+	Batch     = "batch"    // batch GET (multiple objects in a single request)
+	Events    = "events"   // object event notifications (long-poll)
+	RateLim   = "ratelim"  // intra-cluster rate-limit usage exchange
+
+	AccessKeys = "access-keys" // AuthN: S3 credentials
 
 	// l3 ---
 
@@ -63,6 +68,8 @@ const (
 	FinishedAck = "finished_ack"
 	UList       = "list"
 	Remove      = "remove"
+	Resume      = "resume"
+	Checkpoint  = "checkpoint"
 	Next        = "next"
 	Peek        = "peek"
 	Discard     = "discard"
@@ -70,6 +77,10 @@ const (
 
 	LoadX509 = "load-x509"
 
+	// AuthN
+	RotateKeys = "rotate-keys" // (asymmetric signing) generate new signing key
+	OIDC       = "oidc"        // login with OIDC ID token
+
 	// ETL
 	ETL        = "etl"
 	ETLInfo    = "info"
@@ -101,6 +112,9 @@ var (
 
 	URLPathBuckets  = urlpath(Version, Buckets)
 	URLPathObjects  = urlpath(Version, Objects)
+	URLPathBatch    = urlpath(Version, Batch)
+	URLPathEvents   = urlpath(Version, Events)
+	URLPathRateLim  = urlpath(Version, RateLim)
 	URLPathEC       = urlpath(Version, EC)
 	URLPathNotifs   = urlpath(Version, Notifs)
 	URLPathTxn      = urlpath(Version, Txn)
@@ -154,6 +168,8 @@ var (
 	URLPathdSortMetrics = urlpath(Version, Sort, Metrics)
 	URLPathdSortAck     = urlpath(Version, Sort, FinishedAck)
 	URLPathdSortRemove  = urlpath(Version, Sort, Remove)
+	URLPathdSortResume  = urlpath(Version, Sort, Resume)
+	URLPathdSortCkpt    = urlpath(Version, Sort, Checkpoint)
 
 	URLPathDownload       = urlpath(Version, Download)
 	URLPathDownloadAbort  = urlpath(Version, Download, Abort)
@@ -166,6 +182,11 @@ var (
 	URLPathUsers    = urlpath(Version, Users)
 	URLPathClusters = urlpath(Version, Clusters)
 	URLPathRoles    = urlpath(Version, Roles)
+
+	URLPathAccessKeys = urlpath(Version, AccessKeys)
+
+	// AuthN public keys (JWKS, RFC 7517); unauthenticated
+	URLPathJWKS = urlpath(".well-known", "jwks.json")
 )
 
 func (u URLPath) Join(words ...string) string {
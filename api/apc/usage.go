@@ -0,0 +1,36 @@
+// Package apc: API control messages and constants
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package apc
+
+// usage report grouping (QparamUsageBy); empty - by (user, namespace) pairs
+const (
+	UsageByUser      = "user"
+	UsageByNamespace = "namespace"
+)
+
+// GET(what=usage): usage accounting (see feature flag "Usage-Accounting")
+//   - user: AuthN user ID from the request token (empty when authentication is disabled);
+//   - namespace: bucket namespace (empty for the global one);
+//   - stored: bytes written less bytes deleted, an approximation that does not account for
+//     overwrites and objects removed by other means (e.g., eviction, batch jobs).
+type UsageStats struct {
+	User      string `json:"user,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	GetCount  int64  `json:"get_n,string"`
+	GetBytes  int64  `json:"get_size,string"`
+	PutCount  int64  `json:"put_n,string"`
+	PutBytes  int64  `json:"put_size,string"`
+	DelCount  int64  `json:"del_n,string"`
+	Stored    int64  `json:"stored_size,string"`
+}
+
+func (u *UsageStats) Add(other *UsageStats) {
+	u.GetCount += other.GetCount
+	u.GetBytes += other.GetBytes
+	u.PutCount += other.PutCount
+	u.PutBytes += other.PutBytes
+	u.DelCount += other.DelCount
+	u.Stored += other.Stored
+}
@@ -81,6 +81,102 @@ func LoginUser(bp api.BaseParams, userID, pass string, expire *time.Duration) (t
 	return token, nil
 }
 
+// Log in with ID token issued by the (configured) OpenID Connect provider;
+// user's groups are mapped to AuthN roles as per AuthN configuration (see OIDCConf).
+func LoginOIDC(bp api.BaseParams, idToken string, expire *time.Duration) (token *TokenMsg, err error) {
+	bp.Method = http.MethodPost
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathTokens.Join(apc.OIDC)
+		reqParams.Body = cos.MustMarshal(OIDCLoginMsg{IDToken: idToken, ExpiresIn: expire})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	if _, err = reqParams.DoReqAny(&token); err != nil {
+		return nil, err
+	}
+	if token.Token == "" {
+		return nil, errors.New("OIDC login failed: empty response from AuthN server")
+	}
+	return token, nil
+}
+
+// Generate new token-signing key (RS256 and ES256 only); returns the new key ID.
+// Previously issued tokens remain valid until they expire.
+func RotateKeys(bp api.BaseParams) (string, error) {
+	bp.Method = http.MethodPost
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathTokens.Join(apc.RotateKeys)
+	}
+	res := &RotateKeysResult{}
+	if _, err := reqParams.DoReqAny(res); err != nil {
+		return "", err
+	}
+	return res.KID, nil
+}
+
+// Get AuthN public keys (JWKS, RFC 7517) in their raw JSON form.
+func GetJWKS(bp api.BaseParams) ([]byte, error) {
+	bp.Method = http.MethodGet
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathJWKS.S
+	}
+	var jwks jsoniter.RawMessage
+	if _, err := reqParams.DoReqAny(&jwks); err != nil {
+		return nil, err
+	}
+	return jwks, nil
+}
+
+// Issue S3 access key (ID and secret) for a given user; the key carries the user's
+// permissions at the time of creation. Expiration: zero - never; nil - AuthN default.
+func AddAccessKey(bp api.BaseParams, userID string, expire *time.Duration) (key *AccessKey, err error) {
+	bp.Method = http.MethodPost
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathAccessKeys.S
+		reqParams.Body = cos.MustMarshal(AccessKeyMsg{UserID: userID, ExpiresIn: expire})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	if _, err = reqParams.DoReqAny(&key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// List access keys (without secrets): all (admin) or the caller's own.
+func GetAccessKeys(bp api.BaseParams) (keys []*AccessKey, err error) {
+	bp.Method = http.MethodGet
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathAccessKeys.S
+	}
+	_, err = reqParams.DoReqAny(&keys)
+	return keys, err
+}
+
+func DeleteAccessKey(bp api.BaseParams, id string) error {
+	bp.Method = http.MethodDelete
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathAccessKeys.Join(id)
+	}
+	return reqParams.DoRequest()
+}
+
 func RegisterCluster(bp api.BaseParams, cluSpec CluACL) error {
 	msg := cos.MustMarshal(cluSpec)
 	bp.Method = http.MethodPost
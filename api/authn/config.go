@@ -22,6 +22,7 @@ type (
 		Net     NetConf     `json:"net"`
 		Server  ServerConf  `json:"auth"`
 		Timeout TimeoutConf `json:"timeout"`
+		OIDC    OIDCConf    `json:"oidc,omitempty"`
 		// private
 		mu sync.RWMutex `json:"-"`
 	}
@@ -41,6 +42,11 @@ type (
 	ServerConf struct {
 		Secret string       `json:"secret"`
 		Expire cos.Duration `json:"expiration_time"`
+		// token signing algorithm: "HS256" (default, shared secret), "RS256", or "ES256";
+		// the latter two sign with AuthN's private key and publish public keys (JWKS)
+		SigningAlg string `json:"signing_alg,omitempty"`
+		// (asymmetric signing) generate new signing key every so often; zero - never
+		KeyRotation cos.Duration `json:"key_rotation,omitempty"`
 		// private
 		psecret *string       `json:"-"`
 		pexpire *cos.Duration `json:"-"`
@@ -48,17 +54,37 @@ type (
 	TimeoutConf struct {
 		Default cos.Duration `json:"default_timeout"`
 	}
+	// login with ID tokens issued by external OpenID Connect provider
+	OIDCConf struct {
+		// ID token issuer, e.g. "https://accounts.example.com"; empty - OIDC login disabled
+		Issuer string `json:"issuer"`
+		// expected ID token audience (OIDC client ID)
+		ClientID string `json:"client_id"`
+		// issuer's public keys; default: discovered via "<issuer>/.well-known/openid-configuration"
+		JWKSURL string `json:"jwks_url,omitempty"`
+		// claim that identifies the user (default: "sub")
+		UserClaim string `json:"user_claim,omitempty"`
+		// claim that lists user's groups (default: "groups")
+		GroupsClaim string `json:"groups_claim,omitempty"`
+		// group => AuthN role names
+		RoleMapping map[string][]string `json:"role_mapping,omitempty"`
+	}
 	ConfigToUpdate struct {
 		Server *ServerConfToSet `json:"auth"`
+		OIDC   *OIDCConf        `json:"oidc,omitempty"` // (replaces the entire section)
 	}
 	ServerConfToSet struct {
-		Secret *string `json:"secret,omitempty"`
-		Expire *string `json:"expiration_time,omitempty"`
+		Secret      *string `json:"secret,omitempty"`
+		Expire      *string `json:"expiration_time,omitempty"`
+		SigningAlg  *string `json:"signing_alg,omitempty"`
+		KeyRotation *string `json:"key_rotation,omitempty"`
 	}
 	// TokenList is a list of tokens pushed by authn
+	// (and, separately, S3 access keys issued by authn)
 	TokenList struct {
-		Tokens  []string `json:"tokens"`
-		Version int64    `json:"version,string"`
+		Tokens     []string     `json:"tokens"`
+		AccessKeys []*AccessKey `json:"access_keys,omitempty"`
+		Version    int64        `json:"version,string"`
 	}
 )
 
@@ -88,15 +114,47 @@ func (c *Config) Verbose() bool {
 func (c *Config) Secret() string        { return *c.Server.psecret }
 func (c *Config) Expire() time.Duration { return time.Duration(*c.Server.pexpire) }
 
+func (c *Config) SigningAlg() string {
+	c.mu.RLock()
+	alg := c.Server.SigningAlg
+	c.mu.RUnlock()
+	return cos.Left(alg, "HS256")
+}
+
+func (c *Config) KeyRotation() time.Duration {
+	c.mu.RLock()
+	d := c.Server.KeyRotation
+	c.mu.RUnlock()
+	return d.D()
+}
+
+func (c *Config) OIDCConf() OIDCConf {
+	c.mu.RLock()
+	conf := c.OIDC
+	c.mu.RUnlock()
+	conf.UserClaim = cos.Left(conf.UserClaim, "sub")
+	conf.GroupsClaim = cos.Left(conf.GroupsClaim, "groups")
+	return conf
+}
+
 func (c *Config) SetSecret(val *string) {
 	c.Server.Secret = *val
 	c.Server.psecret = val
 }
 
 func (c *Config) ApplyUpdate(cu *ConfigToUpdate) error {
-	if cu.Server == nil {
+	if cu.Server == nil && cu.OIDC == nil {
 		return errors.New("configuration is empty")
 	}
+	if cu.OIDC != nil {
+		if cu.OIDC.Issuer != "" && cu.OIDC.ClientID == "" {
+			return errors.New("OIDC client ID not defined")
+		}
+		c.OIDC = *cu.OIDC
+	}
+	if cu.Server == nil {
+		return nil
+	}
 	if cu.Server.Secret != nil {
 		if *cu.Server.Secret == "" {
 			return errors.New("secret not defined")
@@ -112,5 +170,20 @@ func (c *Config) ApplyUpdate(cu *ConfigToUpdate) error {
 		c.Server.Expire = v
 		c.Server.pexpire = &v
 	}
+	if cu.Server.SigningAlg != nil {
+		switch alg := *cu.Server.SigningAlg; alg {
+		case "", "HS256", "RS256", "ES256":
+			c.Server.SigningAlg = alg
+		default:
+			return fmt.Errorf("unsupported signing algorithm %q", alg)
+		}
+	}
+	if cu.Server.KeyRotation != nil {
+		dur, err := time.ParseDuration(*cu.Server.KeyRotation)
+		if err != nil {
+			return fmt.Errorf("invalid time format %s: %v", *cu.Server.KeyRotation, err)
+		}
+		c.Server.KeyRotation = cos.Duration(dur)
+	}
 	return nil
 }
@@ -39,11 +39,35 @@ type (
 		Token string `json:"token"`
 	}
 
+	// (asymmetric signing) ID of the newly generated signing key
+	RotateKeysResult struct {
+		KID string `json:"kid"`
+	}
+
 	LoginMsg struct {
 		Password  string         `json:"password"`
 		ExpiresIn *time.Duration `json:"expires_in"`
 	}
 
+	// login with OIDC ID token (see OIDCConf)
+	OIDCLoginMsg struct {
+		IDToken   string         `json:"id_token"`
+		ExpiresIn *time.Duration `json:"expires_in"`
+	}
+
+	// S3 credentials (SigV4) issued by AuthN to a given user
+	AccessKey struct {
+		ID      string    `json:"id"`
+		Secret  string    `json:"secret,omitempty"`
+		UserID  string    `json:"user_id"`
+		Token   string    `json:"token,omitempty"` // AIS token (user's permissions) the key maps to
+		Expires time.Time `json:"expires"`
+	}
+	AccessKeyMsg struct {
+		UserID    string         `json:"user_id"`
+		ExpiresIn *time.Duration `json:"expires_in"` // zero: never expires; nil: AuthN default
+	}
+
 	RegisteredClusters struct {
 		Clusters map[string]*CluACL `json:"clusters,omitempty"`
 	}
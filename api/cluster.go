@@ -157,6 +157,45 @@ func GetClusterSysInfo(bp BaseParams) (info apc.ClusterSysInfo, err error) {
 	return
 }
 
+// GetAuditEvents returns (at most `limit`, zero for default) most recent audit events
+// recorded by all gateways, most recent first; see also config "audit" section.
+func GetAuditEvents(bp BaseParams, limit int) (events []*apc.AuditEvent, err error) {
+	q := url.Values{apc.QparamWhat: []string{apc.WhatAudit}}
+	if limit > 0 {
+		q.Set(apc.QparamAuditLimit, strconv.Itoa(limit))
+	}
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = q
+	}
+	_, err = reqParams.DoReqAny(&events)
+	FreeRp(reqParams)
+	return
+}
+
+// GetUsage returns cluster-wide usage (requests, bytes read, written, and stored) aggregated by
+// the primary and grouped by `by` (apc.UsageByUser, apc.UsageByNamespace, or "" - by both);
+// requires "Usage-Accounting" feature flag (see cmn/feat).
+func GetUsage(bp BaseParams, by string) (usage []*apc.UsageStats, err error) {
+	q := url.Values{apc.QparamWhat: []string{apc.WhatUsage}}
+	if by != "" {
+		q.Set(apc.QparamUsageBy, by)
+	}
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = q
+	}
+	_, err = reqParams.DoReqAny(&usage)
+	FreeRp(reqParams)
+	return
+}
+
 func GetRemoteAIS(bp BaseParams) (remais meta.RemAisVec, err error) {
 	bp.Method = http.MethodGet
 	reqParams := AllocRp()
@@ -40,6 +40,21 @@ func AbortDsort(bp BaseParams, managerUUID string) error {
 	return err
 }
 
+// ResumeDsort resumes failed (aborted) dsort job from its last checkpoint
+// (requires the same set of targets)
+func ResumeDsort(bp BaseParams, managerUUID string) error {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathdSortResume.S
+		reqParams.Query = url.Values{apc.QparamUUID: []string{managerUUID}}
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 func RemoveDsort(bp BaseParams, managerUUID string) error {
 	bp.Method = http.MethodDelete
 	reqParams := AllocRp()
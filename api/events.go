@@ -0,0 +1,43 @@
+// Package api provides native Go-based API/SDK over HTTP(S).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// GetEvents long-polls object events for a given bucket's event rule (see cmn.EventsConf):
+// - returns as soon as there are any events or when `wait` expires (in which case the result is empty);
+// - to receive the next events (and acknowledge the current ones), pass back the returned token;
+// - empty token: from the beginning of the (retained) events;
+// - NOTE: bp.Client timeout (if any) must exceed `wait`.
+func GetEvents(bp BaseParams, bck cmn.Bck, ruleID, token string, wait time.Duration) (*cmn.EventsResult, error) {
+	q := bck.NewQuery()
+	q.Set(apc.QparamEventRule, ruleID)
+	if token != "" {
+		q.Set(apc.QparamEventToken, token)
+	}
+	if wait > 0 {
+		q.Set(apc.QparamEventWait, wait.String())
+	}
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathEvents.Join(bck.Name)
+		reqParams.Query = q
+	}
+	res := &cmn.EventsResult{}
+	_, err := reqParams.DoReqAny(res)
+	FreeRp(reqParams)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
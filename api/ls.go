@@ -276,3 +276,25 @@ func (ctx *LsoCounter) mustCall() bool {
 }
 
 func (ctx *LsoCounter) finish() { ctx.done = true }
+
+// SearchObjectsMD returns (sorted) names of the objects that have a given custom metadata
+// key (and, optionally, value) - see apc.MDSearchMsg.
+// The bucket must have "Index-Custom-Metadata" feature enabled (see cmn/feat).
+func SearchObjectsMD(bp BaseParams, bck cmn.Bck, msg *apc.MDSearchMsg) ([]string, error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBuckets.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActSearchMD, Value: msg})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	names := []string{}
+	_, err := reqParams.DoReqAny(&names)
+	FreeRp(reqParams)
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
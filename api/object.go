@@ -55,6 +55,8 @@ type (
 		//   For range formatting, see https://www.rfc-editor.org/rfc/rfc7233#section-2.1
 		// E.g. blob download:
 		// * Header.Set(apc.HdrBlobDownload, "true")
+		// E.g. conditional GET (see also `ObjAttrs.NotModified`):
+		// * Header.Set(cos.HdrIfNoneMatch, etag)
 		Header http.Header
 	}
 
@@ -66,6 +68,7 @@ type (
 	ObjAttrs struct {
 		wrespHeader http.Header
 		n           int64
+		status      int
 	}
 )
 
@@ -92,6 +95,11 @@ type (
 		// - we massively write a new content into a bucket, and/or
 		// - we simply don't care.
 		SkipVC bool
+
+		// optional; e.g., conditional PUT:
+		// * Header.Set(cos.HdrIfNoneMatch, "*") // create only (fail with 412 if exists)
+		// * Header.Set(cos.HdrIfMatch, etag)    // overwrite if unchanged
+		Header http.Header
 	}
 )
 
@@ -132,6 +140,16 @@ type (
 		Object     string
 		Handle     string
 	}
+
+	// PATCH(object): write at offset
+	PatchArgs struct {
+		Reader     cos.ReadOpenCloser
+		BaseParams BaseParams
+		Bck        cmn.Bck
+		Object     string
+		Offset     int64
+		Size       int64
+	}
 )
 
 // GET(object) =========================================================================================
@@ -170,6 +188,11 @@ func (oah *ObjAttrs) RespHeader() http.Header {
 	return oah.wrespHeader
 }
 
+// conditional GET (If-None-Match, If-Modified-Since) - object not transmitted
+func (oah *ObjAttrs) NotModified() bool {
+	return oah.status == http.StatusNotModified
+}
+
 func GetObject(bp BaseParams, bck cmn.Bck, objName string, args *GetArgs) (oah ObjAttrs, err error) {
 	var (
 		wresp     *wrappedResp
@@ -194,7 +217,7 @@ func GetObject(bp BaseParams, bck cmn.Bck, objName string, args *GetArgs) (oah O
 	wresp, err = reqParams.doWriter(w)
 	FreeRp(reqParams)
 	if err == nil {
-		oah.wrespHeader, oah.n = wresp.Header, wresp.n
+		oah.wrespHeader, oah.n, oah.status = wresp.Header, wresp.n, wresp.StatusCode
 	}
 	return oah, err
 }
@@ -221,6 +244,13 @@ func GetObjectWithValidation(bp BaseParams, bck cmn.Bck, objName string, args *G
 	if err != nil {
 		return
 	}
+	if resp.StatusCode == http.StatusNotModified {
+		// (nothing to validate)
+		oah.wrespHeader, oah.status = resp.Header, resp.StatusCode
+		resp.Body.Close()
+		FreeRp(reqParams)
+		return
+	}
 
 	wresp, err = reqParams.readValidate(resp, w)
 	cos.DrainReader(resp.Body)
@@ -252,6 +282,31 @@ func GetObjectReader(bp BaseParams, bck cmn.Bck, objName string, args *GetArgs)
 	return
 }
 
+// GetBatch =============================================================================================
+// Retrieve multiple objects, files archived in (shard) objects, and/or object ranges in a single request.
+// Entries with empty bucket default to `bck`.
+// Writes the response - a single archive (see `msg.Mime`) that contains all the entries in order -
+// into the provided writer; returns the number of bytes written.
+// Missing entries do not fail the request - see cmn.GetBatchPrefixNotFound and cmn.GetBatchPrefixErr.
+
+func GetBatch(bp BaseParams, bck cmn.Bck, msg *cmn.GetBatchMsg, w io.Writer) (int64, error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathBatch.Join(bck.Name)
+		reqParams.Body = cos.MustMarshal(msg)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	wresp, err := reqParams.doWriter(w)
+	FreeRp(reqParams)
+	if err != nil {
+		return 0, err
+	}
+	return wresp.n, nil
+}
+
 // PUT(object) ============================================================================================
 //
 // Uses the specified reader (`args.Reader`) to write a new object (or a new version of the object).
@@ -282,6 +337,11 @@ func (args *PutArgs) put(reqArgs *cmn.HreqArgs) (*http.Request, error) {
 	if args.Size != 0 {
 		req.ContentLength = int64(args.Size) // as per https://tools.ietf.org/html/rfc7230#section-3.3.2
 	}
+	for k, vs := range args.Header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
 	SetAuxHeaders(req, &args.BaseParams)
 	return req, nil
 }
@@ -576,6 +636,85 @@ func RenameObject(bp BaseParams, bck cmn.Bck, oldName, newName string) error {
 	return err
 }
 
+// Patch(object) ===============================================================================
+// overwrites existing ais:// object in place, starting at `args.Offset`, with the content
+// read from `args.Reader`; the offset must not exceed the object's size (writing past the end
+// extends the object). Requires "Allow-Object-Patch" feature (see cmn/feat).
+// See also: CopyRange
+
+func (args *PatchArgs) getBody() (io.ReadCloser, error) { return args.Reader.Open() }
+
+func (args *PatchArgs) _patch(reqArgs *cmn.HreqArgs) (*http.Request, error) {
+	req, err := reqArgs.Req()
+	if err != nil {
+		return nil, newErrCreateHTTPRequest(err)
+	}
+	req.GetBody = args.getBody // (ditto)
+	if args.Size != 0 {
+		req.ContentLength = args.Size
+	}
+	SetAuxHeaders(req, &args.BaseParams)
+	return req, nil
+}
+
+func PatchObject(args *PatchArgs) error {
+	q := make(url.Values, 4)
+	q.Set(apc.QparamPatchOffset, strconv.FormatInt(args.Offset, 10))
+	q = args.Bck.AddToQuery(q)
+
+	reqArgs := cmn.AllocHra()
+	{
+		reqArgs.Method = http.MethodPatch
+		reqArgs.Base = args.BaseParams.URL
+		reqArgs.Path = apc.URLPathObjects.Join(args.Bck.Name, args.Object)
+		reqArgs.Query = q
+		reqArgs.BodyR = args.Reader
+	}
+	_, err := DoWithRetry(args.BaseParams.Client, args._patch, reqArgs) //nolint:bodyclose // it's closed inside
+	cmn.FreeHra(reqArgs)
+	return err
+}
+
+// CopyRange(object) ===========================================================================
+// server-side version of the above: overwrites existing ais:// object `objName` in place,
+// starting at `msg.Offset`, with the specified source - another object, its range, or archived file.
+// See also: cmn.CopyRangeMsg
+
+func CopyRange(bp BaseParams, bck cmn.Bck, objName string, msg *cmn.CopyRangeMsg) error {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, objName)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActCopyRange, Value: msg})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
+// Compose(object) =============================================================================
+// creates (or overwrites) `objName` in the specified bucket by concatenating, in order, the
+// specified sources: objects, their ranges, and/or archived files - in the same or different buckets.
+// Sources with empty bucket default to `bck`. See also: cmn.ComposeMsg
+
+func ComposeObject(bp BaseParams, bck cmn.Bck, objName string, msg *cmn.ComposeMsg) error {
+	bp.Method = http.MethodPost
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathObjects.Join(bck.Name, objName)
+		reqParams.Body = cos.MustMarshal(apc.ActMsg{Action: apc.ActComposeObject, Value: msg})
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+		reqParams.Query = bck.NewQuery()
+	}
+	err := reqParams.DoRequest()
+	FreeRp(reqParams)
+	return err
+}
+
 // Promote =========================================================================================
 // promote POSIX files and/or directories to (become) in-cluster objects.
 
@@ -0,0 +1,219 @@
+// Package authn is authentication server for AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+)
+
+// S3 access keys:
+// - each key (ID, secret) maps to AIS token that carries the user's permissions at the time
+//   the key is created, and expires when the key does;
+// - keys (including secrets and tokens) are pushed to all registered clusters, where AIS
+//   gateways verify SigV4-signed S3 requests and then authorize them with the key's token;
+// - deleting a key revokes its token (and is broadcast as such).
+
+const (
+	accessKeyIDLen     = 20
+	accessKeySecretLen = 30 // bytes => 40 base64 characters
+	accessKeyIDPrefix  = "AIS"
+	accessKeyIDChars   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+func genAccessKey() (id, secret string, err error) {
+	b := make([]byte, accessKeyIDLen-len(accessKeyIDPrefix)+accessKeySecretLen)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", err
+	}
+	idb := []byte(accessKeyIDPrefix)
+	for _, c := range b[:accessKeyIDLen-len(accessKeyIDPrefix)] {
+		idb = append(idb, accessKeyIDChars[int(c)%len(accessKeyIDChars)])
+	}
+	return string(idb), base64.StdEncoding.EncodeToString(b[len(b)-accessKeySecretLen:]), nil
+}
+
+func (m *mgr) addAccessKey(msg *authn.AccessKeyMsg) (*authn.AccessKey, error) {
+	uInfo := &authn.User{}
+	if err := m.db.Get(usersCollection, msg.UserID, uInfo); err != nil {
+		return nil, err
+	}
+	id, secret, err := genAccessKey()
+	if err != nil {
+		return nil, err
+	}
+	expires := time.Now().Add(tokenTTL(msg.ExpiresIn))
+	token, err := m.userToken(uInfo, &authn.LoginMsg{ExpiresIn: msg.ExpiresIn})
+	if err != nil {
+		return nil, err
+	}
+	key := &authn.AccessKey{ID: id, Secret: secret, UserID: uInfo.ID, Token: token, Expires: expires}
+	if err := m.db.Set(accessKeysCollection, id, key); err != nil {
+		return nil, err
+	}
+	go m.broadcastAccessKeys(key)
+	return key, nil
+}
+
+func (m *mgr) delAccessKey(id string) error {
+	key := &authn.AccessKey{}
+	if err := m.db.Get(accessKeysCollection, id, key); err != nil {
+		return err
+	}
+	if err := m.db.Delete(accessKeysCollection, id); err != nil {
+		return err
+	}
+	return m.revokeToken(key.Token)
+}
+
+func (m *mgr) delUserAccessKeys(userID string) {
+	aks, err := m.accessKeys(userID)
+	if err != nil {
+		return
+	}
+	for _, key := range aks {
+		if err := m.delAccessKey(key.ID); err != nil {
+			nlog.Errorf("failed to delete user %q access key %q: %v", userID, key.ID, err)
+		}
+	}
+}
+
+// non-expired access keys (all or a given user's); expired ones get removed
+func (m *mgr) accessKeys(userID string) ([]*authn.AccessKey, error) {
+	recs, err := m.db.GetAll(accessKeysCollection, "")
+	if err != nil && !cos.IsErrNotFound(err) {
+		return nil, err
+	}
+	var (
+		now = time.Now()
+		aks = make([]*authn.AccessKey, 0, len(recs))
+	)
+	for id, s := range recs {
+		key := &authn.AccessKey{}
+		if err := cos.JSON.UnmarshalFromString(s, key); err != nil {
+			nlog.Errorf("access key %q: %v (skipping)", id, err)
+			continue
+		}
+		if key.Expires.Before(now) {
+			m.db.Delete(accessKeysCollection, id)
+			continue
+		}
+		if userID == "" || key.UserID == userID {
+			aks = append(aks, key)
+		}
+	}
+	return aks, nil
+}
+
+//
+// http handlers
+//
+
+func (h *hserv) accessKeyHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.httpAccessKeyPost(w, r)
+	case http.MethodDelete:
+		h.httpAccessKeyDel(w, r)
+	case http.MethodGet:
+		h.httpAccessKeyGet(w, r)
+	default:
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodGet, http.MethodPost)
+	}
+}
+
+// admin or the user in question
+func validateOwnerPerms(w http.ResponseWriter, r *http.Request, userID string) error {
+	tk, err := getToken(r)
+	if err != nil {
+		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
+		return err
+	}
+	if tk.IsAdmin || tk.UserID == userID {
+		return nil
+	}
+	err = fmt.Errorf("not authorized: (%s)", tk)
+	cmn.WriteErr(w, r, err, http.StatusUnauthorized)
+	return err
+}
+
+// POST /v1/access-keys
+func (h *hserv) httpAccessKeyPost(w http.ResponseWriter, r *http.Request) {
+	if _, err := parseURL(w, r, 0, apc.URLPathAccessKeys.L); err != nil {
+		return
+	}
+	msg := &authn.AccessKeyMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	if msg.UserID == "" {
+		cmn.WriteErrMsg(w, r, "missing user ID")
+		return
+	}
+	if err := validateOwnerPerms(w, r, msg.UserID); err != nil {
+		return
+	}
+	key, err := h.mgr.addAccessKey(msg)
+	if err != nil {
+		cmn.WriteErr(w, r, err)
+		return
+	}
+	out := *key
+	out.Token = "" // (internal use)
+	writeJSON(w, &out, "add access key")
+}
+
+// DELETE /v1/access-keys/<id>
+func (h *hserv) httpAccessKeyDel(w http.ResponseWriter, r *http.Request) {
+	apiItems, err := parseURL(w, r, 1, apc.URLPathAccessKeys.L)
+	if err != nil {
+		return
+	}
+	key := &authn.AccessKey{}
+	if err := h.mgr.db.Get(accessKeysCollection, apiItems[0], key); err != nil {
+		cmn.WriteErr(w, r, cos.NewErrNotFound(h.mgr, "access key "+apiItems[0]))
+		return
+	}
+	if err := validateOwnerPerms(w, r, key.UserID); err != nil {
+		return
+	}
+	if err := h.mgr.delAccessKey(key.ID); err != nil {
+		cmn.WriteErr(w, r, err)
+	}
+}
+
+// GET /v1/access-keys (admin: all keys; otherwise, user's own)
+func (h *hserv) httpAccessKeyGet(w http.ResponseWriter, r *http.Request) {
+	if _, err := parseURL(w, r, 0, apc.URLPathAccessKeys.L); err != nil {
+		return
+	}
+	tk, err := getToken(r)
+	if err != nil {
+		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
+		return
+	}
+	var userID string
+	if !tk.IsAdmin {
+		userID = tk.UserID
+	}
+	aks, err := h.mgr.accessKeys(userID)
+	if err != nil {
+		cmn.WriteErr(w, r, err)
+		return
+	}
+	for _, key := range aks {
+		key.Secret, key.Token = "", ""
+	}
+	writeJSON(w, aks, "get access keys")
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/nlog"
@@ -23,10 +24,16 @@ const (
 	retryCount = 4
 	retrySleep = 3 * time.Second
 	retry503   = time.Minute
+
+	clusterTokenTTL = 10 * time.Minute // (covers all retries - see call below)
 )
 
 func (m *mgr) validateSecret(clu *authn.CluACL) (err error) {
 	const tag = "validate-secret"
+	if tok.IsAsymmetric(Conf.SigningAlg()) {
+		// no shared secret: AIS clusters verify tokens with AuthN public keys (JWKS)
+		return nil
+	}
 	var (
 		secret = Conf.Secret()
 		cksum  = cos.NewCksumHash(cos.ChecksumSHA256)
@@ -51,6 +58,12 @@ func (m *mgr) broadcastRevoked(token string) {
 	m.broadcast(http.MethodDelete, apc.Tokens, body, "broadcast-revoked")
 }
 
+// push new access keys to all clusters
+func (m *mgr) broadcastAccessKeys(aks ...*authn.AccessKey) {
+	body := cos.MustMarshal(authn.TokenList{AccessKeys: aks})
+	m.broadcast(http.MethodPut, apc.Tokens, body, "broadcast-access-keys")
+}
+
 // broadcast the request to all clusters. If a cluster has a few URLS,
 // it sends to the first working one. Clusters are processed in parallel.
 func (m *mgr) broadcast(method, path string, body []byte, tag string) {
@@ -86,13 +99,29 @@ func (m *mgr) syncTokenList(clu *authn.CluACL) {
 		nlog.Errorf("failed to sync token list with %q(%q): %v", clu.ID, clu.Alias, err)
 		return
 	}
-	if len(tokenList) == 0 {
+	if len(tokenList) > 0 {
+		body := cos.MustMarshal(authn.TokenList{Tokens: tokenList})
+		m.callAny(clu, http.MethodDelete, body, tag)
+	}
+
+	// S3 access keys
+	aks, err := m.accessKeys("")
+	if err != nil {
+		nlog.Errorf("failed to sync access keys with %q(%q): %v", clu.ID, clu.Alias, err)
 		return
 	}
-	body := cos.MustMarshal(authn.TokenList{Tokens: tokenList})
+	if len(aks) > 0 {
+		body := cos.MustMarshal(authn.TokenList{AccessKeys: aks})
+		m.callAny(clu, http.MethodPut, body, tag)
+	}
+}
+
+// call the first responding cluster URL
+func (m *mgr) callAny(clu *authn.CluACL, method string, body []byte, tag string) {
+	var err error
 	for _, u := range clu.URLs {
-		if err = m.call(http.MethodDelete, u, apc.Tokens, body, tag); err == nil {
-			break
+		if err = m.call(method, u, apc.Tokens, body, tag); err == nil {
+			return
 		}
 		err = fmt.Errorf("failed to %s with %s: %v", tag, clu, err)
 	}
@@ -101,6 +130,16 @@ func (m *mgr) syncTokenList(clu *authn.CluACL) {
 	}
 }
 
+// short-lived admin token: AIS clusters authenticate AuthN requests to update
+// (revoked) token list and S3 access keys (see ais/prxauth.go)
+func (m *mgr) clusterToken() (string, error) {
+	var (
+		ttl   = clusterTokenTTL
+		admin = &authn.User{ID: adminUserID, Roles: []*authn.Role{{Name: authn.AdminRole}}}
+	)
+	return m._token(&authn.LoginMsg{ExpiresIn: &ttl}, admin, nil, nil)
+}
+
 // TODO: reuse api/client.go reqParams.do()
 func (m *mgr) call(method, proxyURL, path string, injson []byte, tag string) error {
 	var (
@@ -114,6 +153,10 @@ func (m *mgr) call(method, proxyURL, path string, injson []byte, tag string) err
 	if cos.IsHTTPS(proxyURL) {
 		client = m.clientTLS
 	}
+	token, err := m.clusterToken()
+	if err != nil {
+		return err
+	}
 	// while cos.IsRetriableConnErr()
 	for i := 1; i <= retries; i++ {
 		req, nerr := http.NewRequest(method, url, bytes.NewBuffer(injson))
@@ -121,6 +164,7 @@ func (m *mgr) call(method, proxyURL, path string, injson []byte, tag string) err
 			return nerr
 		}
 		req.Header.Set(cos.HdrContentType, cos.ContentJSON)
+		req.Header.Set(apc.HdrAuthorization, apc.AuthenticationTypeBearer+" "+token)
 		resp, err := client.Do(req)
 		if resp != nil && resp.Body != nil {
 			var e error
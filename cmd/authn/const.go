@@ -13,10 +13,12 @@ const (
 )
 
 const (
-	usersCollection    = "user"
-	rolesCollection    = "role"
-	revokedCollection  = "revoked"
-	clustersCollection = "cluster"
+	usersCollection      = "user"
+	rolesCollection      = "role"
+	revokedCollection    = "revoked"
+	clustersCollection   = "cluster"
+	keysCollection       = "key"
+	accessKeysCollection = "accesskey"
 
 	adminUserID   = "admin"
 	adminUserPass = "admin"
@@ -111,7 +111,9 @@ func (h *hserv) registerPublicHandlers() {
 	h.registerHandler(apc.URLPathTokens.S, h.tokenHandler)
 	h.registerHandler(apc.URLPathClusters.S, h.clusterHandler)
 	h.registerHandler(apc.URLPathRoles.S, h.roleHandler)
+	h.registerHandler(apc.URLPathAccessKeys.S, h.accessKeyHandler)
 	h.registerHandler(apc.URLPathDae.S, configHandler)
+	h.mux.HandleFunc(apc.URLPathJWKS.S, jwksHandler)
 }
 
 func (h *hserv) userHandler(w http.ResponseWriter, r *http.Request) {
@@ -133,9 +135,58 @@ func (h *hserv) tokenHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodDelete:
 		h.httpRevokeToken(w, r)
+	case http.MethodPost:
+		h.httpTokenPost(w, r)
+	default:
+		cmn.WriteErr405(w, r, http.MethodDelete, http.MethodPost)
+	}
+}
+
+func (h *hserv) httpTokenPost(w http.ResponseWriter, r *http.Request) {
+	apiItems, err := parseURL(w, r, 1, apc.URLPathTokens.L)
+	if err != nil {
+		return
+	}
+	switch apiItems[0] {
+	case apc.RotateKeys:
+		httpRotateKeys(w, r)
+	case apc.OIDC:
+		h.oidcLogin(w, r)
 	default:
-		cmn.WriteErr405(w, r, http.MethodDelete)
+		cmn.WriteErrMsg(w, r, "invalid request: "+r.URL.Path)
+	}
+}
+
+// POST /v1/tokens/oidc
+func (h *hserv) oidcLogin(w http.ResponseWriter, r *http.Request) {
+	msg := &authn.OIDCLoginMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	if msg.IDToken == "" {
+		cmn.WriteErrMsg(w, r, "empty ID token", http.StatusUnauthorized)
+		return
+	}
+	token, err := h.mgr.issueTokenOIDC(msg)
+	if err != nil {
+		nlog.Errorln("OIDC login:", err)
+		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
+		return
+	}
+	writeJSON(w, &authn.TokenMsg{Token: token}, "oidc login")
+}
+
+// POST /v1/tokens/rotate-keys
+func httpRotateKeys(w http.ResponseWriter, r *http.Request) {
+	if err := validateAdminPerms(w, r); err != nil {
+		return
+	}
+	kid, err := keys.rotate()
+	if err != nil {
+		cmn.WriteErr(w, r, err)
+		return
 	}
+	writeJSON(w, &authn.RotateKeysResult{KID: kid}, "rotate keys")
 }
 
 func (h *hserv) clusterHandler(w http.ResponseWriter, r *http.Request) {
@@ -166,8 +217,7 @@ func (h *hserv) httpRevokeToken(w http.ResponseWriter, r *http.Request) {
 		cmn.WriteErrMsg(w, r, "empty token")
 		return
 	}
-	secret := Conf.Secret()
-	if _, err := tok.DecryptToken(msg.Token, secret); err != nil {
+	if _, err := keys.verifier().DecryptToken(msg.Token); err != nil {
 		cmn.WriteErr(w, r, err)
 		return
 	}
@@ -282,8 +332,7 @@ func getToken(r *http.Request) (*tok.Token, error) {
 	if err != nil {
 		return nil, err
 	}
-	secret := Conf.Secret()
-	tk, err := tok.DecryptToken(tokenStr, secret)
+	tk, err := keys.verifier().DecryptToken(tokenStr)
 	if err != nil {
 		return nil, err
 	}
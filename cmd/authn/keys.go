@@ -0,0 +1,240 @@
+// Package authn is authentication server for AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package main
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+)
+
+// Asymmetric (RS256, ES256) signing keys:
+// - persisted in the "key" collection, one record per key ID (kid);
+// - the most recently created non-retired key of the configured algorithm signs new tokens;
+// - upon rotation (scheduled, see Conf.KeyRotation, or manual), the previously active key
+//   is retired but remains published (JWKS) for as long as the tokens it signed may
+//   still be valid (Conf.Expire), and is then removed.
+
+const keyRotationCheck = time.Minute
+
+type (
+	keyRec struct {
+		Alg     string `json:"alg"`
+		PEM     string `json:"pem"`
+		Created int64  `json:"created"`
+		Retired int64  `json:"retired,omitempty"`
+	}
+	keyring struct {
+		db     kvdb.Driver
+		active *tok.SigningKey
+		recs   map[string]*keyRec // kid => key record
+		jwks   *tok.JWKS          // published public keys (do not modify)
+		mu     sync.RWMutex
+	}
+)
+
+var keys = &keyring{}
+
+func (kr *keyring) init(db kvdb.Driver) error {
+	kr.db = db
+	kr.recs = make(map[string]*keyRec, 4)
+	all, err := db.GetAll(keysCollection, "")
+	if err != nil && !cos.IsErrNotFound(err) {
+		return err
+	}
+	for kid, s := range all {
+		rec := &keyRec{}
+		if err := cos.JSON.UnmarshalFromString(s, rec); err != nil {
+			nlog.Errorf("key %q: %v (skipping)", kid, err)
+			continue
+		}
+		kr.recs[kid] = rec
+	}
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	if err := kr._activate(); err != nil {
+		return err
+	}
+	if tok.IsAsymmetric(Conf.SigningAlg()) && kr.active == nil {
+		if err := kr._rotate(); err != nil {
+			return err
+		}
+	}
+	go kr.housekeep()
+	return nil
+}
+
+// signing key of the configured algorithm; nil when the algorithm is HS256
+func (kr *keyring) signingKey() (*tok.SigningKey, error) {
+	alg := Conf.SigningAlg()
+	if !tok.IsAsymmetric(alg) {
+		return nil, nil
+	}
+	kr.mu.RLock()
+	k := kr.active
+	kr.mu.RUnlock()
+	if k != nil && k.Alg == alg {
+		return k, nil
+	}
+
+	// signing algorithm has changed
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	if kr.active == nil || kr.active.Alg != alg {
+		if err := kr._rotate(); err != nil {
+			return nil, err
+		}
+	}
+	return kr.active, nil
+}
+
+func (kr *keyring) publicKeys() *tok.JWKS {
+	kr.mu.RLock()
+	jwks := kr.jwks
+	kr.mu.RUnlock()
+	return jwks
+}
+
+func (kr *keyring) verifier() *tok.Verifier {
+	v := &tok.Verifier{Secret: Conf.Secret()}
+	if jwks := kr.publicKeys(); jwks != nil && len(jwks.Keys) > 0 {
+		v.Keys = jwks // (avoid typed nil)
+	}
+	return v
+}
+
+func (kr *keyring) rotate() (kid string, err error) {
+	if !tok.IsAsymmetric(Conf.SigningAlg()) {
+		return "", errors.New("cannot rotate keys: tokens are signed with a shared secret (HS256)")
+	}
+	kr.mu.Lock()
+	err = kr._rotate()
+	if err == nil {
+		kid = kr.active.KID
+	}
+	kr.mu.Unlock()
+	return kid, err
+}
+
+// must be called under lock
+func (kr *keyring) _rotate() error {
+	var (
+		alg = Conf.SigningAlg()
+		kid = cos.GenUUID()
+	)
+	k, err := tok.NewSigningKey(alg, kid)
+	if err != nil {
+		return err
+	}
+	b, err := k.PEM()
+	if err != nil {
+		return err
+	}
+	now := time.Now().UnixNano()
+	rec := &keyRec{Alg: alg, PEM: string(b), Created: now}
+	if err := kr.db.Set(keysCollection, kid, rec); err != nil {
+		return err
+	}
+	for okid, orec := range kr.recs {
+		if orec.Retired != 0 {
+			continue
+		}
+		orec.Retired = now
+		if err := kr.db.Set(keysCollection, okid, orec); err != nil {
+			nlog.Errorf("failed to retire key %q: %v", okid, err)
+		}
+	}
+	kr.recs[kid] = rec
+	nlog.Infof("new %s signing key %q", alg, kid)
+	return kr._activate()
+}
+
+// (re)load active key and (re)generate the set of public keys
+func (kr *keyring) _activate() error {
+	var (
+		active  *keyRec
+		kid     string
+		jwks    = &tok.JWKS{Keys: make([]tok.JWK, 0, len(kr.recs))}
+		expired = time.Now().Add(-Conf.Expire()).UnixNano()
+	)
+	for k, rec := range kr.recs {
+		if rec.Retired != 0 && Conf.Expire() > 0 && rec.Retired < expired {
+			// tokens signed with this key have all expired
+			if err := kr.db.Delete(keysCollection, k); err != nil {
+				nlog.Errorf("failed to remove key %q: %v", k, err)
+			}
+			delete(kr.recs, k)
+			nlog.Infof("removed retired signing key %q", k)
+			continue
+		}
+		sk, err := tok.ParseSigningKey(rec.Alg, k, []byte(rec.PEM))
+		if err != nil {
+			return err
+		}
+		jwks.Keys = append(jwks.Keys, sk.JWK())
+		if rec.Retired == 0 && (active == nil || rec.Created > active.Created) {
+			active, kid = rec, k
+			kr.active = sk
+		}
+	}
+	if active == nil {
+		kr.active = nil
+	} else {
+		debug.Assert(kr.active.KID == kid)
+	}
+	kr.jwks = jwks
+	return nil
+}
+
+func (kr *keyring) housekeep() {
+	ticker := time.NewTicker(keyRotationCheck)
+	defer ticker.Stop()
+	for range ticker.C {
+		kr.mu.Lock()
+		if interval := Conf.KeyRotation(); interval > 0 && tok.IsAsymmetric(Conf.SigningAlg()) {
+			if rec, ok := kr.recs[kr.activeKID()]; ok && time.Since(time.Unix(0, rec.Created)) >= interval {
+				if err := kr._rotate(); err != nil {
+					nlog.Errorln("failed to rotate signing key:", err)
+				}
+			}
+		}
+		if err := kr._activate(); err != nil { // prune
+			nlog.Errorln(err)
+		}
+		kr.mu.Unlock()
+	}
+}
+
+func (kr *keyring) activeKID() string {
+	if kr.active == nil {
+		return ""
+	}
+	return kr.active.KID
+}
+
+//
+// http handlers
+//
+
+// GET /.well-known/jwks.json (unauthenticated)
+func jwksHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.WriteErr405(w, r, http.MethodGet)
+		return
+	}
+	jwks := keys.publicKeys()
+	if jwks == nil {
+		jwks = &tok.JWKS{Keys: []tok.JWK{}}
+	}
+	writeJSON(w, jwks, "get jwks")
+}
@@ -90,6 +90,9 @@ func main() {
 	if err != nil {
 		cos.ExitLogf("Failed to init manager: %v", err)
 	}
+	if err := keys.init(driver); err != nil {
+		cos.ExitLogf("Failed to init signing keys: %v", err)
+	}
 
 	nlog.Infof("Version %s (build %s)\n", cmn.VersionAuthN+"."+build, buildtime)
 
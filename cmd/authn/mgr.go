@@ -29,6 +29,7 @@ type mgr struct {
 	clientH   *http.Client
 	clientTLS *http.Client
 	db        kvdb.Driver
+	oidc      *oidcKeys
 }
 
 var (
@@ -51,6 +52,7 @@ func newMgr(driver kvdb.Driver) (m *mgr, err error) {
 		db: driver,
 	}
 	m.clientH, m.clientTLS = cmn.NewDefaultClients(time.Duration(Conf.Timeout.Default))
+	m.oidc = newOIDCKeys(m)
 	err = initializeDB(driver)
 	return
 }
@@ -81,7 +83,11 @@ func (m *mgr) delUser(userID string) error {
 	if userID == adminUserID {
 		return fmt.Errorf("cannot remove built-in %q account", adminUserID)
 	}
-	return m.db.Delete(usersCollection, userID)
+	if err := m.db.Delete(usersCollection, userID); err != nil {
+		return err
+	}
+	m.delUserAccessKeys(userID)
+	return nil
 }
 
 // Updates an existing user. The function invalidates user tokens after
@@ -350,12 +356,7 @@ func (m *mgr) delCluster(cluID string) error {
 // Token includes user ID, permissions, and token expiration time.
 // If a new token was generated then it sends the proxy a new valid token list
 func (m *mgr) issueToken(uid, pwd string, msg *authn.LoginMsg) (token string, err error) {
-	var (
-		uInfo   = &authn.User{}
-		cid     string
-		cluACLs []*authn.CluACL
-		bckACLs []*authn.BckACL
-	)
+	uInfo := &authn.User{}
 	err = m.db.Get(usersCollection, uid, uInfo)
 	if err != nil {
 		nlog.Errorln(err)
@@ -367,7 +368,15 @@ func (m *mgr) issueToken(uid, pwd string, msg *authn.LoginMsg) (token string, er
 	if !isSamePassword(pwd, uInfo.Password) {
 		return "", errInvalidCredentials
 	}
+	return m.userToken(uInfo, msg)
+}
 
+func (m *mgr) userToken(uInfo *authn.User, msg *authn.LoginMsg) (string, error) {
+	var (
+		cid     string
+		cluACLs []*authn.CluACL
+		bckACLs []*authn.BckACL
+	)
 	// update ACLs with roles' ones
 	for _, role := range uInfo.Roles {
 		cluACLs = mergeClusterACLs(cluACLs, role.ClusterACLs, cid)
@@ -375,27 +384,41 @@ func (m *mgr) issueToken(uid, pwd string, msg *authn.LoginMsg) (token string, er
 	}
 
 	// generate token
-	token, err = m._token(msg, uInfo, cluACLs, bckACLs)
-	return token, err
+	return m._token(msg, uInfo, cluACLs, bckACLs)
 }
 
-func (m *mgr) _token(msg *authn.LoginMsg, uInfo *authn.User, cluACLs []*authn.CluACL, bckACLs []*authn.BckACL) (token string, err error) {
+func tokenTTL(expiresIn *time.Duration) time.Duration {
 	expDelta := Conf.Expire()
-	if msg.ExpiresIn != nil {
-		expDelta = *msg.ExpiresIn
+	if expiresIn != nil {
+		expDelta = *expiresIn
 	}
 	if expDelta == 0 {
 		expDelta = foreverTokenTime
 	}
+	return expDelta
+}
+
+func (m *mgr) _token(msg *authn.LoginMsg, uInfo *authn.User, cluACLs []*authn.CluACL, bckACLs []*authn.BckACL) (token string, err error) {
+	expDelta := tokenTTL(msg.ExpiresIn)
 
 	// put all useful info into token: who owns the token, when it was issued,
 	// when it expires and credentials to log in AWS, GCP etc.
 	// If a user is a super user, it is enough to pass only isAdmin marker
 	expires := time.Now().Add(expDelta)
 	uid := uInfo.ID
-	if uInfo.IsAdmin() {
+	sk, err := keys.signingKey()
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case uInfo.IsAdmin() && sk != nil:
+		token, err = sk.AdminJWT(expires, uid)
+	case uInfo.IsAdmin():
 		token, err = tok.AdminJWT(expires, uid, Conf.Secret())
-	} else {
+	case sk != nil:
+		m.fixClusterIDs(cluACLs)
+		token, err = sk.JWT(expires, uid, bckACLs, cluACLs)
+	default:
 		m.fixClusterIDs(cluACLs)
 		token, err = tok.JWT(expires, uid, bckACLs, cluACLs, Conf.Secret())
 	}
@@ -446,9 +469,9 @@ func (m *mgr) generateRevokedTokenList() ([]string, error) {
 
 	now := time.Now()
 	revokeList := make([]string, 0, len(tokens))
-	secret := Conf.Secret()
+	verifier := keys.verifier()
 	for _, token := range tokens {
-		tk, err := tok.DecryptToken(token, secret)
+		tk, err := verifier.DecryptToken(token)
 		if err != nil {
 			m.db.Delete(revokedCollection, token)
 			continue
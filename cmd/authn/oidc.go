@@ -0,0 +1,184 @@
+// Package authn is authentication server for AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// OIDC login: given ID token issued by external OpenID Connect provider (see authn.OIDCConf):
+// - verify the token's signature with the issuer's public keys (JWKS);
+// - check issuer, audience (client ID), and expiration;
+// - map the user's groups to AuthN roles (OIDCConf.RoleMapping) and issue AIS token.
+// OIDC users are not stored in AuthN database and must not collide with existing (local) users.
+
+const oidcDiscoveryPath = "/.well-known/openid-configuration"
+
+type (
+	// (subset of) OpenID provider metadata
+	oidcDiscovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	// issuer's public keys (cached and refetched upon unknown key ID - see tok.JWKSCache);
+	// dropped when the configured issuer changes
+	oidcKeys struct {
+		m *mgr
+		tok.JWKSCache
+		issuer string // cached keys' (guarded by mu)
+		mu     sync.Mutex
+	}
+)
+
+// interface guard
+var _ tok.KeySource = (*oidcKeys)(nil)
+
+func (m *mgr) issueTokenOIDC(msg *authn.OIDCLoginMsg) (string, error) {
+	conf := Conf.OIDCConf()
+	if conf.Issuer == "" {
+		return "", errors.New("OIDC login is not configured")
+	}
+	v := &tok.Verifier{Keys: m.oidc} // (no secret: ID tokens must be signed with issuer's private key)
+	claims, err := v.Claims(msg.IDToken)
+	if err != nil {
+		return "", fmt.Errorf("invalid ID token: %v", err)
+	}
+	if !claims.VerifyIssuer(conf.Issuer, true) {
+		return "", fmt.Errorf("invalid ID token: issuer %v (expecting %q)", claims["iss"], conf.Issuer)
+	}
+	if !claims.VerifyAudience(conf.ClientID, true) {
+		return "", fmt.Errorf("invalid ID token: audience %v (expecting %q)", claims["aud"], conf.ClientID)
+	}
+	// (when present, `iat` and `nbf` are validated by the parser; `exp` is required)
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true /*required*/) {
+		return "", fmt.Errorf("invalid ID token: missing or expired 'exp' (%v)", claims["exp"])
+	}
+	uid, _ := claims[conf.UserClaim].(string)
+	if uid == "" {
+		return "", fmt.Errorf("invalid ID token: missing %q claim", conf.UserClaim)
+	}
+	if _, err := m.db.GetString(usersCollection, uid); err == nil {
+		return "", fmt.Errorf("OIDC user %q conflicts with existing AuthN user", uid)
+	}
+
+	// map groups to roles
+	var (
+		roles  []*authn.Role
+		seen   = cos.NewStrSet()
+		groups = oidcGroups(claims[conf.GroupsClaim])
+	)
+	for _, group := range groups {
+		for _, name := range conf.RoleMapping[group] {
+			if seen.Contains(name) {
+				continue
+			}
+			seen.Add(name)
+			role := &authn.Role{}
+			if err := m.db.Get(rolesCollection, name, role); err != nil {
+				nlog.Errorf("OIDC group %q maps to unknown role %q: %v", group, name, err)
+				continue
+			}
+			roles = append(roles, role)
+		}
+	}
+	if len(roles) == 0 {
+		return "", fmt.Errorf("OIDC user %q: none of the groups %v maps to AuthN role", uid, groups)
+	}
+
+	uInfo := &authn.User{ID: uid, Roles: roles}
+	return m.userToken(uInfo, &authn.LoginMsg{ExpiresIn: msg.ExpiresIn})
+}
+
+// groups claim: either a list or a single (space or comma-separated) string
+func oidcGroups(v any) (groups []string) {
+	switch v := v.(type) {
+	case []any:
+		for _, g := range v {
+			if s, ok := g.(string); ok && s != "" {
+				groups = append(groups, s)
+			}
+		}
+	case string:
+		groups = strings.FieldsFunc(v, func(r rune) bool { return r == ' ' || r == ',' })
+	}
+	return groups
+}
+
+//////////////
+// oidcKeys //
+//////////////
+
+func newOIDCKeys(m *mgr) *oidcKeys {
+	ks := &oidcKeys{m: m}
+	ks.Fetch = ks.fetch
+	return ks
+}
+
+func (ks *oidcKeys) PublicKey(kid string) (*tok.JWK, error) {
+	issuer := Conf.OIDCConf().Issuer
+	ks.mu.Lock()
+	if ks.issuer != issuer {
+		ks.issuer = issuer
+		ks.Reset()
+	}
+	ks.mu.Unlock()
+	return ks.JWKSCache.PublicKey(kid)
+}
+
+func (ks *oidcKeys) fetch() (*tok.JWKS, error) {
+	var (
+		conf = Conf.OIDCConf()
+		u    = conf.JWKSURL
+	)
+	if u == "" {
+		disc := &oidcDiscovery{}
+		if err := ks.get(strings.TrimSuffix(conf.Issuer, "/")+oidcDiscoveryPath, disc); err != nil {
+			return nil, err
+		}
+		if disc.Issuer != conf.Issuer {
+			return nil, fmt.Errorf("OIDC discovery: issuer %q vs configured %q", disc.Issuer, conf.Issuer)
+		}
+		if disc.JWKSURI == "" {
+			return nil, fmt.Errorf("OIDC discovery: issuer %q does not publish jwks_uri", conf.Issuer)
+		}
+		u = disc.JWKSURI
+	}
+	jwks := &tok.JWKS{}
+	if err := ks.get(u, jwks); err != nil {
+		return nil, err
+	}
+	return jwks, nil
+}
+
+func (ks *oidcKeys) get(u string, out any) error {
+	client := ks.m.clientH
+	if cos.IsHTTPS(u) {
+		client = ks.m.clientTLS
+	}
+	req, err := http.NewRequest(http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+	return jsoniter.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,117 @@
+// Package tok provides AuthN token (structure and methods)
+// for validation by AIS gateways
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tok
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+const (
+	DefaultRefetchIval = 10 * time.Second
+	unknownTTL         = time.Minute
+	maxUnknown         = 1024
+)
+
+type (
+	// public keys (JWKS) obtained via Fetch and cached; refetched upon encountering
+	// an unknown key ID (the keys may have been rotated), but:
+	// - at most once per RefetchIval (including when nothing's cached yet),
+	// - not for the key IDs that were not found within the last minute, and
+	// - without blocking callers that present known keys
+	JWKSCache struct {
+		Fetch       func() (*JWKS, error)
+		jwks        *JWKS
+		unknown     map[string]int64 // negative cache: unknown key ID => mono.NanoTime
+		RefetchIval time.Duration    // zero means DefaultRefetchIval
+		last        int64            // mono.NanoTime of the last fetch
+		gen         int64            // incremented by Reset
+		mu          sync.Mutex
+		fetching    bool
+	}
+)
+
+// interface guard
+var _ KeySource = (*JWKSCache)(nil)
+
+func (c *JWKSCache) PublicKey(kid string) (*JWK, error) {
+	c.mu.Lock()
+	if c.jwks != nil {
+		if jwk, err := c.jwks.PublicKey(kid); err == nil {
+			c.mu.Unlock()
+			return jwk, nil
+		}
+	}
+	ival := c.RefetchIval
+	if ival == 0 {
+		ival = DefaultRefetchIval
+	}
+	now := mono.NanoTime()
+	if c.fetching || (c.last != 0 && time.Duration(now-c.last) < ival) || c.isUnknown(kid, now) {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, kid)
+	}
+	c.fetching, c.last = true, now
+	gen := c.gen
+	c.mu.Unlock()
+
+	// (only one fetching at a time)
+	jwks, err := c.Fetch()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fetching = false
+	if err != nil {
+		return nil, err
+	}
+	if c.gen != gen {
+		return nil, fmt.Errorf("%w %q (key source changed)", ErrUnknownKey, kid)
+	}
+	c.jwks = jwks
+	clear(c.unknown)
+	jwk, err := jwks.PublicKey(kid)
+	if err != nil {
+		c.addUnknown(kid, now)
+	}
+	return jwk, err
+}
+
+// drop cached keys, e.g. when the (configured) source of keys changes;
+// keys being fetched at the time of the call won't be cached
+func (c *JWKSCache) Reset() {
+	c.mu.Lock()
+	c.jwks, c.last = nil, 0
+	clear(c.unknown)
+	c.gen++
+	c.mu.Unlock()
+}
+
+// is called under lock
+func (c *JWKSCache) isUnknown(kid string, now int64) bool {
+	added, ok := c.unknown[kid]
+	if !ok {
+		return false
+	}
+	if time.Duration(now-added) < unknownTTL {
+		return true
+	}
+	delete(c.unknown, kid)
+	return false
+}
+
+// is called under lock
+func (c *JWKSCache) addUnknown(kid string, now int64) {
+	if c.unknown == nil {
+		c.unknown = make(map[string]int64, 16)
+	}
+	if len(c.unknown) >= maxUnknown {
+		clear(c.unknown)
+	}
+	c.unknown[kid] = now
+}
@@ -0,0 +1,300 @@
+// Package tok provides AuthN token (structure and methods)
+// for validation by AIS gateways
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tok
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Asymmetric signing: AuthN signs tokens with its (current) private key and publishes
+// the corresponding public keys (JWKS, RFC 7517); AIS gateways then verify tokens
+// using the key identified by the token's "kid" header.
+
+const (
+	AlgHS256 = "HS256" // shared secret (default)
+	AlgRS256 = "RS256"
+	AlgES256 = "ES256"
+)
+
+const rsaKeyBits = 2048
+
+const pemType = "PRIVATE KEY"
+
+type (
+	// AuthN's private key
+	SigningKey struct {
+		priv crypto.Signer
+		KID  string
+		Alg  string
+	}
+
+	// public key in JWK format
+	JWK struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use,omitempty"`
+		Alg string `json:"alg"`
+		// RSA
+		N string `json:"n,omitempty"`
+		E string `json:"e,omitempty"`
+		// EC
+		Crv string `json:"crv,omitempty"`
+		X   string `json:"x,omitempty"`
+		Y   string `json:"y,omitempty"`
+	}
+
+	// a set of public keys (JWKS)
+	JWKS struct {
+		Keys []JWK `json:"keys"`
+	}
+
+	// source of public keys to verify tokens, e.g. JWKS
+	KeySource interface {
+		PublicKey(kid string) (*JWK, error)
+	}
+
+	// verifies tokens signed either with a shared secret (HS256)
+	// or with one of the keys from the key source (RS256, ES256)
+	Verifier struct {
+		Keys   KeySource
+		Secret string
+	}
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// interface guard
+var _ KeySource = (*JWKS)(nil)
+
+func IsAsymmetric(alg string) bool { return alg == AlgRS256 || alg == AlgES256 }
+
+func ValidateAlg(alg string) error {
+	switch alg {
+	case "", AlgHS256, AlgRS256, AlgES256:
+		return nil
+	default:
+		return fmt.Errorf("unsupported signing algorithm %q (expecting one of: %s, %s, %s)", alg, AlgHS256, AlgRS256, AlgES256)
+	}
+}
+
+////////////////
+// SigningKey //
+////////////////
+
+func NewSigningKey(alg, kid string) (k *SigningKey, err error) {
+	k = &SigningKey{KID: kid, Alg: alg}
+	switch alg {
+	case AlgRS256:
+		k.priv, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case AlgES256:
+		k.priv, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		err = fmt.Errorf("cannot generate %q key: expecting %s or %s", alg, AlgRS256, AlgES256)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// (PKCS #8, PEM-encoded)
+func ParseSigningKey(alg, kid string, b []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil || block.Type != pemType {
+		return nil, fmt.Errorf("key %q: invalid PEM", kid)
+	}
+	priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("key %q: %v", kid, err)
+	}
+	k := &SigningKey{KID: kid, Alg: alg}
+	switch priv := priv.(type) {
+	case *rsa.PrivateKey:
+		if alg != AlgRS256 {
+			return nil, fmt.Errorf("key %q: RSA key vs %q", kid, alg)
+		}
+		k.priv = priv
+	case *ecdsa.PrivateKey:
+		if alg != AlgES256 {
+			return nil, fmt.Errorf("key %q: EC key vs %q", kid, alg)
+		}
+		k.priv = priv
+	default:
+		return nil, fmt.Errorf("key %q: unsupported key type %T", kid, priv)
+	}
+	return k, nil
+}
+
+func (k *SigningKey) PEM() ([]byte, error) {
+	b, err := x509.MarshalPKCS8PrivateKey(k.priv)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: b}), nil
+}
+
+func (k *SigningKey) method() jwt.SigningMethod {
+	if k.Alg == AlgES256 {
+		return jwt.SigningMethodES256
+	}
+	return jwt.SigningMethodRS256
+}
+
+// sign arbitrary claims
+func (k *SigningKey) Sign(claims jwt.MapClaims) (string, error) {
+	t := jwt.NewWithClaims(k.method(), claims)
+	t.Header["kid"] = k.KID
+	return t.SignedString(k.priv)
+}
+
+func (k *SigningKey) AdminJWT(expires time.Time, userID string) (string, error) {
+	return k.Sign(adminClaims(expires, userID))
+}
+
+func (k *SigningKey) JWT(expires time.Time, userID string, bucketACLs []*authn.BckACL, clusterACLs []*authn.CluACL) (string, error) {
+	return k.Sign(userClaims(expires, userID, bucketACLs, clusterACLs))
+}
+
+func (k *SigningKey) JWK() (jwk JWK) {
+	jwk = JWK{Kid: k.KID, Alg: k.Alg, Use: "sig"}
+	switch pub := k.priv.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = b64(pub.N.Bytes())
+		jwk.E = b64(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = pub.Curve.Params().Name
+		jwk.X = b64(pub.X.FillBytes(make([]byte, size)))
+		jwk.Y = b64(pub.Y.FillBytes(make([]byte, size)))
+	}
+	return jwk
+}
+
+/////////
+// JWK //
+/////////
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func b64int(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (jwk *JWK) key() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := b64int(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := b64int(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if jwk.Crv != elliptic.P256().Params().Name {
+			return nil, fmt.Errorf("key %q: unsupported curve %q", jwk.Kid, jwk.Crv)
+		}
+		x, err := b64int(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := b64int(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("key %q: unsupported key type %q", jwk.Kid, jwk.Kty)
+	}
+}
+
+//////////
+// JWKS //
+//////////
+
+func (jwks *JWKS) PublicKey(kid string) (*JWK, error) {
+	for i := range jwks.Keys {
+		if jwks.Keys[i].Kid == kid {
+			return &jwks.Keys[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownKey, kid)
+}
+
+//////////////
+// Verifier //
+//////////////
+
+func (v *Verifier) keyfunc(t *jwt.Token) (any, error) {
+	switch t.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if v.Secret == "" {
+			return nil, fmt.Errorf("unexpected signing method: %v (no secret)", t.Header["alg"])
+		}
+		return []byte(v.Secret), nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		if v.Keys == nil {
+			return nil, fmt.Errorf("unexpected signing method: %v (no public keys)", t.Header["alg"])
+		}
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" {
+			return nil, errors.New("missing key ID (kid)")
+		}
+		jwk, err := v.Keys.PublicKey(kid)
+		if err != nil {
+			return nil, err
+		}
+		if jwk.Alg != "" && jwk.Alg != t.Method.Alg() { // ("alg" is optional)
+			return nil, fmt.Errorf("key %q: signing method %v vs %q", kid, t.Header["alg"], jwk.Alg)
+		}
+		return jwk.key()
+	default:
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+}
+
+func (v *Verifier) DecryptToken(tokenStr string) (*Token, error) {
+	jwtToken, err := jwt.Parse(tokenStr, v.keyfunc)
+	if err != nil {
+		return nil, err
+	}
+	return fromClaims(jwtToken)
+}
+
+// verify and return the claims as they are (e.g., OIDC ID token)
+func (v *Verifier) Claims(tokenStr string) (jwt.MapClaims, error) {
+	jwtToken, err := jwt.Parse(tokenStr, v.keyfunc)
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := jwtToken.Claims.(jwt.MapClaims)
+	if !ok || !jwtToken.Valid {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
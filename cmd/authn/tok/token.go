@@ -39,23 +39,33 @@ var (
 
 // TODO: cos.Unsafe* and other micro-optimization and refactoring
 
-func AdminJWT(expires time.Time, userID, secret string) (string, error) {
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+func adminClaims(expires time.Time, userID string) jwt.MapClaims {
+	return jwt.MapClaims{
 		"expires":  expires,
 		"username": userID,
 		"admin":    true,
-	})
-	return t.SignedString([]byte(secret))
+	}
 }
 
-func JWT(expires time.Time, userID string, bucketACLs []*authn.BckACL, clusterACLs []*authn.CluACL,
-	secret string) (string, error) {
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+func userClaims(expires time.Time, userID string, bucketACLs []*authn.BckACL, clusterACLs []*authn.CluACL) jwt.MapClaims {
+	return jwt.MapClaims{
 		"expires":  expires,
 		"username": userID,
 		"buckets":  bucketACLs,
 		"clusters": clusterACLs,
-	})
+	}
+}
+
+// HS256 (shared secret); see also SigningKey (RS256, ES256)
+
+func AdminJWT(expires time.Time, userID, secret string) (string, error) {
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, adminClaims(expires, userID))
+	return t.SignedString([]byte(secret))
+}
+
+func JWT(expires time.Time, userID string, bucketACLs []*authn.BckACL, clusterACLs []*authn.CluACL,
+	secret string) (string, error) {
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, userClaims(expires, userID, bucketACLs, clusterACLs))
 	return t.SignedString([]byte(secret))
 }
 
@@ -72,6 +82,7 @@ func ExtractToken(hdr http.Header) (string, error) {
 	return s[idx+1:], nil
 }
 
+// HS256 only; see also Verifier
 func DecryptToken(tokenStr, secret string) (*Token, error) {
 	jwtToken, err := jwt.Parse(tokenStr, func(t *jwt.Token) (any, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -82,6 +93,10 @@ func DecryptToken(tokenStr, secret string) (*Token, error) {
 	if err != nil {
 		return nil, err
 	}
+	return fromClaims(jwtToken)
+}
+
+func fromClaims(jwtToken *jwt.Token) (*Token, error) {
 	claims, ok := jwtToken.Claims.(jwt.MapClaims)
 	if !ok || !jwtToken.Valid {
 		return nil, ErrInvalidToken
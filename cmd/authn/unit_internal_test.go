@@ -9,6 +9,11 @@ package main
 // NOTE go:build debug (above) =====================================
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -19,6 +24,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/core/mock"
 	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/golang-jwt/jwt/v4"
 )
 
 var (
@@ -637,3 +643,189 @@ func TestMergeBckACLS(t *testing.T) {
 		}
 	}
 }
+
+func TestSigningKeys(t *testing.T) {
+	defer func() { Conf.Server.SigningAlg = "" }()
+	for _, alg := range []string{tok.AlgRS256, tok.AlgES256} {
+		t.Run(alg, func(t *testing.T) {
+			Conf.Server.SigningAlg = alg
+			driver := mock.NewDBDriver()
+			mgr, err := newMgr(driver)
+			tassert.CheckFatal(t, err)
+			keys = &keyring{}
+			tassert.CheckFatal(t, keys.init(driver))
+			createUsers(mgr, t)
+			defer deleteUsers(mgr, false, t)
+
+			token, err := mgr.issueToken(users[0], passs[0], &authn.LoginMsg{})
+			tassert.CheckFatal(t, err)
+
+			// not verifiable with the secret alone
+			_, err = tok.DecryptToken(token, Conf.Secret())
+			tassert.Errorf(t, err != nil, "%s token must not verify with HS256 secret", alg)
+
+			// public keys (JWKS) roundtrip
+			var jwks tok.JWKS
+			tassert.CheckFatal(t, cos.JSON.Unmarshal(cos.MustMarshal(keys.publicKeys()), &jwks))
+			tassert.Fatalf(t, len(jwks.Keys) == 1, "expected a single public key, got %d", len(jwks.Keys))
+			tk, err := (&tok.Verifier{Keys: &jwks}).DecryptToken(token)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, tk.UserID == users[0], "expected user %q, got %q", users[0], tk.UserID)
+
+			// rotation: previously issued tokens remain valid
+			kid, err := keys.rotate()
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, kid != jwks.Keys[0].Kid, "expected new key ID")
+			_, err = keys.verifier().DecryptToken(token)
+			tassert.CheckError(t, err)
+			_, err = (&tok.Verifier{Keys: &jwks}).DecryptToken(token)
+			tassert.CheckError(t, err)
+
+			newToken, err := mgr.issueToken(users[0], passs[0], &authn.LoginMsg{})
+			tassert.CheckFatal(t, err)
+			_, err = (&tok.Verifier{Keys: &jwks}).DecryptToken(newToken)
+			tassert.Errorf(t, err != nil, "token signed with the new key %q must not verify with the old JWKS", kid)
+			_, err = keys.verifier().DecryptToken(newToken)
+			tassert.CheckError(t, err)
+		})
+	}
+}
+
+// mock OIDC issuer: discovery + JWKS (counting JWKS fetches)
+func newMockIssuer(t *testing.T, sk *tok.SigningKey, fetches *atomic.Int32) *httptest.Server {
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	mux.HandleFunc(oidcDiscoveryPath, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, &oidcDiscovery{Issuer: srv.URL, JWKSURI: srv.URL + "/keys"}, "discovery")
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		writeJSON(w, &tok.JWKS{Keys: []tok.JWK{sk.JWK()}}, "jwks")
+	})
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOIDCLogin(t *testing.T) {
+	const clientID = "ais"
+	sk, err := tok.NewSigningKey(tok.AlgRS256, "mock-kid")
+	tassert.CheckFatal(t, err)
+	var fetches atomic.Int32
+	srv := newMockIssuer(t, sk, &fetches)
+
+	driver := mock.NewDBDriver()
+	mgr, err := newMgr(driver)
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, mgr.addRole(guestRole))
+
+	Conf.OIDC = authn.OIDCConf{
+		Issuer:      srv.URL,
+		ClientID:    clientID,
+		RoleMapping: map[string][]string{"readers": {GuestRole}},
+	}
+	defer func() { Conf.OIDC = authn.OIDCConf{} }()
+
+	claims := func(sub, aud string, groups ...string) jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":    srv.URL,
+			"aud":    aud,
+			"sub":    sub,
+			"exp":    time.Now().Add(time.Minute).Unix(),
+			"groups": groups,
+		}
+	}
+	sign := func(c jwt.MapClaims) string {
+		s, err := sk.Sign(c)
+		tassert.CheckFatal(t, err)
+		return s
+	}
+	idToken := func(sub, aud string, groups ...string) string { return sign(claims(sub, aud, groups...)) }
+
+	token, err := mgr.issueTokenOIDC(&authn.OIDCLoginMsg{IDToken: idToken("alice", clientID, "readers", "others")})
+	tassert.CheckFatal(t, err)
+	tk, err := tok.DecryptToken(token, Conf.Secret())
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tk.UserID == "alice", "expected user alice, got %q", tk.UserID)
+	tassert.Errorf(t, !tk.IsAdmin, "must not be admin")
+	tassert.Errorf(t, len(tk.ClusterACLs) == 1 && tk.ClusterACLs[0].Access == apc.AccessRO,
+		"expected read-only cluster access, got %+v", tk.ClusterACLs)
+
+	// wrong audience, unmapped groups, collision with local user
+	_, err = mgr.issueTokenOIDC(&authn.OIDCLoginMsg{IDToken: idToken("alice", "other", "readers")})
+	tassert.Errorf(t, err != nil, "expected audience error")
+	_, err = mgr.issueTokenOIDC(&authn.OIDCLoginMsg{IDToken: idToken("bob", clientID, "others")})
+	tassert.Errorf(t, err != nil, "expected no-roles error")
+	_, err = mgr.issueTokenOIDC(&authn.OIDCLoginMsg{IDToken: idToken(adminUserID, clientID, "readers")})
+	tassert.Errorf(t, err != nil, "expected collision with local user %q", adminUserID)
+
+	// signed by unknown key
+	other, err := tok.NewSigningKey(tok.AlgES256, "other-kid")
+	tassert.CheckFatal(t, err)
+	forged, err := other.Sign(jwt.MapClaims{"iss": srv.URL, "aud": clientID, "sub": "eve", "groups": []string{"readers"}})
+	tassert.CheckFatal(t, err)
+	_, err = mgr.issueTokenOIDC(&authn.OIDCLoginMsg{IDToken: forged})
+	tassert.Errorf(t, err != nil, "expected unknown key error")
+
+	// missing, expired, and not-yet-valid (`exp`, `nbf`, `iat`)
+	c := claims("carol", clientID, "readers")
+	delete(c, "exp")
+	_, err = mgr.issueTokenOIDC(&authn.OIDCLoginMsg{IDToken: sign(c)})
+	tassert.Errorf(t, err != nil, "expected error: missing 'exp'")
+	c = claims("carol", clientID, "readers")
+	c["exp"] = time.Now().Add(-time.Minute).Unix()
+	_, err = mgr.issueTokenOIDC(&authn.OIDCLoginMsg{IDToken: sign(c)})
+	tassert.Errorf(t, err != nil, "expected error: expired")
+	c = claims("carol", clientID, "readers")
+	c["nbf"] = time.Now().Add(time.Hour).Unix()
+	_, err = mgr.issueTokenOIDC(&authn.OIDCLoginMsg{IDToken: sign(c)})
+	tassert.Errorf(t, err != nil, "expected error: not valid yet ('nbf')")
+	c = claims("carol", clientID, "readers")
+	c["iat"] = time.Now().Add(time.Hour).Unix()
+	_, err = mgr.issueTokenOIDC(&authn.OIDCLoginMsg{IDToken: sign(c)})
+	tassert.Errorf(t, err != nil, "expected error: issued in the future ('iat')")
+
+	// unknown key IDs must not trigger JWKS refetch (rate limit + negative cache)
+	n := fetches.Load()
+	for i := range 10 {
+		other, err := tok.NewSigningKey(tok.AlgES256, "other-kid-"+strconv.Itoa(i))
+		tassert.CheckFatal(t, err)
+		forged, err := other.Sign(claims("eve", clientID, "readers"))
+		tassert.CheckFatal(t, err)
+		_, err = mgr.issueTokenOIDC(&authn.OIDCLoginMsg{IDToken: forged})
+		tassert.Errorf(t, err != nil, "expected unknown key error")
+	}
+	tassert.Errorf(t, fetches.Load() == n, "expected no JWKS refetches, got %d", fetches.Load()-n)
+}
+
+func TestAccessKeys(t *testing.T) {
+	driver := mock.NewDBDriver()
+	mgr, err := newMgr(driver)
+	tassert.CheckFatal(t, err)
+	createUsers(mgr, t)
+
+	key, err := mgr.addAccessKey(&authn.AccessKeyMsg{UserID: users[0]})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, strings.HasPrefix(key.ID, accessKeyIDPrefix) && len(key.ID) == accessKeyIDLen, "invalid access key ID %q", key.ID)
+	tassert.Errorf(t, key.Secret != "", "empty secret")
+	tk, err := tok.DecryptToken(key.Token, Conf.Secret())
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tk.UserID == users[0], "expected user %q, got %q", users[0], tk.UserID)
+
+	_, err = mgr.addAccessKey(&authn.AccessKeyMsg{UserID: users[1]})
+	tassert.CheckFatal(t, err)
+	_, err = mgr.addAccessKey(&authn.AccessKeyMsg{UserID: "unknown"})
+	tassert.Errorf(t, err != nil, "expected error for unknown user")
+
+	aks, err := mgr.accessKeys(users[0])
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(aks) == 1 && aks[0].ID == key.ID, "expected user %q key %q, got %d keys", users[0], key.ID, len(aks))
+	aks, err = mgr.accessKeys("")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(aks) == 2, "expected 2 keys, got %d", len(aks))
+
+	// deleting user removes (and revokes) their keys
+	deleteUsers(mgr, false, t)
+	aks, err = mgr.accessKeys("")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(aks) == 0, "expected no keys, got %d", len(aks))
+}
@@ -137,12 +137,11 @@ func ecEncodeHandler(c *cli.Context) error {
 	checkAndRecover := flagIsSet(c, checkAndRecoverFlag)
 	if bprops.EC.Enabled {
 		if bprops.EC.DataSlices != numd || bprops.EC.ParitySlices != nump {
-			// not supported yet:
-			err := fmt.Errorf("%s is already (D=%d, P=%d) erasure-coded - cannot change this existing configuration to (D=%d, P=%d)",
+			warn := fmt.Sprintf("%s is already (D=%d, P=%d) erasure-coded - re-encoding all objects for (D=%d, P=%d)",
 				bck.Cname(""), bprops.EC.DataSlices, bprops.EC.ParitySlices, numd, nump)
-			return err
-		}
-		if !checkAndRecover {
+			actionWarn(c, warn)
+			warned = true
+		} else if !checkAndRecover {
 			var warn string
 			if bprops.EC.ObjSizeLimit == cmn.ObjSizeToAlwaysReplicate {
 				warn = fmt.Sprintf("%s is already configured for (P + 1 = %d copies)", bck.Cname(""), bprops.EC.ParitySlices+1)
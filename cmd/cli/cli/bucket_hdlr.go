@@ -178,6 +178,8 @@ var (
 			dontHeadRemoteFlag,
 			dontAddRemoteFlag,
 			listArchFlag,
+			archregxFlag,
+			archmodeFlag,
 			unitsFlag,
 			silentFlag,
 			dontWaitFlag,
@@ -63,9 +63,11 @@ const (
 	commandGet       = "get"
 	commandList      = "ls"
 	commandSetCustom = "set-custom"
+	commandSearchMD  = "search-md"
 	commandPut       = "put"
 	commandRemove    = "rm"
 	commandRename    = "mv"
+	commandResume    = "resume"
 	commandSet       = "set"
 	commandStart     = apc.ActXactStart
 	commandStop      = apc.ActXactStop
@@ -76,6 +78,10 @@ const (
 	cmdConfig = "config" // apc.WhatNodeConfig and apc.WhatClusterConfig
 	cmdLog    = apc.WhatLog
 
+	cmdFailureDomains = "failure-domains"
+	cmdAudit          = apc.WhatAudit
+	cmdUsage          = apc.WhatUsage
+
 	cmdBucket = "bucket"
 	cmdObject = "object"
 	cmdProps  = "props"
@@ -545,6 +551,15 @@ var (
 		Usage: "maximum number of object names to list (0 - unlimited; see also '--max-pages')\n" +
 			indent4 + "\te.g.: 'ais ls gs://abc --limit 1234 --cached --props size,custom",
 	}
+	auditLimitFlag = cli.IntFlag{
+		Name:  "limit",
+		Value: 100,
+		Usage: "maximum number of (most recent) audit events to show",
+	}
+	usageByFlag = cli.StringFlag{
+		Name:  "by",
+		Usage: "group usage by: " + apc.UsageByUser + " or " + apc.UsageByNamespace + " (default: by user and namespace)",
+	}
 	pageSizeFlag = cli.IntFlag{
 		Name: "page-size",
 		Usage: "maximum number of object names per page; when the flag is omitted or 0 (zero)\n" +
@@ -1020,7 +1035,7 @@ var (
 	// Copy Bucket
 	copyDryRunFlag = cli.BoolFlag{
 		Name:  "dry-run",
-		Usage: "show total size of new objects without really creating them",
+		Usage: "list selected source objects (ETL: show total counts and sizes) without really creating new objects",
 	}
 	copyPrependFlag = cli.StringFlag{
 		Name: "prepend",
@@ -97,9 +97,20 @@ var dsortStartCmd = cli.Command{
 		indent1 + "Tip: use '--dry-run' to see the results without making any changes\n" +
 		indent1 + "Tip: use '--verbose' to print the spec (with all its parameters including applied defaults)\n" +
 		indent1 + "See also: docs/dsort.md, docs/cli/dsort.md, and ais/test/scripts/dsort*",
-	ArgsUsage: dsortSpecArgument,
-	Flags:     startSpecialFlags[cmdDsort],
-	Action:    startDsortHandler,
+	ArgsUsage:   dsortSpecArgument,
+	Flags:       startSpecialFlags[cmdDsort],
+	Action:      startDsortHandler,
+	Subcommands: []cli.Command{dsortResumeCmd},
+}
+
+var dsortResumeCmd = cli.Command{
+	Name: commandResume,
+	Usage: "resume failed (aborted) " + apc.ActDsort + " job from its last checkpoint, e.g.:\n" +
+		indent1 + "\t- 'ais dsort resume srt-8Bf4FnHZL'\t- skip extraction and already created output shards, if possible.\n" +
+		indent1 + "Note: requires the same set of targets (as the original run)",
+	ArgsUsage:    jobIDArgument,
+	Action:       resumeDsortHandler,
+	BashComplete: dsortIDFinishedCompletions,
 }
 
 var phasesOrdered = [...]string{
@@ -214,6 +225,18 @@ func startDsortHandler(c *cli.Context) (err error) {
 	return
 }
 
+func resumeDsortHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	id := c.Args().Get(0)
+	if err := api.ResumeDsort(apiBP, id); err != nil {
+		return V(err)
+	}
+	actionDone(c, "Resumed "+apc.ActDsort+" job "+id)
+	return nil
+}
+
 // with minor editing
 func _flattenSpec(spec *dsort.RequestSpec) (flat, config nvpairList) {
 	var src, dst cmn.Bck
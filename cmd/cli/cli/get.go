@@ -154,7 +154,21 @@ func getHandler(c *cli.Context) error {
 		return err
 	}
 
-	// GET multiple -- currently, only prefix (TODO: list/range)
+	// GET multiple: list (in one shot, as a single archive)
+	if flagIsSet(c, listFlag) {
+		if flagIsSet(c, getObjPrefixFlag) {
+			return fmt.Errorf(errFmtExclusive, qflprn(listFlag), qflprn(getObjPrefixFlag))
+		}
+		if objName != "" {
+			return fmt.Errorf("object name in %q and %s cannot be used together", uri, qflprn(listFlag))
+		}
+		if extract || a.archmode != "" {
+			return fmt.Errorf("%s: only single %s is currently supported - "+NIY, qflprn(listFlag), qflprn(archpathGetFlag))
+		}
+		return getBatch(c, bck, outFile, a.archpath)
+	}
+
+	// GET multiple: prefix
 	if flagIsSet(c, getObjPrefixFlag) {
 		if objName != "" {
 			if _, err := archive.Mime("", objName); err != nil {
@@ -164,23 +178,34 @@ func getHandler(c *cli.Context) error {
 			}
 		}
 
-		// `getMultiObj` is a fusion of 'ais ls' and GET, with progress bar and a
-		// very limited archival support (that boils down to listing archived files)
-		// TODO -- FIXME: implement '--archregx' and the rest of the `qparamArch`
-		return getMultiObj(c, bck, outFile, a.enabled(), extract)
+		// `getMultiObj` is a fusion of 'ais ls' and GET, with progress bar and archival support
+		// that includes listing archived files (and getting them one by one) and, with '--archregx',
+		// selecting matching files from each prefix-matching shard
+		return getMultiObj(c, bck, outFile, &a, extract)
 	}
 
 	// GET
 	return getObject(c, bck, objName, outFile, a, false /*quiet*/, extract)
 }
 
-// GET multiple -- currently, only prefix (TODO: list/range)
-func getMultiObj(c *cli.Context, bck cmn.Bck, outFile string, lsarch, extract bool) error {
+// GET multiple: prefix
+func getMultiObj(c *cli.Context, bck cmn.Bck, outFile string, a *qparamArch, extract bool) error {
 	var (
 		prefix     = parseStrFlag(c, getObjPrefixFlag)
 		origPrefix = prefix
 		lstFilter  = &lstFilter{}
+		sel        *qparamArch // multi-selection: GET matching files from each shard (as a TAR)
 	)
+	if a.archregx != "" || a.archmode != "" {
+		if flagIsSet(c, listArchFlag) {
+			return fmt.Errorf(errFmtExclusive, qflprn(listArchFlag), qflprn(archregxFlag))
+		}
+		sel = a
+		lstFilter._add(func(obj *cmn.LsoEnt) bool {
+			_, err := archive.Mime(a.archmime, obj.Name)
+			return err == nil
+		})
+	}
 	if flagIsSet(c, listArchFlag) && prefix != "" {
 		// when prefix crosses shard boundary
 		if external, internal := splitPrefixShardBoundary(prefix); internal != "" {
@@ -195,7 +220,7 @@ func getMultiObj(c *cli.Context, bck cmn.Bck, outFile string, lsarch, extract bo
 	// setup lsmsg
 	msg := &apc.LsoMsg{Prefix: prefix}
 	msg.AddProps(apc.GetPropsMinimal...)
-	if flagIsSet(c, listArchFlag) || extract || lsarch {
+	if sel == nil && (flagIsSet(c, listArchFlag) || extract || a.enabled()) {
 		msg.SetFlag(apc.LsArchDir)
 	}
 	if flagIsSet(c, getObjCachedFlag) {
@@ -335,7 +360,7 @@ func getMultiObj(c *cli.Context, bck cmn.Bck, outFile string, lsarch, extract bo
 			}
 		}
 		u.wg.Add(1)
-		go u.get(c, bck, en, shardName, outFile, quiet, extract)
+		go u.get(c, bck, en, shardName, outFile, sel, quiet, extract)
 	}
 	u.wg.Wait()
 
@@ -353,12 +378,15 @@ func getMultiObj(c *cli.Context, bck cmn.Bck, outFile string, lsarch, extract bo
 // uctx - "get" extension
 //////////
 
-func (u *uctx) get(c *cli.Context, bck cmn.Bck, entry *cmn.LsoEnt, shardName, outFile string, quiet, extract bool) {
+func (u *uctx) get(c *cli.Context, bck cmn.Bck, entry *cmn.LsoEnt, shardName, outFile string, sel *qparamArch, quiet, extract bool) {
 	var (
 		a       qparamArch // effectively, ignore user-specified command line and redefine to GET a given shardName
 		objName = entry.Name
 	)
-	if shardName != "" {
+	switch {
+	case sel != nil:
+		a = *sel // (archregx, archmode, archmime) to select from each listed shard
+	case shardName != "":
 		objName = shardName
 		a.archpath = strings.TrimPrefix(entry.Name, shardName+"/")
 		if outFile != fileStdIO && !discardOutput(outFile) {
@@ -387,6 +415,65 @@ func (u *uctx) get(c *cli.Context, bck cmn.Bck, entry *cmn.LsoEnt, shardName, ou
 	u.wg.Done()
 }
 
+// GET multiple: list
+// - one request, one archive (its format is determined by the destination's extension; default: TAR)
+// - missing objects do not fail the operation - they are reported inline (see cmn.GetBatchPrefixNotFound)
+func getBatch(c *cli.Context, bck cmn.Bck, outFile, archpath string) error {
+	if outFile == "" {
+		return missingArgumentsError(c, "destination (archive filename, '-' for STDOUT, or '/dev/null')")
+	}
+	names := splitCsv(parseStrFlag(c, listFlag))
+	msg := &cmn.GetBatchMsg{Entries: make([]cmn.GetBatchEntry, 0, len(names))}
+	for _, name := range names {
+		msg.Entries = append(msg.Entries, cmn.GetBatchEntry{ObjName: name, ArchPath: archpath})
+	}
+	if mime, err := archive.Mime("", outFile); err == nil {
+		msg.Mime = mime
+	}
+
+	var w io.Writer
+	switch {
+	case outFile == fileStdIO:
+		w = os.Stdout
+	case discardOutput(outFile):
+		w = io.Discard
+	default:
+		if finfo, err := os.Stat(outFile); err == nil {
+			if finfo.IsDir() {
+				return fmt.Errorf("destination %q is a directory (expecting archive filename)", outFile)
+			}
+			if finfo.Mode().IsRegular() && !flagIsSet(c, yesFlag) {
+				if ok := confirm(c, fmt.Sprintf("overwrite existing %q", outFile)); !ok {
+					return nil
+				}
+			}
+		}
+		file, err := os.Create(outFile)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	n, err := api.GetBatch(apiBP, bck, msg, w)
+	if err != nil {
+		if outFile != fileStdIO && !discardOutput(outFile) {
+			os.Remove(outFile)
+		}
+		return V(err)
+	}
+	if outFile != fileStdIO {
+		units, errU := parseUnitsFlag(c, unitsFlag)
+		if errU != nil {
+			return errU
+		}
+		fmt.Fprintf(c.App.Writer, "GET %d object%s from %s as %q (%s)\n", len(msg.Entries), cos.Plural(len(msg.Entries)),
+			bck.Cname(""), outFile, teb.FmtSize(n, units, 2))
+	}
+	return nil
+}
+
 // get one (main function)
 func getObject(c *cli.Context, bck cmn.Bck, objName, outFile string, a qparamArch, quiet, extract bool) error {
 	if outFile == fileStdIO && extract {
@@ -310,6 +310,11 @@ func listObjects(c *cli.Context, bck cmn.Bck, prefix string, listArch, printEmpt
 	}
 	if listArch {
 		msg.SetFlag(apc.LsArchDir)
+		// select archived files (server-side)
+		msg.ArchRegx = parseStrFlag(c, archregxFlag)
+		msg.ArchMode = parseStrFlag(c, archmodeFlag)
+	} else if flagIsSet(c, archregxFlag) {
+		return fmt.Errorf("flag %s requires %s", qflprn(archregxFlag), qflprn(listArchFlag))
 	}
 	if flagIsSet(c, noRecursFlag) {
 		msg.SetFlag(apc.LsNoRecursion)
@@ -653,33 +658,15 @@ func (o *lstFilter) apply(entries cmn.LsoEntries) (matching, rest cmn.LsoEntries
 // prefix that crosses shard boundary, e.g.:
 // `ais ls bucket --prefix virt-subdir/A.tar.gz/dir-or-prefix-inside`
 func splitPrefixShardBoundary(prefix string) (external, internal string) {
-	if prefix == "" {
-		return
+	if shard, archPrefix, ok := archive.SplitPrefix(prefix); ok {
+		return shard, archPrefix
 	}
-	external = prefix
-	for _, ext := range archive.FileExtensions {
-		i := strings.Index(prefix, ext+"/")
-		if i <= 0 {
-			continue
-		}
-		internal = prefix[i+len(ext)+1:]
-		external = prefix[:i+len(ext)]
-		break
-	}
-	return
+	return prefix, ""
 }
 
 func splitObjnameShardBoundary(fullName string) (objName, fileName string) {
-	for _, ext := range archive.FileExtensions {
-		i := strings.Index(fullName, ext+"/")
-		if i <= 0 {
-			continue
-		}
-		fileName = fullName[i+len(ext)+1:]
-		objName = fullName[:i+len(ext)]
-		break
-	}
-	return
+	objName, fileName, _ = archive.SplitPrefix(fullName)
+	return objName, fileName
 }
 
 /////////////
@@ -96,6 +96,9 @@ func runTCO(c *cli.Context, bckFrom, bckTo cmn.Bck, listObjs, tmplObjs, etlName
 	if err != nil {
 		return err
 	}
+	if msg.DryRun {
+		return showDryRun(c, &xact.ArgsMsg{ID: xid, Kind: xkind})
+	}
 
 	// 4. progress bar, if requested
 	if showProgress {
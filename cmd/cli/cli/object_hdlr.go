@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmd/cli/teb"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/archive"
@@ -26,6 +27,7 @@ const objGetUsage = "get an object, a shard, an archived file, or a range of byt
 	indent4 + "\twrite the content locally with destination options including: filename, directory, STDOUT ('-'), or '/dev/null' (discard);\n" +
 	indent4 + "\tassorted options further include:\n" +
 	indent4 + "\t- '--prefix' to get multiple objects in one shot (empty prefix for the entire bucket);\n" +
+	indent4 + "\t- '--list' to get multiple objects (or, with '--archpath', archived files) as a single TAR (or other archive);\n" +
 	indent4 + "\t- '--extract' or '--archpath' to extract archived content;\n" +
 	indent4 + "\t- '--progress' and '--refresh' to watch progress bar;\n" +
 	indent4 + "\t- '-v' to produce verbose output when getting multiple objects."
@@ -73,6 +75,12 @@ const concatUsage = "append a file, a directory, or multiple files and/or direct
 const setCustomArgument = objectArgument + " " + jsonKeyValueArgument + " | " + keyValuePairsArgument + ", e.g.:\n" +
 	indent1 + "mykey1=value1 mykey2=value2 OR (same) '{\"mykey1\":\"value1\", \"mykey2\":\"value2\"}'"
 
+const searchMDUsage = "search bucket for objects that have the specified custom metadata, e.g.:\n" +
+	indent1 + "\t- 'search-md ais://nnn label=cat'\t- objects labeled \"cat\";\n" +
+	indent1 + "\t- 'search-md ais://nnn split'\t- objects that have custom \"split\" key (with any value);\n" +
+	indent1 + "\t- 'search-md ais://nnn split=val --prefix images/'\t- ditto, with the value \"val\" and only in the virtual directory \"images\".\n" +
+	indent1 + "Note: requires bucket feature " + "'Index-Custom-Metadata', e.g.: 'ais bucket props set ais://nnn features Index-Custom-Metadata'"
+
 var (
 	objectCmdsFlags = map[string][]cli.Flag{
 		commandRemove: append(
@@ -115,6 +123,8 @@ var (
 			getObjCachedFlag,
 			listArchFlag,
 			objLimitFlag,
+			// batch GET (single archive containing all listed objects)
+			listFlag,
 			//
 			unitsFlag,   // raw (bytes), kb, mib, etc.
 			verboseFlag, // client side
@@ -144,6 +154,9 @@ var (
 		commandSetCustom: {
 			setNewCustomMDFlag,
 		},
+		commandSearchMD: {
+			verbObjPrefixFlag,
+		},
 		commandPromote: {
 			recursFlag,
 			overwriteFlag,
@@ -209,6 +222,15 @@ var (
 		Action:    setCustomPropsHandler,
 	}
 
+	objectCmdSearchMD = cli.Command{
+		Name:         commandSearchMD,
+		Usage:        searchMDUsage,
+		ArgsUsage:    bucketArgument + " KEY[=VALUE]",
+		Flags:        objectCmdsFlags[commandSearchMD],
+		Action:       searchMDHandler,
+		BashComplete: bucketCompletions(bcmplop{}),
+	}
+
 	objectCmdPrefetch = cli.Command{
 		Name:         commandPrefetch,
 		Usage:        prefetchUsage,
@@ -238,6 +260,7 @@ var (
 			makeAlias(bucketCmdCopy, "", true, commandCopy), // alias for `ais [bucket] cp`
 			objectCmdConcat,
 			objectCmdSetCustom,
+			objectCmdSearchMD,
 			objectCmdRemove,
 			objectCmdPrefetch,
 			bucketObjCmdEvict,
@@ -496,6 +519,31 @@ func promoteHandler(c *cli.Context) (err error) {
 	return promote(c, bck, objName, fqn)
 }
 
+func searchMDHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	if c.NArg() == 1 {
+		return missingArgumentsError(c, "custom metadata KEY[=VALUE]")
+	}
+	bck, err := parseBckURI(c, c.Args().Get(0), false)
+	if err != nil {
+		return err
+	}
+	msg := &apc.MDSearchMsg{Prefix: parseStrFlag(c, verbObjPrefixFlag)}
+	msg.Key, msg.Value, _ = strings.Cut(c.Args().Get(1), "=")
+	msg.Key, msg.Value = strings.TrimSpace(msg.Key), strings.TrimSpace(msg.Value)
+
+	names, err := api.SearchObjectsMD(apiBP, bck, msg)
+	if err != nil {
+		return V(err)
+	}
+	for _, name := range names {
+		fmt.Fprintln(c.App.Writer, name)
+	}
+	return nil
+}
+
 func setCustomPropsHandler(c *cli.Context) (err error) {
 	if c.NArg() == 0 {
 		return missingArgumentsError(c, c.Command.ArgsUsage)
@@ -7,7 +7,10 @@ package cli
 
 import (
 	"fmt"
+	"math"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -278,6 +281,9 @@ func _throughput(c *cli.Context, metrics cos.StrKVs, mapBegin, mapEnd teb.StstMa
 // otherwise, skip computing (TODO: add comdline option)
 const miLatencyCntChange = 4
 
+// computed from latency histograms (see stats.KindHistogram)
+var latPercentiles = [...]int{50, 90, 99}
+
 func showLatencyHandler(c *cli.Context) error {
 	verbose := flagIsSet(c, verboseFlag)
 	metrics, err := getMetricNames(c)
@@ -297,6 +303,13 @@ func showLatencyHandler(c *cli.Context) error {
 			selected[name] = kind
 			continue
 		}
+		// histograms: percentiles (computed below)
+		if kind == stats.KindHistogram {
+			for _, pct := range latPercentiles {
+				selected[stats.HistToPercentile(name, pct)] = stats.KindLatency
+			}
+			continue
+		}
 		// skipping internal/computed latency; computing here over GetLatencyTotal instead
 		if kind == stats.KindLatency {
 			continue
@@ -375,11 +388,77 @@ func _latency(c *cli.Context, metrics cos.StrKVs, mapBegin, mapEnd teb.StstMap,
 			v.Value = 0
 			begin.Tracker[name] = v
 		}
+
+		// percentiles
+		for name := range metrics {
+			hist, pct := stats.PercentileToHist(name)
+			if hist == "" {
+				continue
+			}
+			v := begin.Tracker[name]
+			v.Value = _percentile(begin, end, hist, pct)
+			begin.Tracker[name] = v
+			if v.Value > 0 {
+				num++
+			}
+		}
 	}
 	idle = num == 0
 	return
 }
 
+// estimate latency percentile over the (begin, end) interval given histogram's cumulative bucket counts
+// (linear interpolation within the bucket, as in prometheus `histogram_quantile`)
+func _percentile(begin, end *stats.NodeStatus, hist string, pct int) int64 {
+	type bucket struct {
+		bound int64 // math.MaxInt64 for "+Inf"
+		cnt   int64
+	}
+	var (
+		prefix  = stats.HistBucketPrefix(hist)
+		buckets = make([]bucket, 0, 16)
+	)
+	for name, v := range end.Tracker {
+		s, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		b := bucket{bound: math.MaxInt64}
+		if s != stats.HistBucketInf {
+			var err error
+			if b.bound, err = strconv.ParseInt(s, 10, 64); err != nil {
+				continue
+			}
+		}
+		b.cnt = v.Value - begin.Tracker[name].Value
+		buckets = append(buckets, b)
+	}
+	if len(buckets) < 2 {
+		return 0
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].bound < buckets[j].bound })
+
+	total := buckets[len(buckets)-1].cnt
+	if total < miLatencyCntChange {
+		return 0
+	}
+	var (
+		rank           = float64(total) * float64(pct) / 100
+		lower, prevCnt int64
+	)
+	for _, b := range buckets {
+		if float64(b.cnt) >= rank {
+			if b.bound == math.MaxInt64 {
+				return lower // the highest finite bound
+			}
+			frac := (rank - float64(prevCnt)) / float64(b.cnt-prevCnt)
+			return lower + int64(frac*float64(b.bound-lower))
+		}
+		lower, prevCnt = b.bound, b.cnt
+	}
+	return lower
+}
+
 // (main method)
 func showPerfTab(c *cli.Context, metrics cos.StrKVs, cb perfcb, tag string, totals map[string]int64, inclAvgSize bool) error {
 	var (
@@ -96,6 +96,21 @@ var (
 			jsonFlag,
 			noHeaderFlag,
 		),
+		cmdFailureDomains: {
+			jsonFlag,
+			noHeaderFlag,
+		},
+		cmdAudit: {
+			auditLimitFlag,
+			jsonFlag,
+			noHeaderFlag,
+		},
+		cmdUsage: {
+			usageByFlag,
+			unitsFlag,
+			jsonFlag,
+			noHeaderFlag,
+		},
 		cmdBucket: {
 			jsonFlag,
 			compactPropFlag,
@@ -129,9 +144,17 @@ var (
 			showCmdJob,
 			showCmdLog,
 			showTLS,
+			showCmdUsage,
 		},
 	}
 
+	showCmdUsage = cli.Command{
+		Name:   cmdUsage,
+		Usage:  "show cluster-wide usage (requests, bytes read, written, and stored) by user and/or bucket namespace",
+		Flags:  showCmdsFlags[cmdUsage],
+		Action: showUsageHandler,
+	}
+
 	showCmdStorage = cli.Command{
 		Name:      commandStorage,
 		Usage:     "show storage usage and utilization, disks and mountpaths",
@@ -177,6 +200,18 @@ var (
 				Action:       showBMDHandler,
 				BashComplete: suggestAllNodes,
 			},
+			{
+				Name:   cmdFailureDomains,
+				Usage:  "show targets by failure domain (zone, rack) and validate domain coverage of erasure-coded buckets",
+				Flags:  showCmdsFlags[cmdFailureDomains],
+				Action: showFailureDomainsHandler,
+			},
+			{
+				Name:   cmdAudit,
+				Usage:  "show the most recent audit events (who did what) recorded by AIS gateways",
+				Flags:  showCmdsFlags[cmdAudit],
+				Action: showAuditHandler,
+			},
 			{
 				Name:      cmdConfig,
 				Usage:     "show cluster and node configuration",
@@ -729,6 +764,178 @@ func showBMDHandler(c *cli.Context) error {
 	return nil
 }
 
+func showAuditHandler(c *cli.Context) error {
+	events, err := api.GetAuditEvents(apiBP, parseIntFlag(c, auditLimitFlag))
+	if err != nil {
+		return V(err)
+	}
+	if flagIsSet(c, jsonFlag) {
+		return teb.Print(events, "", teb.Jopts(true))
+	}
+	if len(events) == 0 {
+		actionDone(c, "No audit events (hint: 'ais config cluster audit --help')")
+		return nil
+	}
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "TIME\tUSER\tCLIENT\tMETHOD\tACTION\tBUCKET\tOBJECT\tSTATUS\tPROXY")
+	}
+	for _, ev := range events {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			ev.Time.Format(time.RFC3339), cos.Left(ev.User, teb.NotSetVal), ev.Client, ev.Method,
+			cos.Left(ev.Action, ev.Path), cos.Left(ev.Bucket, teb.NotSetVal), cos.Left(ev.Object, teb.NotSetVal), ev.Status, ev.Node)
+	}
+	tw.Flush()
+	return nil
+}
+
+func showUsageHandler(c *cli.Context) error {
+	by := parseStrFlag(c, usageByFlag)
+	if by != "" && by != apc.UsageByUser && by != apc.UsageByNamespace {
+		return incorrectUsageMsg(c, "invalid %s value %q (expecting %q or %q)", qflprn(usageByFlag), by, apc.UsageByUser, apc.UsageByNamespace)
+	}
+	units, err := parseUnitsFlag(c, unitsFlag)
+	if err != nil {
+		return err
+	}
+	usage, err := api.GetUsage(apiBP, by)
+	if err != nil {
+		return V(err)
+	}
+	if flagIsSet(c, jsonFlag) {
+		return teb.Print(usage, "", teb.Jopts(true))
+	}
+	if len(usage) == 0 {
+		actionDone(c, "No usage recorded (hint: 'ais config cluster features Usage-Accounting')")
+		return nil
+	}
+	var hdr []string
+	if by != apc.UsageByNamespace {
+		hdr = append(hdr, "USER")
+	}
+	if by != apc.UsageByUser {
+		hdr = append(hdr, "NAMESPACE")
+	}
+	hdr = append(hdr, "GET", "READ", "PUT", "WRITTEN", "DELETE", "STORED")
+
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, strings.Join(hdr, "\t"))
+	}
+	for _, us := range usage {
+		var row []string
+		if by != apc.UsageByNamespace {
+			row = append(row, cos.Left(us.User, teb.NotSetVal))
+		}
+		if by != apc.UsageByUser {
+			row = append(row, cos.Left(us.Namespace, teb.NotSetVal))
+		}
+		row = append(row,
+			strconv.FormatInt(us.GetCount, 10), teb.FmtSize(us.GetBytes, units, 2),
+			strconv.FormatInt(us.PutCount, 10), teb.FmtSize(us.PutBytes, units, 2),
+			strconv.FormatInt(us.DelCount, 10), teb.FmtSize(us.Stored, units, 2),
+		)
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+	return nil
+}
+
+// show targets grouped by their respective failure domains (zones and racks) and
+// validate whether each erasure-coded bucket can survive the loss of an entire domain
+// (see also: meta.Smap.HrwTargetList)
+func showFailureDomainsHandler(c *cli.Context) error {
+	smap, err := getClusterMap(c)
+	if err != nil {
+		return err
+	}
+	var (
+		racks     = make(map[string][]string, 4)
+		zones     = cos.NewStrSet()
+		unlabeled []string
+	)
+	for tid, tsi := range smap.Tmap {
+		if tsi.InMaintOrDecomm() {
+			continue
+		}
+		if tsi.FD.IsEmpty() {
+			unlabeled = append(unlabeled, tid)
+			continue
+		}
+		racks[tsi.FD.String()] = append(racks[tsi.FD.String()], tid)
+		zones.Add(tsi.FD.Zone)
+	}
+	if len(racks) == 0 {
+		actionDone(c, "None of the "+strconv.Itoa(smap.CountActiveTs())+" targets advertise failure domains")
+		return nil
+	}
+
+	if flagIsSet(c, jsonFlag) {
+		return teb.Print(racks, "", teb.Jopts(true))
+	}
+	names := make([]string, 0, len(racks))
+	for name := range racks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "ZONE\tRACK\tTARGETS")
+	}
+	for _, name := range names {
+		tids := racks[name]
+		sort.Strings(tids)
+		zone, rack, _ := strings.Cut(name, "/")
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", zone, rack, strings.Join(tids, ", "))
+	}
+	tw.Flush()
+
+	// validate
+	if len(unlabeled) > 0 {
+		sort.Strings(unlabeled)
+		actionWarn(c, fmt.Sprintf("targets %v do not advertise failure domain (zone, rack)", unlabeled))
+	}
+	bmd, err := api.GetBMD(apiBP)
+	if err != nil {
+		return V(err)
+	}
+	numDomains, what := len(racks), "rack"
+	if len(zones) > 1 {
+		numDomains, what = len(zones), "zone"
+	}
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		ecconf := &bck.Props.EC
+		if !ecconf.Enabled {
+			return false
+		}
+		if !fdCoverage(ecconf, numDomains) {
+			actionWarn(c, fmt.Sprintf("%s (D=%d, P=%d): losing a single %s may result in data loss (%d %ss)",
+				bck.Cname(""), ecconf.DataSlices, ecconf.ParitySlices, what, numDomains, what))
+		}
+		return false
+	})
+	return nil
+}
+
+// with EC slices and replicas spread evenly across domains (the main replica being
+// the first), an object survives the loss of a domain if either its main replica
+// or any `D` slices survive
+func fdCoverage(ecconf *cmn.ECConf, numDomains int) bool {
+	if numDomains < 2 {
+		return false
+	}
+	if ecconf.ObjSizeLimit == cmn.ObjSizeToAlwaysReplicate {
+		return true
+	}
+	total := ecconf.DataSlices + ecconf.ParitySlices + 1
+	perDomain := (total + numDomains - 1) / numDomains
+	return perDomain <= ecconf.ParitySlices+1
+}
+
 func showClusterConfigHandler(c *cli.Context) error {
 	return showClusterConfig(c, c.Args().Get(0))
 }
@@ -7,6 +7,7 @@ package cli
 import (
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -124,7 +125,6 @@ func copyTransform(c *cli.Context, etlName, objNameOrTmpl string, bckFrom, bckTo
 			return incorrectUsageMsg(c, errFmtSameBucket, commandCopy, bckTo)
 		}
 		if dryRun {
-			// TODO: show object names with destinations, make the output consistent with etl dry-run
 			dryRunCptn(c)
 			actionDone(c, text2+" the entire bucket")
 		}
@@ -147,7 +147,7 @@ func copyTransform(c *cli.Context, etlName, objNameOrTmpl string, bckFrom, bckTo
 		} else {
 			prompt = fmt.Sprintf("%s objects that match the pattern %q ...\n", text2, oltp.tmpl)
 		}
-		dryRunCptn(c) // TODO: ditto
+		dryRunCptn(c)
 		actionDone(c, prompt)
 	}
 	return runTCO(c, bckFrom, bckTo, oltp.list, oltp.tmpl, etlName)
@@ -216,6 +216,9 @@ func copyBucket(c *cli.Context, bckFrom, bckTo cmn.Bck) error {
 			return err
 		}
 	}
+	if msg.DryRun {
+		return showDryRun(c, &xact.ArgsMsg{ID: xid, Kind: kind})
+	}
 
 	if !flagIsSet(c, waitFlag) && !flagIsSet(c, waitJobXactFinishedFlag) {
 		/// TODO: unify vs e2e: ("%s[%s] %s => %s", kind, xid, from, to)
@@ -242,6 +245,43 @@ func copyBucket(c *cli.Context, bckFrom, bckTo cmn.Bck) error {
 	return nil
 }
 
+// wait for x-tcb (x-tco) to finish and show the selected source objects (see apc.DryRunResult)
+func showDryRun(c *cli.Context, xargs *xact.ArgsMsg) error {
+	if err := waitXact(xargs); err != nil {
+		return err
+	}
+	snaps, err := api.QueryXactionSnaps(apiBP, xargs)
+	if err != nil {
+		return V(err)
+	}
+	var (
+		names     []string
+		truncated bool
+	)
+	for _, tsnaps := range snaps {
+		for _, snap := range tsnaps {
+			if snap.ID != xargs.ID || snap.Ext == nil {
+				continue
+			}
+			var res apc.DryRunResult
+			if err := cos.MorphMarshal(snap.Ext, &res); err != nil {
+				return err
+			}
+			names = append(names, res.Names...)
+			truncated = truncated || res.Truncated
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintln(c.App.Writer, name)
+	}
+	if truncated {
+		actionWarn(c, fmt.Sprintf("showing only the first %d selected objects per target", apc.MaxDryRunNames))
+	}
+	actionDone(c, fmt.Sprintf("%d object%s selected", len(names), cos.Plural(len(names))))
+	return nil
+}
+
 func tcbtcoCptn(action string, bckFrom, bckTo cmn.Bck) string {
 	from, to := bckFrom.Cname(""), bckTo.Cname("")
 	if bckFrom.Equal(&bckTo) {
@@ -52,6 +52,10 @@ type (
 		EC          ECConf          `json:"ec"`                             // erasure coding
 		LRU         LRUConf         `json:"lru"`                            // LRU (watermarks and enabled/disabled)
 		Mirror      MirrorConf      `json:"mirror"`                         // mirroring
+		RateLimit   RateConf        `json:"rate_limit"`                     // request rate and bandwidth limits
+		CORS        CORSConf        `json:"cors"`                           // cross-origin resource sharing (CORS) rules
+		Events      EventsConf      `json:"events"`                         // object event notifications (see events.go)
+		Replication ReplConf        `json:"replication"`                    // bucket-to-bucket replication (see repl.go)
 		Access      apc.AccessAttrs `json:"access,string"`                  // access permissions
 		Features    feat.Flags      `json:"features,string"`                // assorted features from feat.Bucket
 		BID         uint64          `json:"bid,string" list:"omit"`         // unique ID
@@ -126,6 +130,10 @@ type (
 		Access      *apc.AccessAttrs      `json:"access,string,omitempty"`
 		Features    *feat.Flags           `json:"features,string,omitempty"`
 		WritePolicy *WritePolicyConfToSet `json:"write_policy,omitempty"`
+		RateLimit   *RateConfToSet        `json:"rate_limit,omitempty"`
+		CORS        *CORSConfToSet        `json:"cors,omitempty"`
+		Events      *EventsConfToSet      `json:"events,omitempty"`
+		Replication *ReplConfToSet        `json:"replication,omitempty"`
 		Extra       *ExtraToSet           `json:"extra,omitempty"`
 		Force       bool                  `json:"force,omitempty" copy:"skip" list:"omit"`
 	}
@@ -176,6 +184,7 @@ func (bck *Bck) DefaultProps(c *ClusterConfig) *Bprops {
 		Access:      apc.AccessAll,
 		EC:          c.EC,
 		WritePolicy: wp,
+		RateLimit:   c.RateLimit.Bucket,
 		Features:    c.Features,
 	}
 }
@@ -220,7 +229,7 @@ func (bp *Bprops) Validate(targetCnt int) error {
 
 	// run assorted props validators
 	var softErr error
-	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy} {
+	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy, &bp.RateLimit, &bp.CORS, &bp.Events, &bp.Replication} {
 		var err error
 		switch {
 		case pv == &bp.EC:
@@ -375,3 +384,127 @@ type (
 )
 
 func (msg *ArchiveBckMsg) Cname() string { return msg.ToBck.Cname(msg.ArchName) }
+
+//
+// Batch GET: multiple objects, archived files, and/or ranges in a single request ----------------------------
+//
+
+// names (in the resulting archive) of the entries that failed to get retrieved
+const (
+	GetBatchPrefixNotFound = "__404__/" // empty file
+	GetBatchPrefixErr      = "__err__/" // error message
+)
+
+type (
+	// an object, a file archived in the object (aka shard), or an object's range;
+	// empty bucket (name) defaults to the one specified in the request URL
+	GetBatchEntry struct {
+		Bck      Bck    `json:"bck"`
+		ObjName  string `json:"objname"`
+		ArchPath string `json:"archpath,omitempty"` // extract a single file from the shard
+		Start    int64  `json:"start,omitempty"`    // range read: offset
+		Length   int64  `json:"length,omitempty"`   // range read: length (zero - to the end of the object)
+	}
+	// GET /v1/batch/<bucket-name>
+	// the response is a single archive (.tar by default) that contains all the entries, in order;
+	// missing entries are reported inline (see GetBatchPrefixNotFound and GetBatchPrefixErr)
+	GetBatchMsg struct {
+		Mime        string          `json:"mime,omitempty"` // output format (.tar, .tgz, .tar.lz4, .zip); empty - .tar
+		Entries     []GetBatchEntry `json:"entries"`
+		OnlyObjName bool            `json:"only_obj_name,omitempty"` // name files in the output without bucket prefix
+	}
+)
+
+func (e *GetBatchEntry) IsRange() bool { return e.Start > 0 || e.Length > 0 }
+
+// (name in the resulting archive)
+func (e *GetBatchEntry) NameInArch(onlyObjName bool) string {
+	name := e.ObjName
+	if !onlyObjName {
+		name = e.Bck.Name + "/" + name
+	}
+	if e.ArchPath != "" {
+		name += "/" + e.ArchPath
+	}
+	return name
+}
+
+// fill in default bucket and validate
+func (msg *GetBatchMsg) Init(bck *Bck) error {
+	if len(msg.Entries) == 0 {
+		return errors.New("get-batch: empty list of entries")
+	}
+	for i := range msg.Entries {
+		if err := msg.Entries[i].init(bck, i, "get-batch"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *GetBatchEntry) init(bck *Bck, i int, tag string) error {
+	if e.Bck.Name == "" {
+		e.Bck = *bck
+	} else if e.Bck.Provider != "" {
+		normp, err := NormalizeProvider(e.Bck.Provider)
+		if err != nil {
+			return err
+		}
+		e.Bck.Provider = normp
+	}
+	if e.ObjName == "" {
+		return fmt.Errorf("%s: entry #%d: missing object name", tag, i)
+	}
+	if err := ValidOname(e.ObjName); err != nil {
+		return err
+	}
+	if e.Start < 0 || e.Length < 0 {
+		return fmt.Errorf("%s: invalid range [%d, %d] for %s", tag, e.Start, e.Length, e.Bck.Cname(e.ObjName))
+	}
+	if e.ArchPath != "" && e.IsRange() {
+		return fmt.Errorf("%s: %s: archpath and range are mutually exclusive", tag, e.Bck.Cname(e.ObjName))
+	}
+	return nil
+}
+
+//
+// Compose: build a new object from (ranges of) existing objects and/or archived files -----------------------
+//
+
+// POST /v1/objects/<bucket-name>/<object-name> with apc.ActComposeObject:
+// the destination is the concatenation of all sources, in order; sources are specified
+// the same way as get-batch entries (see GetBatchEntry) and may reside in different buckets;
+// a missing source fails the request (compare with GetBatchMsg)
+type ComposeMsg struct {
+	Sources       []GetBatchEntry `json:"sources"`
+	DeleteSources bool            `json:"delete_sources,omitempty"` // upon success, delete source objects (except the destination itself)
+}
+
+// fill in default bucket and validate
+func (msg *ComposeMsg) Init(bck *Bck) error {
+	if len(msg.Sources) == 0 {
+		return errors.New("compose: empty list of sources")
+	}
+	for i := range msg.Sources {
+		if err := msg.Sources[i].init(bck, i, "compose"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// POST /v1/objects/<bucket-name>/<object-name> with apc.ActCopyRange:
+// server-side range copy - overwrite existing destination in place, starting at `Offset`,
+// with the source (an object, its range, or archived file) - compare with PATCH(object)
+// and apc.QparamPatchOffset
+type CopyRangeMsg struct {
+	Source GetBatchEntry `json:"source"`
+	Offset int64         `json:"offset"` // destination offset: [0, destination size]
+}
+
+func (msg *CopyRangeMsg) Init(bck *Bck) error {
+	if msg.Offset < 0 {
+		return fmt.Errorf("copy-range: invalid offset %d", msg.Offset)
+	}
+	return msg.Source.init(bck, 0, "copy-range")
+}
@@ -49,6 +49,20 @@ type detect struct {
 
 var FileExtensions = [...]string{ExtTar, ExtTgz, ExtTarGz, ExtZip, ExtTarLz4}
 
+// given prefix that crosses shard boundary, e.g. "A.tar/tutorials/",
+// return the shard name ("A.tar") and the archive-internal prefix ("tutorials/")
+// (used to list and select archived files without reading the rest of the bucket)
+func SplitPrefix(prefix string) (shard, archPrefix string, ok bool) {
+	for _, ext := range FileExtensions {
+		i := strings.Index(prefix, ext+"/")
+		if i <= 0 {
+			continue
+		}
+		return prefix[:i+len(ext)], prefix[i+len(ext)+1:], true
+	}
+	return "", "", false
+}
+
 // standard file signatures
 var (
 	magicTar  = detect{offset: 257, sig: []byte("ustar"), mime: ExtTar}
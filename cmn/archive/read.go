@@ -374,6 +374,28 @@ func (e *ErrMatchMode) Error() string {
 	return fmt.Sprintf("invalid matching mode %q, expecting one of: %v", e.mmode, MatchMode)
 }
 
+// when "archregx" is specified without "archmode"
+func DfltMatchMode() string { return MatchMode[_regexp] }
+
+// select archived files by name (e.g., when listing - see apc.LsoMsg.ArchRegx);
+// empty mmode defaults to regexp
+func NewMatcher(regex, mmode string) (func(filename string) bool, error) {
+	if cos.MatchAll(regex) {
+		regex = ""
+	}
+	if mmode == "" {
+		mmode = DfltMatchMode()
+	}
+	m := &matcher{regex: regex, mmode: mmode}
+	if err := m.init(); err != nil {
+		return nil, err
+	}
+	return m.do, nil
+}
+
+// to select all archived files under a given archive-internal directory ("archpath" ending with '/')
+func DirMatchMode() string { return MatchMode[_prefix] }
+
 func ValidateMatchMode(mmode string) (_ string, err error) {
 	if cos.MatchAll(mmode) {
 		return MatchMode[_prefix], nil
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"path"
@@ -57,6 +58,19 @@ type (
 		LogDir    string         `json:"log_dir"`
 		TestFSP   TestFSPConf    `json:"test_fspaths"`
 		HostNet   LocalNetConfig `json:"host_net"`
+		FD        FailureDomain  `json:"failure_domain"` // (optional) advertised by the node at join time
+		// (optional) target's relative weight for capacity-weighted HRW:
+		// - 0: not weighted (default) - see meta.DefaultHrwWeight
+		// - HrwWeightCapacity: derive from the total capacity of the target's mountpaths
+		// - any positive value: set by admin
+		HrwWeight int64 `json:"hrw_weight,omitempty"`
+	}
+
+	// failure domain: nodes that share a single point of failure, e.g. the same rack (power, ToR switch)
+	// and, at a coarser level, the same zone; see also: Smap.HrwTargetList
+	FailureDomain struct {
+		Zone string `json:"zone,omitempty"`
+		Rack string `json:"rack,omitempty"`
 	}
 
 	// ais node: (local) network config
@@ -99,6 +113,9 @@ type (
 		Dsort       DsortConf       `json:"distributed_sort"`
 		Proxy       ProxyConf       `json:"proxy" allow:"cluster"`
 		Auth        AuthConf        `json:"auth"`
+		Audit       AuditConf       `json:"audit"`
+		Metrics     MetricsConf     `json:"metrics"`
+		RateLimit   RateLimitConf   `json:"rate_limit"`
 		Cksum       CksumConf       `json:"checksum"`
 		TCB         TCBConf         `json:"tcb"` // transform (offline) or copy src bucket => dst bucket
 		Tracing     TracingConf     `json:"tracing"`
@@ -147,6 +164,9 @@ type (
 		Net         *NetConfToSet         `json:"net,omitempty"`
 		FSHC        *FSHCConfToSet        `json:"fshc,omitempty"`
 		Auth        *AuthConfToSet        `json:"auth,omitempty"`
+		Audit       *AuditConfToSet       `json:"audit,omitempty"`
+		Metrics     *MetricsConfToSet     `json:"metrics,omitempty"`
+		RateLimit   *RateLimitConfToSet   `json:"rate_limit,omitempty"`
 		Keepalive   *KeepaliveConfToSet   `json:"keepalivetracker,omitempty"`
 		Downloader  *DownloaderConfToSet  `json:"downloader,omitempty"`
 		Dsort       *DsortConfToSet       `json:"distributed_sort,omitempty"`
@@ -551,12 +571,81 @@ type (
 	FSHCConfRC3 FSHCConf
 
 	AuthConf struct {
-		Secret  string `json:"secret"`
-		Enabled bool   `json:"enabled"`
+		Secret string `json:"secret"`
+		// AuthN public keys to verify asymmetrically signed (RS256, ES256) tokens:
+		// JWKS endpoint, e.g. "http://authn:52001/.well-known/jwks.json" and/or
+		// local JWKS file (fallback, e.g. air-gapped deployments)
+		JWKSURL  string `json:"jwks_url,omitempty"`
+		JWKSFile string `json:"jwks_file,omitempty"`
+		Enabled  bool   `json:"enabled"`
 	}
 	AuthConfToSet struct {
-		Secret  *string `json:"secret,omitempty"`
-		Enabled *bool   `json:"enabled,omitempty"`
+		Secret   *string `json:"secret,omitempty"`
+		JWKSURL  *string `json:"jwks_url,omitempty"`
+		JWKSFile *string `json:"jwks_file,omitempty"`
+		Enabled  *bool   `json:"enabled,omitempty"`
+	}
+
+	// audit log of (control and data plane) operations recorded by AIS gateways
+	AuditConf struct {
+		// comma-separated audit classes, e.g. "cluster,bucket" (see apc.AuditCluster, et al.)
+		Classes string `json:"classes"`
+		// percentage of data plane (apc.AuditObject) operations to record; zero means all
+		ObjSamplePct int `json:"object_sample_pct"`
+		// exceeding this size triggers log rotation
+		MaxSize cos.SizeIEC `json:"max_size"`
+		// number of rotated logs to keep
+		MaxFiles int `json:"max_files"`
+		// comma-separated IP addresses and/or CIDRs of (external) load balancers and reverse proxies
+		// whose "X-Forwarded-For" is trusted to carry the actual client address
+		TrustedProxies string `json:"trusted_proxies"`
+		Enabled        bool   `json:"enabled"`
+	}
+	AuditConfToSet struct {
+		Classes        *string      `json:"classes,omitempty"`
+		ObjSamplePct   *int         `json:"object_sample_pct,omitempty"`
+		MaxSize        *cos.SizeIEC `json:"max_size,omitempty"`
+		MaxFiles       *int         `json:"max_files,omitempty"`
+		TrustedProxies *string      `json:"trusted_proxies,omitempty"`
+		Enabled        *bool        `json:"enabled,omitempty"`
+	}
+
+	// latency histograms (see stats.KindHistogram); changes require restart
+	MetricsConf struct {
+		// comma-separated (ascending) histogram bucket upper bounds, e.g. "1ms, 10ms, 100ms, 1s";
+		// empty means default buckets (see DfltLatencyBuckets)
+		LatencyBuckets string `json:"latency_buckets"`
+		// break down GET and PUT latency histograms by bucket (Prometheus variable label)
+		PerBucket bool `json:"per_bucket"`
+	}
+	MetricsConfToSet struct {
+		LatencyBuckets *string `json:"latency_buckets,omitempty"`
+		PerBucket      *bool   `json:"per_bucket,omitempty"`
+	}
+
+	// request rate and bandwidth limits enforced by AIS gateways (token bucket, one second burst);
+	// the limits are cluster-wide, with each gateway enforcing its (equal) share
+	RateLimitConf struct {
+		// default per-bucket limits (inherited by new buckets - see Bprops.RateLimit)
+		Bucket RateConf `json:"bucket"`
+		// per authenticated (AuthN) user
+		User RateConf `json:"user"`
+	}
+	RateLimitConfToSet struct {
+		Bucket *RateConfToSet `json:"bucket,omitempty"`
+		User   *RateConfToSet `json:"user,omitempty"`
+	}
+	RateConf struct {
+		// max number of requests per second; zero means unlimited
+		MaxRPS int `json:"max_rps"`
+		// max bytes per second written by PUT and APPEND requests with known content length; zero means unlimited
+		MaxBps  cos.SizeIEC `json:"max_bps"`
+		Enabled bool        `json:"enabled"`
+	}
+	RateConfToSet struct {
+		MaxRPS  *int         `json:"max_rps,omitempty"`
+		MaxBps  *cos.SizeIEC `json:"max_bps,omitempty"`
+		Enabled *bool        `json:"enabled,omitempty"`
 	}
 
 	// keepalive
@@ -674,7 +763,7 @@ type (
 
 // assorted named fields that require (cluster | node) restart for changes to make an effect
 // (used by CLI)
-var ConfigRestartRequired = [...]string{"auth.secret", "memsys", "net"}
+var ConfigRestartRequired = [...]string{"auth.secret", "memsys", "metrics", "net"}
 
 //
 // config meta-versioning & serialization
@@ -722,12 +811,16 @@ var (
 	_ Validator = (*TCBConf)(nil)
 	_ Validator = (*WritePolicyConf)(nil)
 	_ Validator = (*TracingConf)(nil)
+	_ Validator = (*AuditConf)(nil)
+	_ Validator = (*MetricsConf)(nil)
+	_ Validator = (*RateLimitConf)(nil)
 
 	_ PropsValidator = (*CksumConf)(nil)
 	_ PropsValidator = (*SpaceConf)(nil)
 	_ PropsValidator = (*MirrorConf)(nil)
 	_ PropsValidator = (*ECConf)(nil)
 	_ PropsValidator = (*WritePolicyConf)(nil)
+	_ PropsValidator = (*RateConf)(nil)
 
 	_ json.Marshaler   = (*BackendConf)(nil)
 	_ json.Unmarshaler = (*BackendConf)(nil)
@@ -762,6 +855,13 @@ func (c *Config) Validate() error {
 	if err := c.LocalConfig.TestFSP.Validate(c); err != nil {
 		return err
 	}
+	if c.LocalConfig.HrwWeight < HrwWeightCapacity {
+		return fmt.Errorf("invalid hrw_weight %d (expecting %d (capacity) or non-negative)",
+			c.LocalConfig.HrwWeight, HrwWeightCapacity)
+	}
+	if err := c.LocalConfig.FD.Validate(); err != nil {
+		return err
+	}
 
 	opts := IterOpts{VisitAll: true}
 	return IterFields(c, _validateFld, opts)
@@ -830,6 +930,36 @@ func (c *LocalConfig) DelPath(mpath string) {
 	c.FSP.Paths.Delete(mpath)
 }
 
+const HrwWeightCapacity = -1 // (see LocalConfig.HrwWeight)
+
+///////////////////
+// FailureDomain //
+///////////////////
+
+func (fd *FailureDomain) IsEmpty() bool { return fd.Zone == "" && fd.Rack == "" }
+
+func (fd *FailureDomain) String() string {
+	if fd.IsEmpty() {
+		return ""
+	}
+	return fd.Zone + "/" + fd.Rack
+}
+
+// zone and rack labels: letters, numbers, dashes, underscores, and periods (see cos.CheckAlphaPlus)
+func (fd *FailureDomain) Validate() error {
+	if fd.Zone != "" {
+		if err := cos.CheckAlphaPlus(fd.Zone, "failure domain zone"); err != nil {
+			return err
+		}
+	}
+	if fd.Rack != "" {
+		if err := cos.CheckAlphaPlus(fd.Rack, "failure domain rack"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 ////////////////
 // PeriodConf //
 ////////////////
@@ -1850,6 +1980,145 @@ func (tac TraceExporterAuthConf) IsEnabled() bool {
 	return tac.TokenFile != "" && tac.TokenHeader != ""
 }
 
+///////////////
+// AuditConf //
+///////////////
+
+func (c *AuditConf) Validate() error {
+	for _, class := range c.ClassList() {
+		switch class {
+		case apc.AuditCluster, apc.AuditBucket, apc.AuditObject:
+		default:
+			return fmt.Errorf("invalid audit.classes: unknown class %q (expecting one of: %s, %s, %s)",
+				class, apc.AuditCluster, apc.AuditBucket, apc.AuditObject)
+		}
+	}
+	if c.ObjSamplePct < 0 || c.ObjSamplePct > 100 {
+		return fmt.Errorf("invalid audit.object_sample_pct %d (expecting 0..100 range)", c.ObjSamplePct)
+	}
+	if c.MaxSize < 0 || c.MaxFiles < 0 {
+		return fmt.Errorf("invalid audit.max_size %d or audit.max_files %d (must be non-negative)", c.MaxSize, c.MaxFiles)
+	}
+	for _, s := range splitCommaList(c.TrustedProxies) {
+		if net.ParseIP(s) != nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(s); err != nil {
+			return fmt.Errorf("invalid audit.trusted_proxies: %q is neither IP address nor CIDR", s)
+		}
+	}
+	return nil
+}
+
+// whether a given (remote) IP address belongs to one of the configured trusted proxies
+func (c *AuditConf) IsTrustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, s := range splitCommaList(c.TrustedProxies) {
+		if tip := net.ParseIP(s); tip != nil {
+			if tip.Equal(ip) {
+				return true
+			}
+			continue
+		}
+		if _, ipnet, err := net.ParseCIDR(s); err == nil && ipnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *AuditConf) ClassList() []string { return splitCommaList(c.Classes) }
+
+func splitCommaList(s string) (out []string) {
+	for s != "" {
+		var item string
+		item, s, _ = strings.Cut(s, ",")
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// (no allocations - called on a per-request basis)
+func (c *AuditConf) IsSet(class string) bool {
+	if !c.Enabled {
+		return false
+	}
+	for s := c.Classes; s != ""; {
+		var item string
+		item, s, _ = strings.Cut(s, ",")
+		if strings.TrimSpace(item) == class {
+			return true
+		}
+	}
+	return false
+}
+
+/////////////////
+// MetricsConf //
+/////////////////
+
+const DfltLatencyBuckets = "1ms, 2ms, 5ms, 10ms, 25ms, 50ms, 100ms, 250ms, 500ms, 1s, 2.5s, 5s, 10s"
+
+func (c *MetricsConf) Validate() error {
+	_, err := c.LatencyBounds()
+	return err
+}
+
+func (c *MetricsConf) LatencyBounds() ([]time.Duration, error) {
+	var (
+		bounds []time.Duration
+		s      = cos.Left(c.LatencyBuckets, DfltLatencyBuckets)
+	)
+	for s != "" {
+		var item string
+		item, s, _ = strings.Cut(s, ",")
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		d, err := time.ParseDuration(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metrics.latency_buckets %q: %v", c.LatencyBuckets, err)
+		}
+		if d <= 0 || (len(bounds) > 0 && d <= bounds[len(bounds)-1]) {
+			return nil, fmt.Errorf("invalid metrics.latency_buckets %q: expecting positive durations in ascending order",
+				c.LatencyBuckets)
+		}
+		bounds = append(bounds, d)
+	}
+	if len(bounds) == 0 {
+		return nil, fmt.Errorf("invalid metrics.latency_buckets %q: no buckets", c.LatencyBuckets)
+	}
+	return bounds, nil
+}
+
+///////////////////
+// RateLimitConf //
+///////////////////
+
+func (c *RateLimitConf) Validate() error {
+	if err := c.Bucket.ValidateAsProps(); err != nil {
+		return fmt.Errorf("invalid rate_limit.bucket: %v", err)
+	}
+	if err := c.User.ValidateAsProps(); err != nil {
+		return fmt.Errorf("invalid rate_limit.user: %v", err)
+	}
+	return nil
+}
+
+func (c *RateConf) ValidateAsProps(...any) error {
+	if c.MaxRPS < 0 || c.MaxBps < 0 {
+		return fmt.Errorf("max_rps %d and max_bps %d must be non-negative", c.MaxRPS, c.MaxBps)
+	}
+	return nil
+}
+
+func (c *RateConf) IsSet() bool { return c.Enabled && (c.MaxRPS > 0 || c.MaxBps > 0) }
+
 ////////////////////
 // ConfigToSet //
 ////////////////////
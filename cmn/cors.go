@@ -0,0 +1,153 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Cross-Origin Resource Sharing (CORS): per-bucket rules
+// - rules are evaluated in order: the first rule that matches request origin and method
+//   (and, in preflight, all requested headers) applies;
+// - allowed origins and headers may contain (at most) one '*' wildcard each;
+// - header names are case-insensitive;
+// - same limits as Amazon S3 (see below).
+
+const (
+	MaxCORSRules = 100
+	corsWildcard = "*"
+)
+
+type (
+	CORSConf struct {
+		Rules []CORSRule `json:"rules,omitempty"`
+	}
+	CORSConfToSet struct {
+		Rules *[]CORSRule `json:"rules" list:"readonly"` // (JSON only)
+	}
+	CORSRule struct {
+		ID             string   `json:"id,omitempty"`
+		AllowedOrigins []string `json:"allowed_origins"`
+		AllowedMethods []string `json:"allowed_methods"`
+		AllowedHeaders []string `json:"allowed_headers,omitempty"`
+		ExposeHeaders  []string `json:"expose_headers,omitempty"`
+		MaxAgeSeconds  int      `json:"max_age_seconds,omitempty"`
+	}
+)
+
+// interface guard
+var _ PropsValidator = (*CORSConf)(nil)
+
+func (c *CORSConf) IsSet() bool { return len(c.Rules) > 0 }
+
+func (c *CORSConf) ValidateAsProps(...any) error {
+	if len(c.Rules) > MaxCORSRules {
+		return fmt.Errorf("too many CORS rules (%d), max %d", len(c.Rules), MaxCORSRules)
+	}
+	for i := range c.Rules {
+		if err := c.Rules[i].validate(); err != nil {
+			return fmt.Errorf("invalid CORS rule #%d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// returns the first matching rule or nil
+// - headers: list of requested headers (preflight only)
+func (c *CORSConf) Match(origin, method string, headers []string) *CORSRule {
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.allows(origin, method, headers) {
+			return rule
+		}
+	}
+	return nil
+}
+
+func (rule *CORSRule) validate() error {
+	if len(rule.AllowedOrigins) == 0 {
+		return errors.New("missing allowed origin(s)")
+	}
+	if len(rule.AllowedMethods) == 0 {
+		return errors.New("missing allowed method(s)")
+	}
+	for _, origin := range rule.AllowedOrigins {
+		if strings.Count(origin, corsWildcard) > 1 {
+			return fmt.Errorf("allowed origin %q contains more than one wildcard", origin)
+		}
+	}
+	for _, method := range rule.AllowedMethods {
+		switch method {
+		case http.MethodGet, http.MethodPut, http.MethodHead, http.MethodPost, http.MethodDelete:
+		default:
+			return fmt.Errorf("allowed method %q is not supported", method)
+		}
+	}
+	for _, hdr := range rule.AllowedHeaders {
+		if strings.Count(hdr, corsWildcard) > 1 {
+			return fmt.Errorf("allowed header %q contains more than one wildcard", hdr)
+		}
+	}
+	if rule.MaxAgeSeconds < 0 {
+		return fmt.Errorf("invalid max-age %d", rule.MaxAgeSeconds)
+	}
+	return nil
+}
+
+// whether the rule allows any origin
+func (rule *CORSRule) AnyOrigin() bool {
+	for _, origin := range rule.AllowedOrigins {
+		if origin == corsWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *CORSRule) allows(origin, method string, headers []string) bool {
+	var ok bool
+	for _, o := range rule.AllowedOrigins {
+		if ok = wildcardMatch(o, origin); ok {
+			break
+		}
+	}
+	if !ok {
+		return false
+	}
+	ok = false
+	for _, m := range rule.AllowedMethods {
+		if ok = m == method; ok {
+			break
+		}
+	}
+	if !ok {
+		return false
+	}
+outer:
+	for _, hdr := range headers {
+		hdr = strings.ToLower(hdr)
+		for _, a := range rule.AllowedHeaders {
+			if wildcardMatch(strings.ToLower(a), hdr) {
+				continue outer
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// pattern with at most one '*'
+func wildcardMatch(pattern, s string) bool {
+	i := strings.Index(pattern, corsWildcard)
+	if i < 0 {
+		return pattern == s
+	}
+	prefix, suffix := pattern[:i], pattern[i+1:]
+	return len(s) >= len(prefix)+len(suffix) && strings.HasPrefix(s, prefix) && strings.HasSuffix(s, suffix)
+}
@@ -44,6 +44,25 @@ const (
 	HdrServer    = "Server"
 	HdrETag      = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/ETag
 
+	// conditional requests: https://www.rfc-editor.org/rfc/rfc9110#section-13.1
+	HdrIfMatch           = "If-Match"
+	HdrIfNoneMatch       = "If-None-Match"
+	HdrIfModifiedSince   = "If-Modified-Since"
+	HdrIfUnmodifiedSince = "If-Unmodified-Since"
+	HdrLastModified      = "Last-Modified"
+
+	// CORS: https://fetch.spec.whatwg.org/#http-cors-protocol
+	HdrOrigin             = "Origin"
+	HdrVary               = "Vary"
+	HdrACAllowOrigin      = "Access-Control-Allow-Origin"
+	HdrACAllowMethods     = "Access-Control-Allow-Methods"
+	HdrACAllowHeaders     = "Access-Control-Allow-Headers"
+	HdrACAllowCredentials = "Access-Control-Allow-Credentials"
+	HdrACExposeHeaders    = "Access-Control-Expose-Headers"
+	HdrACMaxAge           = "Access-Control-Max-Age"
+	HdrACRequestMethod    = "Access-Control-Request-Method"
+	HdrACRequestHeaders   = "Access-Control-Request-Headers"
+
 	HdrHSTS = "Strict-Transport-Security"
 )
 
@@ -67,6 +86,15 @@ const (
 	S3HdrObjSrc = "x-amz-copy-source"
 	S3HdrMptCnt = "x-amz-mp-parts-count"
 
+	// object tagging: https://docs.aws.amazon.com/AmazonS3/latest/userguide/object-tagging.html
+	S3HdrTagging      = "x-amz-tagging"
+	S3HdrTaggingCount = "x-amz-tagging-count"
+
+	// get-object-attributes: https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectAttributes.html
+	S3HdrObjAttrs         = "x-amz-object-attributes"
+	S3HdrMaxParts         = "x-amz-max-parts"
+	S3HdrPartNumberMarker = "x-amz-part-number-marker"
+
 	// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
 	S3UnsignedPayload  = "UNSIGNED-PAYLOAD"
 	S3HdrContentSHA256 = "x-amz-content-sha256"
@@ -81,7 +109,7 @@ const (
 	S3MetadataChecksumType = "x-amz-meta-ais-cksum-type"
 	S3MetadataChecksumVal  = "x-amz-meta-ais-cksum-val"
 
-	S3LastModified = "Last-Modified"
+	S3LastModified = HdrLastModified
 )
 
 const (
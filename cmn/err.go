@@ -16,6 +16,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -215,6 +216,14 @@ type (
 	ErrXactUsePrev struct { // equivalent to xreg.WprUse
 		xaction string
 	}
+	ErrRateLimit struct { // http.StatusTooManyRequests
+		what       string // e.g., "bucket ais://abc"
+		RetryAfter time.Duration
+	}
+	ErrPrecondFailed struct { // http.StatusPreconditionFailed
+		what string // e.g., "ais://abc/obj"
+		hdr  string // e.g., "If-Match"
+	}
 	ErrXactTgtInMaint struct {
 		xaction string
 		tname   string
@@ -885,6 +894,36 @@ func IsErrXactUsePrev(err error) bool {
 	return ok
 }
 
+// ErrRateLimit
+
+func NewErrRateLimit(what string, retryAfter time.Duration) *ErrRateLimit {
+	return &ErrRateLimit{what, retryAfter}
+}
+
+func (e *ErrRateLimit) Error() string {
+	return fmt.Sprintf("%s: rate limit exceeded, retry after %v", e.what, e.RetryAfter)
+}
+
+func IsErrRateLimit(err error) bool {
+	_, ok := err.(*ErrRateLimit)
+	return ok
+}
+
+// ErrPrecondFailed
+
+func NewErrPrecondFailed(what, hdr string) *ErrPrecondFailed {
+	return &ErrPrecondFailed{what, hdr}
+}
+
+func (e *ErrPrecondFailed) Error() string {
+	return fmt.Sprintf("%s: precondition failed (%s)", e.what, e.hdr)
+}
+
+func IsErrPrecondFailed(err error) bool {
+	_, ok := err.(*ErrPrecondFailed)
+	return ok
+}
+
 // ErrInvalidObjName, ErrInvalidPrefix
 
 const (
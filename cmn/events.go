@@ -0,0 +1,168 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Object event notifications: per-bucket subscriptions (rules)
+// - each rule selects event types (all, if not specified) and, optionally, object name prefix and suffix;
+// - events are emitted by the targets that store (or delete) the objects in question;
+// - with a webhook, events are POST-ed (in JSON batches) to the specified http(s) URL;
+//   otherwise, events are queued and delivered via long-poll (see api.GetEvents);
+// - in both cases, delivery is at-least-once: each target maintains a durable
+//   (bounded) per-rule journal, and a given event is delivered until acknowledged
+//   (webhook: 2xx response; long-poll: next request with the returned token).
+
+// event types
+const (
+	EventObjCreated = "ObjectCreated"    // PUT, APPEND, copy (and rename), promote, ETL, compose, patch
+	EventObjRemoved = "ObjectRemoved"    // DELETE, rename
+	EventObjEvicted = "ObjectEvicted"    // evict (remote buckets)
+	EventColdGet    = "ColdGetCompleted" // cold GET (remote buckets)
+)
+
+const MaxEventRules = 100
+
+type (
+	EventsConf struct {
+		Rules []EventRule `json:"rules,omitempty"`
+	}
+	EventsConfToSet struct {
+		Rules *[]EventRule `json:"rules" list:"readonly"` // (JSON only)
+	}
+	EventRule struct {
+		ID      string   `json:"id"`                // required, unique
+		Events  []string `json:"events,omitempty"`  // none specified - all event types
+		Prefix  string   `json:"prefix,omitempty"`  // object name prefix
+		Suffix  string   `json:"suffix,omitempty"`  // object name suffix
+		Webhook string   `json:"webhook,omitempty"` // http(s) URL; none specified - local queue (long-poll)
+	}
+
+	// single event
+	ObjEvent struct {
+		Event   string `json:"event"`
+		RuleID  string `json:"rule"`
+		ObjName string `json:"name"`
+		Version string `json:"version,omitempty"`
+		Node    string `json:"node"` // ID of the target that emitted the event
+		Bck     Bck    `json:"bck"`
+		Size    int64  `json:"size"`
+		Time    int64  `json:"time"` // nanoseconds since epoch
+		Seq     int64  `json:"seq"`  // per (node, rule) sequence number
+	}
+
+	// GET /v1/events/<bucket-name>?rule=<id>&token=<token>
+	EventsResult struct {
+		Events []ObjEvent `json:"events"`
+		Token  string     `json:"token"` // pass it back to receive next events (and acknowledge the current ones)
+	}
+)
+
+// interface guard
+var _ PropsValidator = (*EventsConf)(nil)
+
+func (c *EventsConf) IsSet() bool { return len(c.Rules) > 0 }
+
+func (c *EventsConf) ValidateAsProps(...any) error {
+	if len(c.Rules) > MaxEventRules {
+		return fmt.Errorf("too many event rules (%d), max %d", len(c.Rules), MaxEventRules)
+	}
+	ids := make(cos.StrSet, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("invalid event rule #%d: %v", i, err)
+		}
+		if ids.Contains(rule.ID) {
+			return fmt.Errorf("duplicate event rule ID %q", rule.ID)
+		}
+		ids.Set(rule.ID)
+	}
+	return nil
+}
+
+func (c *EventsConf) Rule(id string) *EventRule {
+	for i := range c.Rules {
+		if c.Rules[i].ID == id {
+			return &c.Rules[i]
+		}
+	}
+	return nil
+}
+
+func (rule *EventRule) validate() error {
+	if rule.ID == "" {
+		return errors.New("missing rule ID")
+	}
+	if !cos.IsAlphaNice(rule.ID) {
+		return fmt.Errorf("rule ID %q is invalid: %s", rule.ID, cos.OnlyNice)
+	}
+	for _, ev := range rule.Events {
+		switch ev {
+		case EventObjCreated, EventObjRemoved, EventObjEvicted, EventColdGet:
+		default:
+			return fmt.Errorf("unknown event type %q", ev)
+		}
+	}
+	if rule.Webhook != "" {
+		if !cos.IsHTTPS(rule.Webhook) && !cos.IsHT(rule.Webhook) {
+			return fmt.Errorf("webhook %q: expecting http(s) URL", rule.Webhook)
+		}
+		if _, ok := cos.ParseURL(rule.Webhook); !ok {
+			return fmt.Errorf("invalid webhook URL %q", rule.Webhook)
+		}
+	}
+	return nil
+}
+
+func (rule *EventRule) Match(event, objName string) bool {
+	if !strings.HasPrefix(objName, rule.Prefix) || !strings.HasSuffix(objName, rule.Suffix) {
+		return false
+	}
+	if len(rule.Events) == 0 {
+		return true
+	}
+	for _, ev := range rule.Events {
+		if ev == event {
+			return true
+		}
+	}
+	return false
+}
+
+//
+// long-poll token: opaque (base64) encoding of the per-target sequence numbers
+//
+
+func EncodeEventsToken(seqs map[string]int64) string {
+	if len(seqs) == 0 {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(cos.MustMarshal(seqs))
+}
+
+func DecodeEventsToken(token string) (map[string]int64, error) {
+	seqs := make(map[string]int64)
+	if token == "" {
+		return seqs, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = jsoniter.Unmarshal(b, &seqs)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid events token %q: %v", token, err)
+	}
+	return seqs, nil
+}
@@ -41,6 +41,9 @@ const (
 	DontDeleteWhenRebalancing // when objects get _rebalanced_ to their proper locations, do not delete their respective _misplaced_ sources
 	DontSetControlPlaneToS    // intra-cluster control plane: do not set IPv4 ToS field (to low-latency)
 	TrustCryptoSafeChecksums  // when checking whether objects are identical trust only cryptographically secure checksums
+	IndexCustomMD             // (*) maintain (per-target) index of objects' custom metadata to search objects by
+	UsageAccounting           // track per-user and per-namespace usage: request counts, bytes read, written, and stored
+	AllowObjectPatch          // (*) allow in-place partial overwrites (PATCH at offset, server-side range copy) of ais:// objects
 )
 
 var Cluster = [...]string{
@@ -62,6 +65,9 @@ var Cluster = [...]string{
 	"Do-not-Delete-When-Rebalancing",
 	"Do-not-Set-Control-Plane-ToS",
 	"Trust-Crypto-Safe-Checksums",
+	"Index-Custom-Metadata",
+	"Usage-Accounting",
+	"Allow-Object-Patch",
 
 	// "none" ====================
 }
@@ -73,6 +79,8 @@ var Bucket = [...]string{
 	"Disable-Cold-GET",
 	"Streaming-Cold-GET",
 	"S3-Use-Path-Style", // https://aws.amazon.com/blogs/aws/amazon-s3-path-deprecation-plan-the-rest-of-the-story
+	"Index-Custom-Metadata",
+	"Allow-Object-Patch",
 
 	// "none" ====================
 }
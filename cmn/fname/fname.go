@@ -49,4 +49,7 @@ const (
 	RebalanceMarker     = "rebalance"
 	NodeRestartedMarker = "node_restarted"
 	NodeRestartedPrev   = "node_restarted.prev"
+
+	// Object event journals: per target (under config dir)
+	EventsDir = ".ais.events"
 )
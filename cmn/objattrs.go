@@ -38,6 +38,15 @@ const (
 
 	// additional backend
 	LastModified = "LastModified"
+
+	// S3 object tags (URL-encoded, as in: `x-amz-tagging` header)
+	TaggingObjMD = "tagging"
+
+	// bucket-to-bucket replication: destination and replicated content (see ReplConf.Tag)
+	ReplObjMD = "repl"
+
+	// usage accounting: the user charged for storing the object (see feat.UsageAccounting)
+	OwnerObjMD = "owner"
 )
 
 // object properties
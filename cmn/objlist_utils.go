@@ -73,6 +73,14 @@ func (be *LsoEnt) IsVerChanged() bool { return be.Flags&apc.EntryVerChanged != 0
 func (be *LsoEnt) SetVerRemoved()     { be.Flags |= apc.EntryVerRemoved }
 func (be *LsoEnt) IsVerRemoved() bool { return be.Flags&apc.EntryVerRemoved != 0 }
 
+// see also: apc.LsVersions
+func (be *LsoEnt) IsLatest() bool    { return be.Flags&apc.EntryNotLatest == 0 }
+func (be *LsoEnt) IsDelMarker() bool { return be.Flags&apc.EntryDelMarker != 0 }
+
+// bucket-to-bucket replication (see ReplConf)
+func (be *LsoEnt) IsReplicated() bool  { return be.Flags&apc.EntryReplicated != 0 }
+func (be *LsoEnt) IsReplPending() bool { return be.Flags&apc.EntryReplPending != 0 }
+
 func (be *LsoEnt) IsStatusOK() bool   { return be.Status() == 0 }
 func (be *LsoEnt) Status() uint16     { return be.Flags & apc.EntryStatusMask }
 func (be *LsoEnt) IsDir() bool        { return be.Flags&apc.EntryIsDir != 0 }
@@ -128,6 +136,18 @@ func (be *LsoEnt) CopyWithProps(propsSet cos.StrSet) (ne *LsoEnt) {
 
 func SortLso(entries LsoEntries) { sort.Slice(entries, entries.cmp) }
 
+// unlike SortLso (above), does not place virtual directories first -
+// the order that paging (by continuation token) depends upon
+func SortLsoByName(entries LsoEntries) {
+	sort.Slice(entries, func(i, j int) bool {
+		eni, enj := entries[i], entries[j]
+		if eni.Name == enj.Name {
+			return eni.less(enj)
+		}
+		return eni.Name < enj.Name
+	})
+}
+
 func DedupLso(entries LsoEntries, maxSize int, noDirs bool) []*LsoEnt {
 	var j int
 	for _, en := range entries {
@@ -158,6 +178,11 @@ func MergeLso(lists []*LsoRes, lsmsg *apc.LsoMsg, maxSize int) *LsoRes {
 	}
 	resList := lists[0]
 	token := resList.ContinuationToken
+	if lsmsg.IsFlagSet(apc.LsVersions) {
+		// pass-through: multiple versions share the same name and are already ordered by the backend
+		debug.Assert(len(lists) == 1)
+		return resList
+	}
 	if len(lists) == 1 {
 		SortLso(resList.Entries)
 		resList.Entries = DedupLso(resList.Entries, maxSize, noDirs)
@@ -258,6 +283,18 @@ func HandleNoRecurs(prefix, relPath string) (*LsoEnt, error) {
 	return &LsoEnt{Name: relPath, Flags: apc.EntryIsDir}, nil
 }
 
+// arbitrary delimiter (apc.LsoMsg.Delimiter) helper function:
+// returns common prefix - object name up to and including the first occurrence
+// of the delimiter after the listed prefix - or empty string if there's none
+func CommonPrefix(objName, prefix, delim string) string {
+	debug.Assert(delim != "" && strings.HasPrefix(objName, prefix))
+	i := strings.Index(objName[len(prefix):], delim)
+	if i < 0 {
+		return ""
+	}
+	return objName[:len(prefix)+i+len(delim)]
+}
+
 //
 // LsoEnt.Custom ------------------------------------------------------------
 // e.g. "[ETag:67c24314d6587da16bfa50dd4d2f6a0a LastModified:2023-09-20T21:04:51Z]
@@ -276,6 +313,16 @@ var (
 	stdCustomProps = [...]string{SourceObjMD, ETag, LastModified, CRC32CObjMD, MD5ObjMD, VersionObjMD}
 )
 
+// whether a given custom key is system-supported (ie., not user-defined)
+func IsStdCustom(key string) bool {
+	for _, k := range stdCustomProps {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
 // (compare w/ CustomProps2S below)
 func CustomMD2S(md cos.StrKVs) string {
 	var (
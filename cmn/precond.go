@@ -0,0 +1,147 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Conditional requests (RFC 9110, section 13): If-Match, If-None-Match, If-Modified-Since, If-Unmodified-Since
+// - entity tag (as in: ETag) of a given object is any of the following:
+//   remote ETag (custom metadata), checksum value, and version;
+// - comparison ignores quotes and weak ("W/") prefix;
+// - last-modified time is the remote LastModified (custom metadata), if available;
+//   otherwise, the time the object was last written in-cluster;
+// - preconditions are evaluated by the targets under object lock.
+
+const etagAny = "*"
+
+type Precond struct {
+	IfModSince   time.Time
+	IfUnmodSince time.Time
+	IfMatch      []string // unquoted tags
+	IfNoneMatch  []string // ditto
+}
+
+// returns nil when none of the conditional headers is present
+func ParsePrecond(hdr http.Header) (*Precond, error) {
+	var (
+		pc    Precond
+		found bool
+	)
+	if v := hdr.Get(cos.HdrIfMatch); v != "" {
+		pc.IfMatch, found = parseETags(v), true
+	}
+	if v := hdr.Get(cos.HdrIfNoneMatch); v != "" {
+		pc.IfNoneMatch, found = parseETags(v), true
+	}
+	if v := hdr.Get(cos.HdrIfModifiedSince); v != "" {
+		t, err := http.ParseTime(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %v", cos.HdrIfModifiedSince, v, err)
+		}
+		pc.IfModSince, found = t, true
+	}
+	if v := hdr.Get(cos.HdrIfUnmodifiedSince); v != "" {
+		t, err := http.ParseTime(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %v", cos.HdrIfUnmodifiedSince, v, err)
+		}
+		pc.IfUnmodSince, found = t, true
+	}
+	if !found {
+		return nil, nil
+	}
+	return &pc, nil
+}
+
+func parseETags(s string) (tags []string) {
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.TrimSpace(tag)
+		tag = strings.TrimPrefix(tag, "W/")
+		if tag = UnquoteCEV(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Eval follows the evaluation order defined in RFC 9110, section 13.2.2
+//   - oah is nil when the object does not exist
+//   - zero mtime when unknown (time-based conditions do not apply)
+//   - returns 0 (proceed), http.StatusNotModified (GET and HEAD only), or http.StatusPreconditionFailed,
+//     along with the failed condition (header name)
+func (pc *Precond) Eval(method string, oah cos.OAH, mtime time.Time) (int, string) {
+	var (
+		exists = oah != nil
+		read   = method == http.MethodGet || method == http.MethodHead
+	)
+	mtime = mtime.Truncate(time.Second) // (HTTP-date resolution)
+
+	// 1. If-Match, or else If-Unmodified-Since
+	if len(pc.IfMatch) > 0 {
+		if !exists || !matchETags(pc.IfMatch, oah) {
+			return http.StatusPreconditionFailed, cos.HdrIfMatch
+		}
+	} else if !pc.IfUnmodSince.IsZero() && exists && !mtime.IsZero() && mtime.After(pc.IfUnmodSince) {
+		return http.StatusPreconditionFailed, cos.HdrIfUnmodifiedSince
+	}
+
+	// 2. If-None-Match, or else If-Modified-Since (GET and HEAD only)
+	if len(pc.IfNoneMatch) > 0 {
+		if exists && matchETags(pc.IfNoneMatch, oah) {
+			if read {
+				return http.StatusNotModified, cos.HdrIfNoneMatch
+			}
+			return http.StatusPreconditionFailed, cos.HdrIfNoneMatch
+		}
+	} else if read && !pc.IfModSince.IsZero() && exists && !mtime.IsZero() && !mtime.After(pc.IfModSince) {
+		return http.StatusNotModified, cos.HdrIfModifiedSince
+	}
+	return 0, ""
+}
+
+func matchETags(tags []string, oah cos.OAH) bool {
+	var (
+		version  = oah.Version(true)
+		etag, _  = oah.GetCustomKey(ETag)
+		cksumVal string
+	)
+	etag = UnquoteCEV(etag)
+	if cksum := oah.Checksum(); cksum != nil && cksum.Ty() != cos.ChecksumNone {
+		cksumVal = cksum.Val()
+	}
+	for _, tag := range tags {
+		switch tag {
+		case etagAny:
+			return true
+		case "":
+		case etag, cksumVal, version:
+			return true
+		}
+	}
+	return false
+}
+
+// remote LastModified, if available
+func ObjLastModified(oah cos.OAH) (mtime time.Time) {
+	v, ok := oah.GetCustomKey(LastModified)
+	if !ok || v == "" {
+		return
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return t
+	}
+	return
+}
@@ -0,0 +1,88 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Bucket-to-bucket continuous replication
+// - persistent (bucket property) rule: writes (PUT, APPEND, copy, rename, etc.) and deletions
+//   in the source bucket are asynchronously replayed to the destination bucket;
+// - destination: ais:// bucket in the same cluster, bucket in a remote AIS cluster
+//   (e.g., "ais://@remais/dst"), or Cloud bucket;
+// - each replicated object carries ReplObjMD custom attribute (see ReplConf.Tag) that
+//   tells whether its current content has been replicated (see also apc.EntryReplicated);
+// - see docs/replication.md for details.
+
+type (
+	ReplConf struct {
+		Dest    string `json:"dest,omitempty"` // destination bucket, e.g.: "ais://dst", "ais://@remais/dst", "s3://dst"
+		Enabled bool   `json:"enabled"`
+	}
+	ReplConfToSet struct {
+		Dest    *string `json:"dest"`
+		Enabled *bool   `json:"enabled"`
+	}
+)
+
+// interface guard
+var _ PropsValidator = (*ReplConf)(nil)
+
+func (c *ReplConf) ValidateAsProps(...any) error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Dest == "" {
+		return errors.New("replication: destination bucket is not specified")
+	}
+	_, err := c.DestBck()
+	return err
+}
+
+func (c *ReplConf) DestBck() (bck Bck, err error) {
+	var objName string
+	bck, objName, err = ParseBckObjectURI(c.Dest, ParseURIOpts{})
+	if err != nil {
+		return bck, fmt.Errorf("replication: invalid destination %q: %v", c.Dest, err)
+	}
+	if objName != "" {
+		return bck, fmt.Errorf("replication: invalid destination %q: expecting bucket (not object) name", c.Dest)
+	}
+	if err = bck.Validate(); err != nil {
+		return bck, fmt.Errorf("replication: invalid destination %q: %v", c.Dest, err)
+	}
+	return bck, nil
+}
+
+// Tag identifies the object's content replicated to a given destination;
+// stored in the source object's custom metadata (under ReplObjMD) upon successful replication
+func (c *ReplConf) Tag(oah cos.OAH) string {
+	var cksum string
+	if ck := oah.Checksum(); ck != nil {
+		cksum = ck.Value()
+	}
+	return c.Dest + "|" + strconv.FormatInt(oah.Lsize(true), 10) + "|" + oah.Version(true) + "|" + cksum
+}
+
+// whether the object's current content has been replicated
+func (c *ReplConf) IsReplicated(oah cos.OAH) bool {
+	v, ok := oah.GetCustomKey(ReplObjMD)
+	return ok && v == c.Tag(oah)
+}
+
+// whether replicating the object's current content can be skipped (already replicated) -
+// requires checksum or version: without either, a same-size overwrite keeps the tag
+func (c *ReplConf) CanSkip(oah cos.OAH) bool {
+	if oah.Checksum().IsEmpty() && oah.Version(true) == "" {
+		return false
+	}
+	return c.IsReplicated(oah)
+}
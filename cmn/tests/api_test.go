@@ -5,8 +5,11 @@
 package tests_test
 
 import (
+	"time"
+
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -184,4 +187,158 @@ var _ = Describe("API", func() {
 			),
 		)
 	})
+
+	Describe("CopyBckMsg", func() {
+		It("should validate source object filter", func() {
+			Expect((&apc.CopyBckMsg{}).Validate()).NotTo(HaveOccurred())
+			msg := &apc.CopyBckMsg{Filter: &apc.ObjFilter{
+				Regex:     `^train/.*\.tar$`,
+				MinSize:   1,
+				MaxSize:   cos.GiB,
+				NewerThan: "2024-01-01T00:00:00Z",
+				OlderThan: "2024-06-01T00:00:00Z",
+				CustomMD:  cos.StrKVs{"label": "cat"},
+			}}
+			Expect(msg.Validate()).NotTo(HaveOccurred())
+			Expect(msg.Filter.NeedsAttrs()).To(BeTrue())
+			Expect((&apc.ObjFilter{Regex: "abc"}).NeedsAttrs()).To(BeFalse())
+
+			newer, older := msg.Filter.TimeBounds()
+			Expect(newer).To(BeNumerically("<", older))
+			Expect(newer).To(Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()))
+
+			msg.Sync = true
+			Expect(msg.Validate()).To(MatchError(ContainSubstring("synchronize")))
+		})
+
+		DescribeTable("should fail to validate filter",
+			func(flt apc.ObjFilter) {
+				msg := &apc.CopyBckMsg{Filter: &flt}
+				Expect(msg.Validate()).To(HaveOccurred())
+			},
+			Entry("invalid regex", apc.ObjFilter{Regex: "[a-"}),
+			Entry("negative size", apc.ObjFilter{MaxSize: -1}),
+			Entry("min-size > max-size", apc.ObjFilter{MinSize: 2, MaxSize: 1}),
+			Entry("not RFC3339", apc.ObjFilter{OlderThan: "2024-01-01"}),
+			Entry("empty time range", apc.ObjFilter{NewerThan: "2024-01-01T00:00:00Z", OlderThan: "2024-01-01T00:00:00Z"}),
+		)
+
+		It("should generate destination names", func() {
+			msg := &apc.TCBMsg{Ext: cos.StrKVs{"jpg": ".png"}, CopyBckMsg: apc.CopyBckMsg{Prepend: "dst/"}}
+			Expect(msg.ToName("a/b.jpg")).To(Equal("dst/a/b.png"))
+			Expect(msg.ToName("a/b.tar")).To(Equal("dst/a/b.tar"))
+			Expect(msg.Validate(true)).To(HaveOccurred())
+			Expect(msg.Validate(false)).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("GetBatchMsg", func() {
+		bck := cmn.Bck{Name: "abc", Provider: apc.AIS}
+
+		It("should fill in default bucket and name entries", func() {
+			msg := &cmn.GetBatchMsg{Entries: []cmn.GetBatchEntry{
+				{ObjName: "a/1.tar", ArchPath: "x/y.jpg"},
+				{Bck: cmn.Bck{Name: "def", Provider: "s3"}, ObjName: "b", Length: 10},
+			}}
+			Expect(msg.Init(&bck)).NotTo(HaveOccurred())
+			Expect(msg.Entries[0].Bck).To(Equal(bck))
+			Expect(msg.Entries[1].Bck.Provider).To(Equal(apc.AWS))
+			Expect(msg.Entries[0].NameInArch(false)).To(Equal("abc/a/1.tar/x/y.jpg"))
+			Expect(msg.Entries[1].NameInArch(true)).To(Equal("b"))
+			Expect(msg.Entries[1].IsRange()).To(BeTrue())
+		})
+
+		DescribeTable("should fail to validate",
+			func(entries []cmn.GetBatchEntry) {
+				msg := &cmn.GetBatchMsg{Entries: entries}
+				Expect(msg.Init(&bck)).To(HaveOccurred())
+			},
+			Entry("no entries", nil),
+			Entry("empty object name", []cmn.GetBatchEntry{{}}),
+			Entry("negative range", []cmn.GetBatchEntry{{ObjName: "a", Start: -1}}),
+			Entry("archpath and range", []cmn.GetBatchEntry{{ObjName: "a.tar", ArchPath: "b", Length: 1}}),
+			Entry("invalid provider", []cmn.GetBatchEntry{{Bck: cmn.Bck{Name: "x", Provider: "xyz"}, ObjName: "a"}}),
+		)
+	})
+
+	Describe("ComposeMsg", func() {
+		bck := cmn.Bck{Name: "abc", Provider: apc.AIS}
+
+		It("should fill in default bucket", func() {
+			msg := &cmn.ComposeMsg{Sources: []cmn.GetBatchEntry{
+				{ObjName: "part-1"},
+				{Bck: cmn.Bck{Name: "def", Provider: "gs"}, ObjName: "part-2", Start: 100},
+			}}
+			Expect(msg.Init(&bck)).NotTo(HaveOccurred())
+			Expect(msg.Sources[0].Bck).To(Equal(bck))
+			Expect(msg.Sources[1].Bck.Provider).To(Equal(apc.GCP))
+		})
+
+		It("should fail to validate", func() {
+			Expect((&cmn.ComposeMsg{}).Init(&bck)).To(HaveOccurred())
+			msg := &cmn.ComposeMsg{Sources: []cmn.GetBatchEntry{{ObjName: "a"}, {ObjName: "b", Length: -1}}}
+			Expect(msg.Init(&bck)).To(MatchError(ContainSubstring("compose")))
+		})
+	})
+
+	Describe("CopyRangeMsg", func() {
+		bck := cmn.Bck{Name: "abc", Provider: apc.AIS}
+
+		It("should fill in default bucket and validate", func() {
+			msg := &cmn.CopyRangeMsg{Source: cmn.GetBatchEntry{ObjName: "src", Start: 10, Length: 4096}, Offset: 1024}
+			Expect(msg.Init(&bck)).NotTo(HaveOccurred())
+			Expect(msg.Source.Bck).To(Equal(bck))
+
+			msg = &cmn.CopyRangeMsg{Source: cmn.GetBatchEntry{ObjName: "src"}, Offset: -1}
+			Expect(msg.Init(&bck)).To(HaveOccurred())
+			msg = &cmn.CopyRangeMsg{}
+			Expect(msg.Init(&bck)).To(HaveOccurred())
+		})
+	})
+
+	Describe("ReplConf", func() {
+		It("should validate destination", func() {
+			Expect((&cmn.ReplConf{}).ValidateAsProps()).NotTo(HaveOccurred())
+			Expect((&cmn.ReplConf{Enabled: true}).ValidateAsProps()).To(HaveOccurred())
+			Expect((&cmn.ReplConf{Dest: "ais://dst/obj", Enabled: true}).ValidateAsProps()).To(HaveOccurred())
+			Expect((&cmn.ReplConf{Dest: "xyz://dst", Enabled: true}).ValidateAsProps()).To(HaveOccurred())
+
+			conf := &cmn.ReplConf{Dest: "ais://@remais/dst", Enabled: true}
+			Expect(conf.ValidateAsProps()).NotTo(HaveOccurred())
+			bck, err := conf.DestBck()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bck.IsRemoteAIS()).To(BeTrue())
+			Expect(bck.Name).To(Equal("dst"))
+		})
+
+		It("should tell replicated content", func() {
+			var (
+				conf = &cmn.ReplConf{Dest: "s3://dst", Enabled: true}
+				oa   = &cmn.ObjAttrs{Size: 1024, Cksum: cos.NewCksum(cos.ChecksumXXHash, "a1b2c3")}
+			)
+			oa.SetVersion("1")
+			Expect(conf.IsReplicated(oa)).To(BeFalse())
+			oa.SetCustomKey(cmn.ReplObjMD, conf.Tag(oa))
+			Expect(conf.IsReplicated(oa)).To(BeTrue())
+
+			oa.SetVersion("2") // overwritten
+			Expect(conf.IsReplicated(oa)).To(BeFalse())
+			oa.SetVersion("1")
+			Expect((&cmn.ReplConf{Dest: "ais://dst", Enabled: true}).IsReplicated(oa)).To(BeFalse())
+		})
+
+		It("should not skip content that has neither checksum nor version", func() {
+			var (
+				conf = &cmn.ReplConf{Dest: "s3://dst", Enabled: true}
+				oa   = &cmn.ObjAttrs{Size: 1024, Cksum: cos.NewCksum(cos.ChecksumNone, "")}
+			)
+			oa.SetCustomKey(cmn.ReplObjMD, conf.Tag(oa))
+			Expect(conf.IsReplicated(oa)).To(BeTrue())
+			Expect(conf.CanSkip(oa)).To(BeFalse()) // (same-size overwrite keeps the tag)
+
+			oa.SetVersion("1")
+			oa.SetCustomKey(cmn.ReplObjMD, conf.Tag(oa))
+			Expect(conf.CanSkip(oa)).To(BeTrue())
+		})
+	})
 })
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -72,3 +73,48 @@ func TestValidateMpath(t *testing.T) {
 		}
 	}
 }
+
+func TestMetricsLatencyBuckets(t *testing.T) {
+	conf := cmn.MetricsConf{}
+	bounds, err := conf.LatencyBounds()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(bounds) > 1 && bounds[0] == time.Millisecond, "unexpected default buckets %v", bounds)
+
+	conf.LatencyBuckets = "500us, 1ms,2.5ms"
+	bounds, err = conf.LatencyBounds()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(bounds) == 3 && bounds[2] == 2500*time.Microsecond, "unexpected buckets %v", bounds)
+
+	for _, s := range []string{"1ms, 1ms", "10ms, 1ms", "-1s", "1ms, abc"} {
+		conf.LatencyBuckets = s
+		if err := conf.Validate(); err == nil {
+			t.Errorf("validation of invalid latency buckets %q succeeded", s)
+		}
+	}
+}
+
+func TestFailureDomainValidate(t *testing.T) {
+	for _, fd := range []cmn.FailureDomain{{}, {Zone: "us-east-1a"}, {Zone: "z1", Rack: "rack_07"}, {Rack: "r1.a"}} {
+		tassert.CheckError(t, fd.Validate())
+	}
+	for _, fd := range []cmn.FailureDomain{{Zone: "z/1"}, {Zone: "z1", Rack: "r 1"}, {Rack: "r..1"}} {
+		if err := fd.Validate(); err == nil {
+			t.Errorf("validation of invalid failure domain %+v succeeded", fd)
+		}
+	}
+
+	// config validation
+	oldConfig := cmn.GCO.Get()
+	defer func() {
+		cmn.GCO.BeginUpdate()
+		cmn.GCO.CommitUpdate(oldConfig)
+	}()
+	confPath := filepath.Join(thisFileDir(t), "configs", "config.json")
+	localConfPath := filepath.Join(thisFileDir(t), "configs", "confignet.json")
+	config := cmn.Config{}
+	tassert.CheckFatal(t, cmn.LoadConfig(confPath, localConfPath, apc.Proxy, &config))
+	config.FD = cmn.FailureDomain{Zone: "z1", Rack: "r/1"}
+	if err := config.Validate(); err == nil {
+		t.Error("validation of config with invalid failure domain succeeded")
+	}
+}
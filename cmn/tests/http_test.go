@@ -5,10 +5,14 @@
 package tests_test
 
 import (
+	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 )
 
 func TestMatchRESTItems(t *testing.T) {
@@ -84,3 +88,176 @@ func TestMatchRESTItems(t *testing.T) {
 		}
 	}
 }
+
+func TestPrecondEval(t *testing.T) {
+	var (
+		mtime = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		oa    = &cmn.ObjAttrs{Cksum: cos.NewCksum(cos.ChecksumMD5, "abc"), Ver: apc.Ptr("2")}
+		early = mtime.Add(-time.Hour).Format(http.TimeFormat)
+		late  = mtime.Add(time.Hour).Format(http.TimeFormat)
+	)
+	oa.SetCustomKey(cmn.ETag, `"etag-1"`)
+
+	tests := []struct {
+		name   string
+		hdr    map[string]string
+		method string
+		absent bool
+		ecode  int
+	}{
+		{name: "unconditional", hdr: nil, method: http.MethodGet, ecode: 0},
+		{name: "if-match-etag", hdr: map[string]string{cos.HdrIfMatch: `"x", "etag-1"`}, method: http.MethodPut, ecode: 0},
+		{name: "if-match-cksum", hdr: map[string]string{cos.HdrIfMatch: `"abc"`}, method: http.MethodGet, ecode: 0},
+		{name: "if-match-version", hdr: map[string]string{cos.HdrIfMatch: "2"}, method: http.MethodGet, ecode: 0},
+		{name: "if-match-mismatch", hdr: map[string]string{cos.HdrIfMatch: `"xyz"`}, method: http.MethodPut, ecode: http.StatusPreconditionFailed},
+		{name: "if-match-absent", hdr: map[string]string{cos.HdrIfMatch: "*"}, method: http.MethodPut, absent: true, ecode: http.StatusPreconditionFailed},
+		{name: "create-only", hdr: map[string]string{cos.HdrIfNoneMatch: "*"}, method: http.MethodPut, ecode: http.StatusPreconditionFailed},
+		{name: "create-only-absent", hdr: map[string]string{cos.HdrIfNoneMatch: "*"}, method: http.MethodPut, absent: true, ecode: 0},
+		{name: "if-none-match-get", hdr: map[string]string{cos.HdrIfNoneMatch: `W/"etag-1"`}, method: http.MethodGet, ecode: http.StatusNotModified},
+		{name: "if-none-match-other", hdr: map[string]string{cos.HdrIfNoneMatch: `"etag-2"`}, method: http.MethodHead, ecode: 0},
+		{name: "not-modified-since", hdr: map[string]string{cos.HdrIfModifiedSince: late}, method: http.MethodGet, ecode: http.StatusNotModified},
+		{name: "modified-since", hdr: map[string]string{cos.HdrIfModifiedSince: early}, method: http.MethodGet, ecode: 0},
+		{name: "modified-since-put", hdr: map[string]string{cos.HdrIfModifiedSince: late}, method: http.MethodPut, ecode: 0},
+		{name: "unmodified-since", hdr: map[string]string{cos.HdrIfUnmodifiedSince: early}, method: http.MethodPut, ecode: http.StatusPreconditionFailed},
+		{
+			name:   "if-none-match-precedence",
+			hdr:    map[string]string{cos.HdrIfNoneMatch: `"etag-2"`, cos.HdrIfModifiedSince: late},
+			method: http.MethodGet, ecode: 0,
+		},
+	}
+	for _, test := range tests {
+		hdr := http.Header{}
+		for k, v := range test.hdr {
+			hdr.Set(k, v)
+		}
+		pc, err := cmn.ParsePrecond(hdr)
+		if err != nil {
+			t.Fatalf("test: %s, err: %v", test.name, err)
+		}
+		if pc == nil {
+			if test.hdr != nil {
+				t.Fatalf("test: %s, expected preconditions", test.name)
+			}
+			continue
+		}
+		var oah cos.OAH = oa
+		if test.absent {
+			oah = nil
+		}
+		if ecode, _ := pc.Eval(test.method, oah, mtime); ecode != test.ecode {
+			t.Fatalf("test: %s, expected %d, got %d", test.name, test.ecode, ecode)
+		}
+	}
+
+	if _, err := cmn.ParsePrecond(http.Header{cos.HdrIfModifiedSince: []string{"yesterday"}}); err == nil {
+		t.Fatal("expected invalid If-Modified-Since to fail")
+	}
+}
+
+func TestCORSMatch(t *testing.T) {
+	conf := cmn.CORSConf{Rules: []cmn.CORSRule{
+		{
+			ID:             "app",
+			AllowedOrigins: []string{"https://*.example.com"},
+			AllowedMethods: []string{http.MethodGet, http.MethodPut},
+			AllowedHeaders: []string{"Content-*", "x-amz-date"},
+		},
+		{
+			ID:             "public",
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{http.MethodGet},
+		},
+	}}
+	if err := conf.ValidateAsProps(); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		origin, method string
+		headers        []string
+		id             string // "" - no match
+	}{
+		{"https://app.example.com", http.MethodPut, []string{"content-type", "X-Amz-Date"}, "app"},
+		{"https://app.example.com", http.MethodPut, []string{"authorization"}, ""},
+		{"https://app.example.com", http.MethodDelete, nil, ""},
+		{"https://example.com", http.MethodGet, nil, "public"},
+		{"http://other.org", http.MethodGet, []string{"range"}, ""},
+		{"http://other.org", http.MethodGet, nil, "public"},
+	}
+	for _, test := range tests {
+		var id string
+		if rule := conf.Match(test.origin, test.method, test.headers); rule != nil {
+			id = rule.ID
+		}
+		if id != test.id {
+			t.Fatalf("%s %s %v: expected rule %q, got %q", test.method, test.origin, test.headers, test.id, id)
+		}
+	}
+
+	invalid := []cmn.CORSRule{
+		{AllowedMethods: []string{http.MethodGet}},
+		{AllowedOrigins: []string{"*"}, AllowedMethods: []string{http.MethodPatch}},
+		{AllowedOrigins: []string{"https://*.*.com"}, AllowedMethods: []string{http.MethodGet}},
+	}
+	for i := range invalid {
+		c := cmn.CORSConf{Rules: invalid[i : i+1]}
+		if err := c.ValidateAsProps(); err == nil {
+			t.Fatalf("expected invalid rule %+v to fail", invalid[i])
+		}
+	}
+}
+
+func TestEventRules(t *testing.T) {
+	conf := cmn.EventsConf{Rules: []cmn.EventRule{
+		{ID: "images", Events: []string{cmn.EventObjCreated}, Prefix: "img/", Suffix: ".jpg"},
+		{ID: "all", Webhook: "https://example.com/hook"},
+	}}
+	if err := conf.ValidateAsProps(); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		event, name string
+		ids         []string
+	}{
+		{cmn.EventObjCreated, "img/a.jpg", []string{"images", "all"}},
+		{cmn.EventObjRemoved, "img/a.jpg", []string{"all"}},
+		{cmn.EventObjCreated, "img/a.png", []string{"all"}},
+		{cmn.EventColdGet, "a.jpg", []string{"all"}},
+	}
+	for _, test := range tests {
+		var ids []string
+		for i := range conf.Rules {
+			if conf.Rules[i].Match(test.event, test.name) {
+				ids = append(ids, conf.Rules[i].ID)
+			}
+		}
+		if !reflect.DeepEqual(ids, test.ids) {
+			t.Fatalf("%s %q: expected rules %v, got %v", test.event, test.name, test.ids, ids)
+		}
+	}
+	if conf.Rule("all") == nil || conf.Rule("none") != nil {
+		t.Fatal("rule lookup by ID failed")
+	}
+
+	invalid := [][]cmn.EventRule{
+		{{Events: []string{cmn.EventObjCreated}}},
+		{{ID: "a/b"}},
+		{{ID: "x", Events: []string{"ObjectRestored"}}},
+		{{ID: "x", Webhook: "ftp://example.com"}},
+		{{ID: "x"}, {ID: "x"}},
+	}
+	for _, rules := range invalid {
+		c := cmn.EventsConf{Rules: rules}
+		if err := c.ValidateAsProps(); err == nil {
+			t.Fatalf("expected invalid rules %+v to fail", rules)
+		}
+	}
+
+	seqs := map[string]int64{"t1": 10, "t2": 3}
+	out, err := cmn.DecodeEventsToken(cmn.EncodeEventsToken(seqs))
+	if err != nil || !reflect.DeepEqual(out, seqs) {
+		t.Fatalf("token round-trip: %v, %v", out, err)
+	}
+	if _, err := cmn.DecodeEventsToken("not-a-token"); err == nil {
+		t.Fatal("expected invalid token to fail")
+	}
+}
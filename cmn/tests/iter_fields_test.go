@@ -102,6 +102,17 @@ var _ = Describe("IterFields", func() {
 
 					"write_policy.data": apc.WritePolicy(""),
 					"write_policy.md":   apc.WritePolicy(""),
+
+					"rate_limit.max_rps": 0,
+					"rate_limit.max_bps": cos.SizeIEC(0),
+					"rate_limit.enabled": false,
+
+					"cors.rules": []cmn.CORSRule(nil),
+
+					"events.rules": []cmn.EventRule(nil),
+
+					"replication.dest":    "",
+					"replication.enabled": false,
 				},
 			),
 			Entry("list BpropsToSet fields",
@@ -156,6 +167,17 @@ var _ = Describe("IterFields", func() {
 					"write_policy.data": (*apc.WritePolicy)(nil),
 					"write_policy.md":   apc.Ptr(apc.WriteDelayed),
 
+					"rate_limit.max_rps": (*int)(nil),
+					"rate_limit.max_bps": (*cos.SizeIEC)(nil),
+					"rate_limit.enabled": (*bool)(nil),
+
+					"cors.rules": (*[]cmn.CORSRule)(nil),
+
+					"events.rules": (*[]cmn.EventRule)(nil),
+
+					"replication.dest":    (*string)(nil),
+					"replication.enabled": (*bool)(nil),
+
 					"extra.hdfs.ref_directory": (*string)(nil),
 					"extra.aws.cloud_region":   (*string)(nil),
 					"extra.aws.endpoint":       (*string)(nil),
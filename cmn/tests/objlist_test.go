@@ -0,0 +1,107 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+)
+
+func TestCommonPrefix(t *testing.T) {
+	tests := []struct {
+		name, prefix, delim string
+		cp                  string // "" - not rolled up
+	}{
+		{"a|b|c", "", "|", "a|"},
+		{"a|b|c", "a|", "|", "a|b|"},
+		{"a|b|c", "a|b|", "|", ""},
+		{"a|", "", "|", "a|"},
+		{"abc", "", "|", ""},
+		{"photos_2024_jan.jpg", "photos_", "_", "photos_2024_"},
+		{"x--y--z", "x-", "--", "x--y--"},
+		{"x--y--z", "", "--", "x--"},
+		{"dir/obj", "", "/", "dir/"},
+	}
+	for _, test := range tests {
+		if cp := cmn.CommonPrefix(test.name, test.prefix, test.delim); cp != test.cp {
+			t.Errorf("%q (prefix %q, delimiter %q): expected %q, got %q", test.name, test.prefix, test.delim, test.cp, cp)
+		}
+	}
+}
+
+func TestLsoMsgDelim(t *testing.T) {
+	msg := &apc.LsoMsg{Delimiter: "/"}
+	if err := msg.InitDelim(); err != nil || !msg.IsFlagSet(apc.LsNoRecursion) || msg.IsDelimAny() {
+		t.Fatalf("delimiter '/' must imply no-recursion: %v", err)
+	}
+	msg = &apc.LsoMsg{Flags: apc.LsNoRecursion}
+	if msg.Delim() != "/" {
+		t.Fatalf("no-recursion implies delimiter '/', got %q", msg.Delim())
+	}
+	msg = &apc.LsoMsg{Delimiter: "|"}
+	if err := msg.InitDelim(); err != nil || !msg.IsDelimAny() || msg.Delim() != "|" {
+		t.Fatalf("unexpected: %v, %q", err, msg.Delim())
+	}
+	msg.SetFlag(apc.LsNoRecursion)
+	if err := msg.InitDelim(); err == nil {
+		t.Fatal("expected no-recursion vs delimiter '|' to fail")
+	}
+}
+
+func TestArchSplitPrefix(t *testing.T) {
+	tests := []struct {
+		prefix, shard, archPrefix string
+		ok                        bool
+	}{
+		{"A.tar/tutorials/", "A.tar", "tutorials/", true},
+		{"dir/A.tar.gz/tutorials/01", "dir/A.tar.gz", "tutorials/01", true},
+		{"A.zip/", "A.zip", "", true},
+		{"A.tar", "", "", false},
+		{"dir/A.tarball/x", "", "", false},
+		{"", "", "", false},
+	}
+	for _, test := range tests {
+		shard, archPrefix, ok := archive.SplitPrefix(test.prefix)
+		if shard != test.shard || archPrefix != test.archPrefix || ok != test.ok {
+			t.Errorf("%q: expected (%q, %q, %t), got (%q, %q, %t)",
+				test.prefix, test.shard, test.archPrefix, test.ok, shard, archPrefix, ok)
+		}
+	}
+}
+
+func TestArchMatcher(t *testing.T) {
+	tests := []struct {
+		regex, mmode, filename string
+		match                  bool
+	}{
+		{`^a/.*\.jpg$`, "", "a/b.jpg", true},
+		{`^a/.*\.jpg$`, "", "b/a/b.jpg", false},
+		{`^a/.*\.jpg$`, "regexp", "a/b.jpeg", false},
+		{"a/", "prefix", "a/b.jpg", true},
+		{".jpg", "suffix", "a/b.jpg", true},
+		{"b.j", "substr", "a/b.jpg", true},
+		{"a/b", "wdskey", "a/b.jpg", true},
+		{"a/b", "wdskey", "a/bc.jpg", false},
+		{"*", "", "anything", true},
+	}
+	for _, test := range tests {
+		match, err := archive.NewMatcher(test.regex, test.mmode)
+		if err != nil {
+			t.Fatalf("%q (%q): %v", test.regex, test.mmode, err)
+		}
+		if match(test.filename) != test.match {
+			t.Errorf("%q (%q) vs %q: expected match=%t", test.regex, test.mmode, test.filename, test.match)
+		}
+	}
+	if _, err := archive.NewMatcher("a", "glob"); err == nil {
+		t.Error("expected invalid matching mode to fail")
+	}
+	if _, err := archive.NewMatcher("a(", ""); err == nil {
+		t.Error("expected invalid regex to fail")
+	}
+}
@@ -45,6 +45,10 @@ const (
 	LcacheEvictedCount   = "lcache.evicted.n"
 	LcacheErrCount       = "err.lcache.n" // errPrefix + "lcache.n"
 	LcacheFlushColdCount = "lcache.flush.cold.n"
+
+	// latency histograms (stats.KindHistogram)
+	ECSendLatencyHist  = "ec.send.ns.hist"
+	RebSendLatencyHist = "reb.send.ns.hist"
 )
 
 type (
@@ -6,6 +6,7 @@ package meta
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -19,6 +20,28 @@ import (
 // aka highest random weight (HRW)
 // See also: fs/hrw.go
 
+// Capacity-weighted HRW (aka weighted rendezvous hashing):
+// - target's HRW is computed as weight / -ln(hash), with the hash mapped onto (0, 1),
+//   so that the share of objects placed on a target is proportional to its weight;
+// - targets that do not advertise their weights (see Snode.Weight) count as DefaultHrwWeight;
+// - with all (active) targets having the same weight the above is a monotonic function
+//   of the hash, and so the placement is identical to the unweighted one (which is
+//   why the latter is used as a shortcut - determined once per Smap version);
+// - the weights are part of the (versioned) cluster map: a change in any target's weight
+//   (including a target joining without one) results in a new Smap version and rebalance,
+//   which then moves only the objects that map to (or from) the target in question.
+
+// the weight of a target that does not advertise one; same as 1TiB of capacity
+// (see cmn.HrwWeightCapacity)
+const DefaultHrwWeight = 1024
+
+// Smap.hrwMode
+const (
+	hrwUnknown = iota // not initialized: determine on the fly (see hrwMax)
+	hrwUniform        // all active targets have the same weight
+	hrwWeighted
+)
+
 func (smap *Smap) HrwName2T(uname []byte) (*Snode, error) {
 	digest := xxhash.Checksum64S(uname, cos.MLCG32)
 	return smap.HrwHash2T(digest)
@@ -38,18 +61,14 @@ func (smap *Smap) HrwMultiHome(uname []byte) (si *Snode, netName string, err err
 }
 
 func (smap *Smap) HrwHash2T(digest uint64) (si *Snode, err error) {
-	var maxH uint64
+	hmax := hrwMax{mode: smap.hrwMode}
 	for _, tsi := range smap.Tmap {
 		if tsi.InMaintOrDecomm() { // always skipping targets 'in maintenance mode'
 			continue
 		}
-		cs := xoshiro256.Hash(tsi.Digest() ^ digest)
-		if cs >= maxH {
-			maxH = cs
-			si = tsi
-		}
+		hmax.add(tsi, xoshiro256.Hash(tsi.Digest()^digest))
 	}
-	if si == nil {
+	if si = hmax.get(); si == nil {
 		err = cmn.NewErrNoNodes(apc.Target, len(smap.Tmap))
 	}
 	return si, err
@@ -57,15 +76,11 @@ func (smap *Smap) HrwHash2T(digest uint64) (si *Snode, err error) {
 
 // NOTE: including targets 'in maintenance mode', if any
 func (smap *Smap) HrwHash2Tall(digest uint64) (si *Snode, err error) {
-	var maxH uint64
+	var hmax hrwMax
 	for _, tsi := range smap.Tmap {
-		cs := xoshiro256.Hash(tsi.Digest() ^ digest)
-		if cs >= maxH {
-			maxH = cs
-			si = tsi
-		}
+		hmax.add(tsi, xoshiro256.Hash(tsi.Digest()^digest))
 	}
-	if si == nil {
+	if si = hmax.get(); si == nil {
 		err = cmn.NewErrNoNodes(apc.Target, len(smap.Tmap))
 	}
 	return si, err
@@ -163,14 +178,22 @@ func (smap *Smap) HrwTargetList(uname *string, count int) (sis Nodes, err error)
 	}
 	b := cos.UnsafeBptr(uname)
 	digest := xxhash.Checksum64S(*b, cos.MLCG32)
+	weighted := smap.weighted()
+	if smap.HasFDs() {
+		sis = smap.hrwSpread(digest, count, weighted)
+		if count != cnt && len(sis) < count {
+			err = fmt.Errorf(fmterr, cmn.ErrNotEnoughTargets, count, len(sis), smap)
+			return nil, err
+		}
+		return sis, nil
+	}
 	hlist := newHrwList(count)
 
 	for _, tsi := range smap.Tmap {
-		cs := xoshiro256.Hash(tsi.Digest() ^ digest)
 		if tsi.InMaintOrDecomm() {
 			continue
 		}
-		hlist.add(cs, tsi)
+		hlist.add(tsi.hrw(digest, weighted), tsi)
 	}
 	sis = hlist.get()
 	if count != cnt && len(sis) < count {
@@ -180,6 +203,156 @@ func (smap *Smap) HrwTargetList(uname *string, count int) (sis Nodes, err error)
 	return sis, nil
 }
 
+// whether any of the targets advertises its failure domain (see cmn.FailureDomain)
+func (smap *Smap) HasFDs() bool {
+	for _, tsi := range smap.Tmap {
+		if !tsi.FD.IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+// failure-domain aware variant of the above: given all targets sorted by HRW, select
+// targets in rounds, whereby each round takes (in the HRW order):
+// - first, one target per zone;
+// - second, one target per (zone, rack);
+// and so on, until selected - resulting in EC slices (and replicas) spread evenly
+// across zones and racks, with the HRW target always first in the list
+func (smap *Smap) hrwSpread(digest uint64, count int, weighted bool) Nodes {
+	all := newHrwList(len(smap.Tmap))
+	for _, tsi := range smap.Tmap {
+		if tsi.InMaintOrDecomm() {
+			continue
+		}
+		all.add(tsi.hrw(digest, weighted), tsi)
+	}
+	var (
+		sorted   = all.get()
+		sis      = make(Nodes, 0, count)
+		selected = make([]bool, len(sorted))
+	)
+	for len(sis) < count && len(sis) < len(sorted) {
+		var (
+			zones = make(map[string]struct{}, count)
+			racks = make(map[string]struct{}, count)
+		)
+		for pass := range 2 {
+			for i, tsi := range sorted {
+				if len(sis) == count {
+					return sis
+				}
+				if selected[i] {
+					continue
+				}
+				if _, ok := zones[tsi.FD.Zone]; ok && pass == 0 {
+					continue
+				}
+				if _, ok := racks[tsi.FD.String()]; ok {
+					continue
+				}
+				zones[tsi.FD.Zone] = struct{}{}
+				racks[tsi.FD.String()] = struct{}{}
+				selected[i] = true
+				sis = append(sis, tsi)
+			}
+		}
+	}
+	return sis
+}
+
+// whether active targets differ in their respective weights (see Snode.Weight)
+func (smap *Smap) Weighted() bool {
+	var w uint64
+	for _, tsi := range smap.Tmap {
+		if tsi.InMaintOrDecomm() {
+			continue
+		}
+		if w == 0 {
+			w = tsi.hweight()
+		} else if w != tsi.hweight() {
+			return true
+		}
+	}
+	return false
+}
+
+func (smap *Smap) weighted() bool {
+	switch smap.hrwMode {
+	case hrwUniform:
+		return false
+	case hrwWeighted:
+		return true
+	default:
+		return smap.Weighted()
+	}
+}
+
+func (d *Snode) hweight() uint64 {
+	if d.Weight == 0 {
+		return DefaultHrwWeight
+	}
+	return d.Weight
+}
+
+// target's HRW given object's digest
+func (d *Snode) hrw(digest uint64, weighted bool) uint64 {
+	cs := xoshiro256.Hash(d.Digest() ^ digest)
+	if weighted {
+		return wscore(cs, d.hweight())
+	}
+	return cs
+}
+
+// weight / -ln(u), where u in (0, 1); for positive floats, the ordering
+// of their IEEE 754 representations is the same as the ordering of the floats
+func wscore(cs, weight uint64) uint64 {
+	u := (float64(cs>>11) + 0.5) / (1 << 53)
+	return math.Float64bits(float64(weight) / -math.Log(u))
+}
+
+////////////
+// hrwMax //
+////////////
+
+// single-pass selection of the HRW target; unless the mode is known (see Smap.hrwMode)
+// keeps track of both unweighted and weighted maximums while finding out whether
+// all targets have the same weight
+type hrwMax struct {
+	si, wsi    *Snode
+	maxH, maxW uint64
+	weight     uint64 // the first one
+	mode       uint8
+	weighted   bool
+}
+
+func (m *hrwMax) add(tsi *Snode, cs uint64) {
+	if m.mode != hrwWeighted && cs >= m.maxH {
+		m.maxH = cs
+		m.si = tsi
+	}
+	if m.mode == hrwUniform {
+		return
+	}
+	w := tsi.hweight()
+	if m.weight == 0 {
+		m.weight = w
+	} else if w != m.weight {
+		m.weighted = true
+	}
+	if ws := wscore(cs, w); ws >= m.maxW {
+		m.maxW = ws
+		m.wsi = tsi
+	}
+}
+
+func (m *hrwMax) get() *Snode {
+	if m.mode == hrwWeighted || m.weighted {
+		return m.wsi
+	}
+	return m.si
+}
+
 func newHrwList(count int) *hrwList {
 	return &hrwList{hs: make([]uint64, 0, count), sis: make(Nodes, 0, count), n: count}
 }
@@ -0,0 +1,184 @@
+// Package meta_test: unit tests for the package
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package meta_test
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/meta"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HrwTargetList", func() {
+	newSmap := func(fds ...cmn.FailureDomain) *meta.Smap {
+		smap := &meta.Smap{Tmap: make(meta.NodeMap, len(fds))}
+		for i, fd := range fds {
+			si := &meta.Snode{FD: fd}
+			si.Init(fmt.Sprintf("t%d", i), apc.Target)
+			smap.Tmap[si.ID()] = si
+		}
+		return smap
+	}
+
+	It("should spread targets across racks", func() {
+		var fds []cmn.FailureDomain
+		for rack := range 3 {
+			for range 4 {
+				fds = append(fds, cmn.FailureDomain{Zone: "z", Rack: fmt.Sprintf("r%d", rack)})
+			}
+		}
+		smap := newSmap(fds...)
+		for i := range 100 {
+			uname := fmt.Sprintf("ais/@#/bck/obj-%d", i)
+			sis, err := smap.HrwTargetList(&uname, 6)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sis).To(HaveLen(6))
+
+			// the first one is always the HRW target
+			tsi, err := smap.HrwName2T([]byte(uname))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sis[0].ID()).To(Equal(tsi.ID()))
+
+			perRack := make(map[string]int, 3)
+			for _, si := range sis {
+				perRack[si.FD.Rack]++
+			}
+			Expect(perRack).To(HaveLen(3))
+			for _, n := range perRack {
+				Expect(n).To(Equal(2))
+			}
+		}
+	})
+
+	It("should prefer zones over racks", func() {
+		smap := newSmap(
+			cmn.FailureDomain{Zone: "z1", Rack: "r1"}, cmn.FailureDomain{Zone: "z1", Rack: "r2"},
+			cmn.FailureDomain{Zone: "z1", Rack: "r3"}, cmn.FailureDomain{Zone: "z2", Rack: "r1"},
+		)
+		for i := range 100 {
+			uname := fmt.Sprintf("ais/@#/bck/obj-%d", i)
+			sis, err := smap.HrwTargetList(&uname, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sis[0].FD.Zone).NotTo(Equal(sis[1].FD.Zone))
+		}
+	})
+
+	It("should not change placement when no failure domains", func() {
+		smap := newSmap(make([]cmn.FailureDomain, 8)...)
+		Expect(smap.HasFDs()).To(BeFalse())
+		uname := "ais/@#/bck/obj"
+		sis, err := smap.HrwTargetList(&uname, 8)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sis).To(HaveLen(8))
+	})
+
+	It("should reject joining node with invalid failure domain", func() {
+		si := &meta.Snode{FD: cmn.FailureDomain{Zone: "z1", Rack: "r1"}}
+		si.Init("t1", apc.Target)
+		Expect(si.Validate()).To(Succeed())
+		si.FD.Rack = "r/1"
+		Expect(si.Validate()).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Capacity-weighted HRW", func() {
+	const numObjs = 20000
+
+	newSmap := func(weights ...uint64) *meta.Smap {
+		smap := &meta.Smap{Tmap: make(meta.NodeMap, len(weights))}
+		for i, w := range weights {
+			si := &meta.Snode{Weight: w}
+			si.Init(fmt.Sprintf("t%d", i), apc.Target)
+			smap.Tmap[si.ID()] = si
+		}
+		return smap
+	}
+	place := func(smap *meta.Smap) map[string]string {
+		placed := make(map[string]string, numObjs)
+		for i := range numObjs {
+			uname := fmt.Sprintf("ais/@#/bck/obj-%d", i)
+			tsi, err := smap.HrwName2T([]byte(uname))
+			Expect(err).NotTo(HaveOccurred())
+			placed[uname] = tsi.ID()
+		}
+		return placed
+	}
+
+	It("should place objects proportionally to weights", func() {
+		smap := newSmap(60, 15, 15, 15, 15)
+		Expect(smap.Weighted()).To(BeTrue())
+		perTarget := make(map[string]int, 5)
+		for uname, tid := range place(smap) {
+			perTarget[tid]++
+
+			sis, err := smap.HrwTargetList(&uname, 3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sis[0].ID()).To(Equal(tid))
+		}
+		// expecting 50% and 12.5% of all objects, respectively
+		Expect(perTarget["t0"]).To(BeNumerically("~", numObjs/2, numObjs/20))
+		for _, tid := range []string{"t1", "t2", "t3", "t4"} {
+			Expect(perTarget[tid]).To(BeNumerically("~", numObjs/8, numObjs/20))
+		}
+	})
+
+	It("should only move objects to or from the target with changed weight", func() {
+		smap := newSmap(30, 15, 15, 15)
+		before := place(smap)
+		smap.Tmap["t1"].Weight = 45
+		after := place(smap)
+		for uname, tid := range after {
+			if tid != before[uname] {
+				Expect(tid).To(Equal("t1"))
+			}
+		}
+	})
+
+	It("should treat targets without weight as default-weighted", func() {
+		unweighted := place(newSmap(0, 0, 0, 0))
+		Expect(newSmap(0, 0, 0, 0).Weighted()).To(BeFalse())
+
+		// same (default) weight - same placement
+		smap := newSmap(meta.DefaultHrwWeight, 0, meta.DefaultHrwWeight, 0)
+		Expect(smap.Weighted()).To(BeFalse())
+		Expect(place(smap)).To(Equal(unweighted))
+
+		// configuring one target moves only the objects to (or from) it
+		smap = newSmap(0, 4*meta.DefaultHrwWeight, 0, 0)
+		Expect(smap.Weighted()).To(BeTrue())
+		for uname, tid := range place(smap) {
+			if tid != unweighted[uname] {
+				Expect(tid).To(Equal("t1"))
+			}
+		}
+
+		// adding a target without weight moves only the objects to it
+		weighted := newSmap(60, 15, 15, 15)
+		before := place(weighted)
+		si := &meta.Snode{}
+		si.Init("t4", apc.Target)
+		weighted.Tmap[si.ID()] = si
+		for uname, tid := range place(weighted) {
+			if tid != before[uname] {
+				Expect(tid).To(Equal("t4"))
+			}
+		}
+	})
+
+	It("should place objects the same way once Smap is initialized", func() {
+		for _, weights := range [][]uint64{{0, 0, 0}, {30, 15, 15}} {
+			smap := newSmap(weights...)
+			expected := place(smap)
+			smap.InitDigests()
+			Expect(place(smap)).To(Equal(expected))
+			smap.ResetHrwMode()
+			Expect(place(smap)).To(Equal(expected))
+		}
+	})
+})
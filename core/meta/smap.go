@@ -76,8 +76,10 @@ type (
 		DaeType    string     `json:"daemon_type"`       // apc.Proxy | apc.Target
 		DaeID      string     `json:"daemon_id"`
 		name       string
-		PubExtra   []NetInfo    `json:"pub_extra,omitempty"`
-		Flags      cos.BitFlags `json:"flags"` // enum { SnodeNonElectable, SnodeIC, ... }
+		PubExtra   []NetInfo         `json:"pub_extra,omitempty"`
+		FD         cmn.FailureDomain `json:"failure_domain"`   // (optional) rack and zone - see HrwTargetList
+		Weight     uint64            `json:"weight,omitempty"` // (optional) capacity-weighted HRW - see hrw.go
+		Flags      cos.BitFlags      `json:"flags"`            // enum { SnodeNonElectable, SnodeIC, ... }
 		idDigest   uint64
 	}
 
@@ -93,6 +95,7 @@ type (
 		UUID         string  `json:"uuid"`          // is assigned once at creation time, never changes
 		CreationTime string  `json:"creation_time"` // creation timestamp
 		Version      int64   `json:"version,string"`
+		hrwMode      uint8   // (in-memory) whether active targets are weighted - see InitDigests
 	}
 )
 
@@ -215,6 +218,9 @@ func (d *Snode) Validate() error {
 	if d.DaeType != apc.Proxy && d.DaeType != apc.Target {
 		cos.Assertf(false, "invalid Snode type %q", d.DaeType)
 	}
+	if err := d.FD.Validate(); err != nil {
+		return fmt.Errorf("invalid Snode %s: %v", d.StrURLs(), err)
+	}
 	return nil
 }
 
@@ -386,6 +392,8 @@ func (ni *NetInfo) eq(o *NetInfo) bool {
 // Cluster map (aks Smap) is a versioned, protected and replicated object
 // Smap versioning is monotonic and incremental
 
+// residual (in-memory) initialization of the new Smap version: node digests and
+// whether HRW is weighted (see hrw.go)
 func (m *Smap) InitDigests() {
 	for _, node := range m.Tmap {
 		node.setDigest()
@@ -393,8 +401,16 @@ func (m *Smap) InitDigests() {
 	for _, node := range m.Pmap {
 		node.setDigest()
 	}
+	m.hrwMode = hrwUniform
+	if m.Weighted() {
+		m.hrwMode = hrwWeighted
+	}
 }
 
+// to be called upon cloning (prior to modifying) the Smap - the clone
+// determines HRW mode on the fly until (re)initialized
+func (m *Smap) ResetHrwMode() { m.hrwMode = hrwUnknown }
+
 func (m *Smap) String() string {
 	if m == nil {
 		return "Smap <nil>"
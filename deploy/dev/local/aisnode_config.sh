@@ -193,6 +193,11 @@ cat > $AIS_LOCAL_CONF_FILE <<EOL
 	"fspaths": {
 		$AIS_FS_PATHS
 	},
+	"failure_domain": {
+		"zone": "${AIS_ZONE}",
+		"rack": "${AIS_RACK}"
+	},
+	"hrw_weight": ${AIS_HRW_WEIGHT:-0},
 	"test_fspaths": {
 		"root":     "${TEST_FSPATH_ROOT:-/tmp/ais$NEXT_TIER/}",
 		"count":    ${TEST_FSPATH_COUNT:-0},
@@ -212,6 +212,9 @@ func (r *XactBckEncode) afterEncode(lom *core.LOM, err error) {
 // Walks through all files in 'obj' directory, and calls EC.Encode for every
 // file whose HRW points to this file and the file does not have corresponding
 // metadata file in 'meta' directory
+// - or, when the bucket's (D, P) configuration changes, the metadata file refers
+// to the previous configuration (re-encoding); that is, objects get re-encoded
+// incrementally, and rerunning an aborted xaction skips those that already were
 func (r *XactBckEncode) encode(lom *core.LOM, _ []byte) error {
 	_, local, err := lom.HrwTarget(r.smap)
 	if err != nil {
@@ -231,7 +234,7 @@ func (r *XactBckEncode) encode(lom *core.LOM, _ []byte) error {
 	// If metafile exists, the object has been already encoded. But for
 	// replicated objects we have to fall through. Otherwise, bencode
 	// won't recover any missing replicas
-	if err == nil && !md.IsCopy {
+	if err == nil && !md.IsCopy && md.sameConf(&r.bck.Props.EC) {
 		return nil
 	}
 	if err != nil && !os.IsNotExist(err) {
@@ -183,6 +183,7 @@ type (
 )
 
 type global struct {
+	tstats   cos.StatsUpdater
 	reqPool  sync.Pool
 	pmm      *memsys.MMSA // memory manager slab/SGL allocator (pages)
 	smm      *memsys.MMSA // ditto, bytes
@@ -197,7 +198,8 @@ var (
 	ErrorNotFound   = errors.New("not found")
 )
 
-func Init() {
+func Init(tstats cos.StatsUpdater) {
+	g.tstats = tstats
 	g.pmm = core.T.PageMM()
 	g.smm = core.T.ByteMM()
 
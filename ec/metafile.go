@@ -9,6 +9,7 @@ import (
 	"io"
 	"os"
 
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
@@ -72,6 +73,11 @@ func MetaFromReader(reader io.Reader, size int64) (*Metadata, error) {
 	return md, err
 }
 
+// whether the object was erasure coded with the given (current) bucket configuration
+func (md *Metadata) sameConf(ecConf *cmn.ECConf) bool {
+	return md.Data == ecConf.DataSlices && md.Parity == ecConf.ParitySlices
+}
+
 // RemoteTargets returns list of Snodes that contain a slice or replica.
 // This target(`t`) is removed from the list.
 func (md *Metadata) RemoteTargets() []*meta.Snode {
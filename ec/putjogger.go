@@ -270,6 +270,9 @@ func (c *putJogger) encode(req *request, lom *core.LOM) error {
 		meta.Daemons[tgt.ID()] = sliceID
 	}
 
+	// re-encoding: the previous generation, if any (see also XactBckEncode)
+	prev, _ := LoadMetadata(ctMeta.FQN())
+
 	if meta.IsCopy {
 		err = c.replicate(ctx)
 	} else {
@@ -288,9 +291,34 @@ func (c *putJogger) encode(req *request, lom *core.LOM) error {
 		}
 		return fmt.Errorf("%s metafile saved while bucket %s was being destroyed", ctMeta.ObjectName(), ctMeta.Bucket())
 	}
+	if prev != nil {
+		return c.cleanupStale(lom, prev, meta)
+	}
 	return nil
 }
 
+// Remove slices and replicas of the previous generation from the targets
+// that are not used by the current one (e.g., after changing (D, P) or
+// when the cluster map changes)
+func (c *putJogger) cleanupStale(lom *core.LOM, prev, md *Metadata) error {
+	var nodes []*meta.Snode
+	for _, tsi := range prev.RemoteTargets() {
+		if _, ok := md.Daemons[tsi.ID()]; !ok {
+			nodes = append(nodes, tsi)
+		}
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+	request := newIntraReq(reqDel, nil, lom.Bck()).NewPack(g.smm)
+	o := transport.AllocSend()
+	o.Hdr = transport.ObjHdr{ObjName: lom.ObjName, Opaque: request, Opcode: reqDel}
+	o.Hdr.Bck.Copy(lom.Bucket())
+	o.Callback = c.ctSendCallback
+	c.parent.IncPending()
+	return c.parent.mgr.req().Send(o, nil, nodes...)
+}
+
 func (*putJogger) newCtx(lom *core.LOM, meta *Metadata) (ctx *encodeCtx, err error) {
 	ctx = allocCtx()
 	ctx.lom = lom
@@ -14,6 +14,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
@@ -343,9 +344,15 @@ func (r *xactECBase) writeRemote(daemonIDs []string, lom *core.LOM, src *dataSou
 		Opcode:   src.reqType,
 	}
 	hdr.Bck.Copy(lom.Bucket())
-	oldCallback := cb
+	var (
+		oldCallback = cb
+		started     = mono.NanoTime()
+	)
 	cb = func(hdr *transport.ObjHdr, reader io.ReadCloser, arg any, err error) {
 		g.smm.Free(hdr.Opaque)
+		if err == nil {
+			g.tstats.Add(core.ECSendLatencyHist, mono.SinceNano(started))
+		}
 		if oldCallback != nil {
 			oldCallback(hdr, reader, arg, err)
 		}
@@ -57,6 +57,10 @@ type RequestSpec struct {
 	// Optional
 	// Default: InputExtension
 	OutputExtension string `json:"output_extension" yaml:"output_extension"`
+	// Maximum number of records (e.g., Parquet rows) per output shard,
+	// in addition to or instead of OutputShardSize
+	// Default: 0 (unlimited)
+	OutputShardRecords int `json:"output_shard_records" yaml:"output_shard_records"`
 	// Default: ""
 	Description string `json:"description" yaml:"description"`
 	// Default: same as `bck` field
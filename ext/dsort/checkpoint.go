@@ -0,0 +1,377 @@
+// Package dsort provides APIs for distributed archive file shuffling.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/ext/dsort/ct"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// Resumable dsort.
+//
+// Each target checkpoints the state of the job under the job's ID:
+// - job's (parsed) specification, participating targets, and the last completed
+//   phase - in the target's kvdb (see checkpointsKey);
+// - extracted records metadata - on local mountpaths, once the extraction phase completes;
+//   at this point, extracted content that still resides in memory gets spilled to disk;
+// - names of the output shards created by this target - ditto, append-only (and fsync-ed).
+//
+// Upon failure (abort), extracted content that has been checkpointed is retained.
+// Resuming the job (see PresumeHandler) then:
+// - skips extraction on the targets that have checkpointed their records;
+// - skips output shards created by previous run(s) - see phase3 - provided the same records
+//   get ordered the same way (see Manager.reproducible); shuffling uses the seed that was
+//   generated when the job started (and checkpointed with its specification).
+//
+// Resuming requires the same set of targets. The checkpoint gets removed together with the
+// (finished or aborted) job - see tremoveHandler and managerGroup.housekeep.
+
+const checkpointsKey = "checkpoints"
+
+const ckptExtracted = "extracted"
+
+const (
+	ckptRecords = ".ckpt-records"
+	ckptCreated = ".ckpt-created"
+)
+
+type (
+	checkpoint struct {
+		Pars          *parsedReqSpec `json:"pars"`
+		Phase         string         `json:"phase"`   // last completed phase (empty or ckptExtracted)
+		Targets       []string       `json:"targets"` // IDs of the participating targets (sorted)
+		ShardSize     int64          `json:"shard_size,string"`
+		ExtractedSize int64          `json:"extracted_size,string"`
+	}
+	ckpter struct {
+		m    *Manager
+		prev *checkpoint // previous run's checkpoint when resuming
+		curr checkpoint
+		cfh  *os.File // created shards (see above)
+		mu   sync.Mutex
+	}
+)
+
+func ckptKey(managerUUID string) string { return path.Join(checkpointsKey, managerUUID) }
+
+func ckptFQN(pars *parsedReqSpec, managerUUID, tag string) (string, error) {
+	c, err := core.NewCTFromBO(&pars.InputBck, path.Join(managerUUID, tag), nil)
+	if err != nil {
+		return "", err
+	}
+	return c.Make(ct.DsortFileType), nil
+}
+
+func activeTargets(smap *meta.Smap) []string {
+	tids := make([]string, 0, len(smap.Tmap))
+	for tid, si := range smap.Tmap {
+		if !smap.InMaintOrDecomm(si) {
+			tids = append(tids, tid)
+		}
+	}
+	slices.Sort(tids)
+	return tids
+}
+
+func loadCheckpoint(db kvdb.Driver, managerUUID string) (*checkpoint, error) {
+	ckpt := &checkpoint{}
+	if err := db.Get(dsortCollection, ckptKey(managerUUID), ckpt); err != nil {
+		return nil, err
+	}
+	return ckpt, nil
+}
+
+func (ckpt *checkpoint) validate(managerUUID string, smap *meta.Smap) error {
+	if tids := activeTargets(smap); !slices.Equal(tids, ckpt.Targets) {
+		return fmt.Errorf("cannot resume %s job %s: cluster map changed (targets %v vs %v)",
+			apc.ActDsort, managerUUID, ckpt.Targets, tids)
+	}
+	return nil
+}
+
+// remove checkpoint including (when withContent) retained extracted content
+func removeCheckpoint(db kvdb.Driver, managerUUID string, withContent bool) {
+	ckpt, err := loadCheckpoint(db, managerUUID)
+	if err != nil {
+		return // nothing to do
+	}
+	if fqn, err := ckptFQN(ckpt.Pars, managerUUID, ckptRecords); err == nil {
+		if withContent && ckpt.Phase == ckptExtracted {
+			if records, err := loadRecords(fqn); err == nil {
+				recm := shard.NewRecordManager(ckpt.Pars.InputBck, nil, nil, nil)
+				for _, rec := range records.All() {
+					for _, obj := range rec.Objects {
+						if obj.StoreType == shard.DiskStoreType {
+							_ = cos.RemoveFile(recm.FullContentPath(obj))
+						}
+					}
+				}
+			}
+		}
+		_ = cos.RemoveFile(fqn)
+	}
+	if fqn, err := ckptFQN(ckpt.Pars, managerUUID, ckptCreated); err == nil {
+		_ = cos.RemoveFile(fqn)
+	}
+	_ = db.Delete(dsortCollection, ckptKey(managerUUID))
+}
+
+func loadRecords(fqn string) (*shard.Records, error) {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	defer cos.Close(fh)
+	buf, slab := g.mem.AllocSize(serializationBufSize)
+	defer slab.Free(buf)
+	records := shard.NewRecords(0)
+	err = records.DecodeMsg(msgp.NewReaderBuf(fh, buf))
+	return records, err
+}
+
+func loadCreated(fqn string, created cos.StrSet) error {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer cos.Close(fh)
+	scanner := bufio.NewScanner(fh)
+	for scanner.Scan() {
+		if name := scanner.Text(); name != "" {
+			created.Add(name)
+		}
+	}
+	return scanner.Err()
+}
+
+////////////
+// ckpter //
+////////////
+
+func (c *ckpter) init(m *Manager) error {
+	c.m = m
+	if c.prev != nil {
+		c.curr = *c.prev
+		c.curr.Pars = m.Pars
+		return nil
+	}
+	c.curr = checkpoint{Pars: m.Pars, Targets: activeTargets(m.smap)}
+	return c.save()
+}
+
+func (c *ckpter) resumed() bool { return c.prev != nil }
+
+func (c *ckpter) save() error {
+	return c.m.mg.db.Set(dsortCollection, ckptKey(c.m.ManagerUUID), &c.curr)
+}
+
+// extraction phase completed: spill in-memory content to disk and checkpoint records
+// (unless some of the content fails to spill)
+func (c *ckpter) extracted() {
+	m := c.m
+	if m.Pars.DryRun {
+		return
+	}
+	if !spillContent(m.recm) {
+		nlog.Errorf("%s: [dsort] %s: failed to spill extracted content to disk - not checkpointing", core.T, m.ManagerUUID)
+		return
+	}
+	fqn, err := ckptFQN(m.Pars, m.ManagerUUID, ckptRecords)
+	if err == nil {
+		err = c.saveRecords(fqn)
+	}
+	if err == nil {
+		c.curr.Phase = ckptExtracted
+		c.curr.ShardSize, c.curr.ExtractedSize = m.totalShardSize(), m.totalExtractedSize()
+		err = c.save()
+	}
+	if err != nil {
+		nlog.Errorf("%s: [dsort] %s: failed to checkpoint extracted records: %v", core.T, m.ManagerUUID, err)
+	}
+}
+
+// write in-memory (SGL) extracted content to local disk; return false if any remains in memory
+func spillContent(recm *shard.RecordManager) bool {
+	buf, slab := g.mem.Alloc()
+	recm.RecordContents().Range(func(key, value any) bool {
+		recm.FreeMem(key.(string), shard.DiskStoreType, value, buf)
+		return true
+	})
+	slab.Free(buf)
+	for _, rec := range recm.Records.All() {
+		for _, obj := range rec.Objects {
+			if obj.StoreType == shard.SGLStoreType {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (c *ckpter) saveRecords(fqn string) error {
+	fh, err := cos.CreateFile(fqn)
+	if err != nil {
+		return err
+	}
+	var (
+		buf, slab = g.mem.AllocSize(serializationBufSize)
+		w         = msgp.NewWriterBuf(fh, buf)
+	)
+	err = c.m.recm.Records.EncodeMsg(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = fh.Sync()
+	}
+	slab.Free(buf)
+	if errC := cos.FlushClose(fh); err == nil {
+		err = errC
+	}
+	return err
+}
+
+// resuming: restore extracted records from the previous run (if checkpointed)
+func (c *ckpter) restore() bool {
+	m := c.m
+	if !c.resumed() || c.prev.Phase != ckptExtracted {
+		return false
+	}
+	fqn, err := ckptFQN(m.Pars, m.ManagerUUID, ckptRecords)
+	if err != nil {
+		return false
+	}
+	records, err := loadRecords(fqn)
+	if err != nil {
+		nlog.Errorf("%s: [dsort] %s: failed to restore extracted records (re-extracting): %v", core.T, m.ManagerUUID, err)
+		c.curr.Phase = ""
+		return false
+	}
+	m.recm.Records.Insert(records.All()...)
+	for _, rec := range records.All() {
+		for _, obj := range rec.Objects {
+			if obj.StoreType == shard.DiskStoreType {
+				m.recm.ExtractionPaths().Store(m.recm.FullContentPath(obj), struct{}{})
+			}
+		}
+	}
+	m.addSizes(c.prev.ShardSize, c.prev.ExtractedSize)
+	nlog.Infof("%s: [dsort] %s: restored %d extracted records", core.T, m.ManagerUUID, records.Len())
+	return true
+}
+
+// output shard created (and stored)
+func (c *ckpter) created(shardName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cfh == nil {
+		fqn, err := ckptFQN(c.m.Pars, c.m.ManagerUUID, ckptCreated)
+		if err == nil {
+			err = cos.CreateDir(filepath.Dir(fqn))
+		}
+		if err == nil {
+			c.cfh, err = os.OpenFile(fqn, os.O_CREATE|os.O_APPEND|os.O_WRONLY, cos.PermRWR)
+		}
+		if err != nil {
+			nlog.Errorf("%s: [dsort] %s: failed to checkpoint created shard %s: %v", core.T, c.m.ManagerUUID, shardName, err)
+			return
+		}
+	}
+	_, err := c.cfh.WriteString(shardName + "\n")
+	if err == nil {
+		err = c.cfh.Sync()
+	}
+	if err != nil {
+		nlog.Errorf("%s: [dsort] %s: failed to checkpoint created shard %s: %v", core.T, c.m.ManagerUUID, shardName, err)
+	}
+}
+
+func (c *ckpter) loadCreated(created cos.StrSet) error {
+	fqn, err := ckptFQN(c.m.Pars, c.m.ManagerUUID, ckptCreated)
+	if err != nil {
+		return err
+	}
+	return loadCreated(fqn, created)
+}
+
+// final target, resuming: output shards created by previous run(s), cluster-wide
+func (c *ckpter) createdAll() (cos.StrSet, error) {
+	created := cos.NewStrSet()
+	if err := c.loadCreated(created); err != nil {
+		return nil, err
+	}
+	path := apc.URLPathdSortCkpt.Join(c.m.ManagerUUID)
+	responses := bcast(http.MethodGet, path, nil, nil, c.m.smap, core.T.Snode())
+	for _, resp := range responses {
+		if resp.err != nil {
+			return nil, resp.err
+		}
+		var names []string
+		if err := js.Unmarshal(resp.res, &names); err != nil {
+			return nil, err
+		}
+		created.Add(names...)
+	}
+	return created, nil
+}
+
+// whether a given output shard was created by previous run(s) - if it was,
+// count its record objects (per target) that won't be loaded
+func skipCreated(s *shard.Shard, created cos.StrSet, released map[string]int64) bool {
+	if !created.Contains(s.Name) {
+		return false
+	}
+	for _, rec := range s.Records.All() {
+		released[rec.DaemonID] += int64(len(rec.Objects))
+	}
+	return true
+}
+
+func (c *ckpter) close() {
+	c.mu.Lock()
+	if c.cfh != nil {
+		cos.Close(c.cfh)
+		c.cfh = nil
+	}
+	c.mu.Unlock()
+}
+
+// job finished locally, extracted content removed (see finalCleanup); keeping the rest,
+// in case some other target fails and the job gets resumed
+func (c *ckpter) finished() {
+	m := c.m
+	if c.curr.Phase != ckptExtracted {
+		return
+	}
+	if fqn, err := ckptFQN(m.Pars, m.ManagerUUID, ckptRecords); err == nil {
+		_ = cos.RemoveFile(fqn)
+	}
+	c.curr.Phase = ""
+	if err := c.save(); err != nil {
+		nlog.Errorf("%s: [dsort] %s: failed to update checkpoint: %v", core.T, m.ManagerUUID, err)
+	}
+}
+
+// retain extracted content upon abort (to resume from)
+func (c *ckpter) retain() bool { return c.m.aborted() && c.curr.Phase == ckptExtracted }
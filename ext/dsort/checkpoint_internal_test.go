@@ -0,0 +1,100 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dsort/ct"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checkpoint", func() {
+	newShard := func(name string, recs ...*shard.Record) *shard.Shard {
+		records := shard.NewRecords(len(recs))
+		records.Insert(recs...)
+		return &shard.Shard{Name: name, Records: records}
+	}
+
+	It("should skip created shards and count released objects per target", func() {
+		var (
+			created  = cos.NewStrSet("shard-0.tar")
+			released = make(map[string]int64)
+			s0       = newShard("shard-0.tar",
+				&shard.Record{Name: "a", DaemonID: "t1", Objects: []*shard.RecordObj{{Extension: ".jpg"}, {Extension: ".cls"}}},
+				&shard.Record{Name: "b", DaemonID: "t2", Objects: []*shard.RecordObj{{Extension: ".jpg"}}},
+			)
+			s1 = newShard("shard-1.tar", &shard.Record{Name: "c", DaemonID: "t1", Objects: []*shard.RecordObj{{Extension: ".jpg"}}})
+		)
+		Expect(skipCreated(s0, created, released)).To(BeTrue())
+		Expect(skipCreated(s1, created, released)).To(BeFalse())
+		Expect(released).To(Equal(map[string]int64{"t1": 2, "t2": 1}))
+	})
+
+	It("should not skip anything when not resuming", func() {
+		released := make(map[string]int64)
+		s := newShard("shard-0.tar", &shard.Record{Name: "a", DaemonID: "t1", Objects: []*shard.RecordObj{{}}})
+		Expect(skipCreated(s, nil, released)).To(BeFalse())
+		Expect(released).To(BeEmpty())
+	})
+
+	It("should spill in-memory content to disk", func() {
+		if g.mem == nil {
+			g.mem = memsys.PageMM()
+		}
+		dir := GinkgoT().TempDir()
+		fs.TestNew(nil)
+		_, err := fs.Add(dir, "daeID")
+		Expect(err).NotTo(HaveOccurred())
+		fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+		fs.CSM.Reg(ct.DsortFileType, &ct.DsortFile{}, true)
+
+		var (
+			bck     = cmn.Bck{Name: "ckpt", Provider: apc.AIS}
+			recm    = shard.NewRecordManager(bck, nil, nil, nil)
+			content = []byte("extracted content")
+			sgl     = g.mem.NewSGL(int64(len(content)))
+			obj     = &shard.RecordObj{ContentPath: "shard-0-a.jpg", StoreType: shard.SGLStoreType, Extension: ".jpg"}
+		)
+		_, err = sgl.Write(content)
+		Expect(err).NotTo(HaveOccurred())
+		recm.Records.Insert(&shard.Record{Name: "shard-0-a", Objects: []*shard.RecordObj{obj}})
+		recm.RecordContents().Store(obj.ContentPath, sgl)
+
+		Expect(spillContent(recm)).To(BeTrue())
+		Expect(obj.StoreType).To(Equal(shard.DiskStoreType))
+		_, inMem := recm.RecordContents().Load(obj.ContentPath)
+		Expect(inMem).To(BeFalse())
+
+		fqn := recm.FullContentPath(obj)
+		Expect(fqn).To(HavePrefix(dir))
+		b, err := os.ReadFile(fqn)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(content))
+
+		// spilling again is a no-op
+		Expect(spillContent(recm)).To(BeTrue())
+	})
+
+	It("should load created shards", func() {
+		fqn := filepath.Join(GinkgoT().TempDir(), ckptCreated)
+		created := cos.NewStrSet()
+		Expect(loadCreated(fqn, created)).To(Succeed())
+		Expect(created).To(BeEmpty())
+
+		Expect(os.WriteFile(fqn, []byte("shard-0.tar\nshard-2.tar\n\n"), cos.PermRWR)).To(Succeed())
+		Expect(loadCreated(fqn, created)).To(Succeed())
+		Expect(created.ToSlice()).To(ConsistOf("shard-0.tar", "shard-2.tar"))
+	})
+})
@@ -180,16 +180,20 @@ func (m *Manager) extractLocalShards() (err error) {
 	m.extractionPhase.adjuster.start()
 	m.Metrics.Extraction.begin()
 
-	// compare with xact/xs/multiobj.go
-	group, ctx := errgroup.WithContext(context.Background())
-	switch {
-	case m.Pars.Pit.isRange():
-		err = m.iterRange(ctx, group)
-	case m.Pars.Pit.isList():
-		err = m.iterList(ctx, group)
-	default:
-		debug.Assert(m.Pars.Pit.isPrefix())
-		debug.Assert(false, "not implemented yet") // TODO -- FIXME
+	// resuming: extracted records from the previous run, if available
+	restored := m.ckpt.restore()
+	if !restored {
+		// compare with xact/xs/multiobj.go
+		group, ctx := errgroup.WithContext(context.Background())
+		switch {
+		case m.Pars.Pit.isRange():
+			err = m.iterRange(ctx, group)
+		case m.Pars.Pit.isList():
+			err = m.iterList(ctx, group)
+		default:
+			debug.Assert(m.Pars.Pit.isPrefix())
+			debug.Assert(false, "not implemented yet") // TODO -- FIXME
+		}
 	}
 
 	m.dsorter.postExtraction()
@@ -197,6 +201,9 @@ func (m *Manager) extractLocalShards() (err error) {
 	m.extractionPhase.adjuster.stop()
 	if err == nil {
 		m.incrementRef(int64(m.recm.Records.TotalObjectCount()))
+		if !restored {
+			m.ckpt.extracted()
+		}
 	}
 	return
 }
@@ -401,6 +408,9 @@ func (m *Manager) createShard(s *shard.Shard, lom *core.LOM) (err error) {
 	}
 
 exit:
+	if !m.Pars.DryRun {
+		m.ckpt.created(shardName)
+	}
 	metrics.mu.Lock()
 	metrics.CreatedCnt++
 	if si.ID() != core.T.SID() {
@@ -472,7 +482,7 @@ func (m *Manager) participateInRecordDistribution(targetOrder meta.Nodes) (curre
 				)
 				defer slab.Free(buf)
 
-				if err := m.recm.Records.EncodeMsg(msgpw); err != nil {
+				if err := m.spill.encode(msgpw, m.recm.Records); err != nil {
 					w.CloseWithError(err)
 					return errors.Errorf("failed to marshal msgp: %v", err)
 				}
@@ -490,7 +500,7 @@ func (m *Manager) participateInRecordDistribution(targetOrder meta.Nodes) (curre
 				)
 				query.Add(apc.QparamTotalCompressedSize, strconv.FormatInt(m.totalShardSize(), 10))
 				query.Add(apc.QparamTotalUncompressedSize, strconv.FormatInt(m.totalExtractedSize(), 10))
-				query.Add(apc.QparamTotalInputShardsExtracted, strconv.Itoa(m.recm.Records.Len()))
+				query.Add(apc.QparamTotalInputShardsExtracted, strconv.Itoa(m.recm.Records.Len()+m.spill.count()))
 				reqArgs := &cmn.HreqArgs{
 					Method: http.MethodPost,
 					Base:   sendTo.URL(cmn.NetIntraData),
@@ -545,64 +555,103 @@ func (m *Manager) participateInRecordDistribution(targetOrder meta.Nodes) (curre
 		targetOrder = t
 
 		m.recm.MergeEnqueuedRecords()
+		if err = m.spill.maybe(); err != nil {
+			return
+		}
 	}
 
-	err = sortRecords(m.recm.Records, m.Pars.Algorithm)
+	if m.spill.spilled() {
+		err = m.spill.merge(m.recm.Records)
+	} else {
+		err = sortRecords(m.recm.Records, m.Pars.Algorithm)
+	}
 	m.dsorter.postRecordDistribution()
 	return true, err
 }
 
 func (m *Manager) generateShardsWithTemplate(maxSize int64) ([]*shard.Shard, error) {
 	var (
-		start           int
-		curShardSize    int64
-		n               = m.recm.Records.Len()
-		pt              = m.Pars.Pot.Template
-		shardCount      = pt.Count()
-		shards          = make([]*shard.Shard, 0)
-		numLocalRecords = make(map[string]int, m.smap.CountActiveTs())
+		idx    int
+		all    = m.recm.Records.All()
+		shards = make([]*shard.Shard, 0)
+	)
+	next := func() (*shard.Record, error) {
+		if idx == len(all) {
+			return nil, nil
+		}
+		idx++
+		return all[idx-1], nil
+	}
+	err := m.genShards(maxSize, next, func(s *shard.Shard) error {
+		shards = append(shards, s)
+		return nil
+	})
+	return shards, err
+}
+
+// genShards groups (sorted) records, as returned by `next`, into output shards
+// named by the template; each shard, once complete, is passed on to `emit`
+func (m *Manager) genShards(maxSize int64, next func() (*shard.Record, error), emit func(*shard.Shard) error) error {
+	var (
+		batch        []*shard.Record
+		curShardSize int64
+		maxRecs      = m.Pars.OutputShardRecords
+		pt           = m.Pars.Pot.Template
+		shardCount   = pt.Count()
 	)
 	pt.InitIter()
 
-	if maxSize <= 0 {
-		// Heuristic: shard size when maxSize not specified.
+	if maxSize <= 0 && maxRecs == 0 {
+		// Heuristic: shard size when neither maxSize nor maxRecs specified.
 		maxSize = int64(math.Ceil(float64(m.totalExtractedSize()) / float64(shardCount)))
 	}
 
-	for i, r := range m.recm.Records.All() {
-		numLocalRecords[r.DaemonID]++
-		curShardSize += r.TotalSize()
-		if curShardSize < maxSize && i < n-1 {
-			continue
-		}
-
+	flush := func() error {
 		name, hasNext := pt.Next()
 		if !hasNext {
 			// no more shard names are available
-			return nil, errors.Errorf("number of shards to be created exceeds expected number of shards (%d)", shardCount)
+			return errors.Errorf("number of shards to be created exceeds expected number of shards (%d)", shardCount)
 		}
-		shard := &shard.Shard{
-			Name: name,
+		s := &shard.Shard{
+			Name:    name,
+			Size:    curShardSize,
+			Records: shard.NewRecordsFrom(batch),
 		}
-		ext, err := archive.Mime("", name)
-		if err == nil {
+		if ext, err := shard.Mime("", name); err == nil {
 			debug.Assert(m.Pars.OutputExtension == ext)
 		} else {
-			shard.Name = name + m.Pars.OutputExtension
+			s.Name = name + m.Pars.OutputExtension
 		}
+		batch, curShardSize = nil, 0
+		return emit(s)
+	}
 
-		shard.Size = curShardSize
-		shard.Records = m.recm.Records.Slice(start, i+1)
-		shards = append(shards, shard)
-
-		start = i + 1
-		curShardSize = 0
-		for k := range numLocalRecords {
-			numLocalRecords[k] = 0
+	for {
+		r, err := next()
+		if err != nil {
+			return err
+		}
+		if r == nil {
+			break
+		}
+		batch = append(batch, r)
+		curShardSize += r.TotalSize()
+		fits := curShardSize < maxSize
+		if maxSize <= 0 {
+			// no size limit when limited by the number of records; otherwise, one record per shard
+			fits = maxRecs > 0
+		}
+		if fits && (maxRecs == 0 || len(batch) < maxRecs) {
+			continue
+		}
+		if err := flush(); err != nil {
+			return err
 		}
 	}
-
-	return shards, nil
+	if len(batch) > 0 {
+		return flush()
+	}
+	return nil
 }
 
 func (m *Manager) parseEKMFile() (shard.ExternalKeyMap, error) {
@@ -779,6 +828,11 @@ func (m *Manager) generateShardsWithOrderingFile(maxSize int64) ([]*shard.Shard,
 //     and secondly (if there is a tie), by least load
 //     (i.e. the target with the least number of pending shard creation requests).
 func (m *Manager) phase3(maxSize int64) error {
+	if m.spill.merging() {
+		debug.Assert(m.spill.unsupported() == "")
+		return m.phase3Spilled(maxSize)
+	}
+
 	var (
 		shards         []*shard.Shard
 		err            error
@@ -803,12 +857,19 @@ func (m *Manager) phase3(maxSize int64) error {
 	if err != nil {
 		return err
 	}
+	created, released, err := m.createdShards()
+	if err != nil {
+		return err
+	}
 
 	bck := meta.CloneBck(&m.Pars.OutputBck)
 	if err := bck.Init(core.T.Bowner()); err != nil {
 		return err
 	}
 	for _, s := range shards {
+		if skipCreated(s, created, released) {
+			continue
+		}
 		si, err := m.smap.HrwName2T(bck.MakeUname(s.Name))
 		if err != nil {
 			return err
@@ -839,10 +900,106 @@ func (m *Manager) phase3(maxSize int64) error {
 
 	wg := cos.NewLimitedWaitGroup(cmn.MaxParallelism(), len(shardsToTarget))
 	for si, s := range shardsToTarget {
+		md := &CreationPhaseMetadata{Shards: s, SendOrder: sendOrder[si.ID()], Released: released[si.ID()]}
 		wg.Add(1)
-		go m._dist(si, s, sendOrder[si.ID()], errCh, wg)
+		go m._dist(si, md.EncodeMsg, errCh, wg)
 	}
+	return m._wait(wg, errCh)
+}
+
+// phase3Spilled is phase3 for the records that were spilled during distribution:
+// output shards are generated while k-way merging the runs, and their metadata
+// gets spilled again, one file per (destination) target - see spill.go
+func (m *Manager) phase3Spilled(maxSize int64) error {
+	var (
+		shardsToTarget = make(map[*meta.Snode]*shardsW, m.smap.CountActiveTs())
+		errCh          = make(chan error, m.smap.CountActiveTs())
+	)
+	for _, d := range m.smap.Tmap {
+		if !m.smap.InMaintOrDecomm(d) {
+			shardsToTarget[d] = nil
+		}
+	}
+	bck := meta.CloneBck(&m.Pars.OutputBck)
+	if err := bck.Init(core.T.Bowner()); err != nil {
+		return err
+	}
+	created, released, err := m.createdShards()
+	if err != nil {
+		return err
+	}
+	err = m.genShards(maxSize, m.spill.merger.next, func(s *shard.Shard) error {
+		if skipCreated(s, created, released) {
+			return nil
+		}
+		si, err := m.smap.HrwName2T(bck.MakeUname(s.Name))
+		if err != nil {
+			return err
+		}
+		sw := shardsToTarget[si]
+		if sw == nil {
+			if sw, err = m.spill.newShardsW(); err != nil {
+				return err
+			}
+			shardsToTarget[si] = sw
+		}
+		return sw.write(s)
+	})
+	for _, sw := range shardsToTarget {
+		if sw == nil {
+			continue
+		}
+		if errC := sw.close(); errC != nil && err == nil {
+			err = errC
+		}
+	}
+	m.spill.closeMerger()
+	m.recm.Records.Drain()
+	if err != nil {
+		return err
+	}
+
+	wg := cos.NewLimitedWaitGroup(cmn.MaxParallelism(), len(shardsToTarget))
+	for si, sw := range shardsToTarget {
+		if sw == nil {
+			sw = &shardsW{}
+		}
+		sw.released = released[si.ID()]
+		wg.Add(1)
+		go m._dist(si, sw.encode, errCh, wg)
+	}
+	return m._wait(wg, errCh)
+}
+
+// resuming: output shards created by previous run(s) - to skip
+func (m *Manager) createdShards() (created cos.StrSet, released map[string]int64, err error) {
+	released = make(map[string]int64, m.smap.CountActiveTs())
+	if !m.ckpt.resumed() {
+		return nil, released, nil
+	}
+	if !m.reproducible() {
+		nlog.Warningf("%s: [dsort] %s: order of records (%q) is not reproducible - recreating all output shards",
+			core.T, m.ManagerUUID, m.Pars.Algorithm.Kind)
+		return nil, released, nil
+	}
+	created, err = m.ckpt.createdAll()
+	return created, released, err
+}
+
+// whether the resumed job generates the same output shards (same names, same records)
+// as the previous run(s) - in other words, whether it is safe to skip the ones already created
+func (m *Manager) reproducible() bool {
+	switch m.Pars.Algorithm.Kind {
+	case None:
+		return false // (order of receiving)
+	case Shuffle:
+		return !m.spill.merging() // (random picks from the spilled runs)
+	default:
+		return true // (see lessRecords)
+	}
+}
 
+func (m *Manager) _wait(wg cos.WG, errCh chan error) error {
 	wg.Wait()
 	close(errCh)
 
@@ -854,7 +1011,8 @@ func (m *Manager) phase3(maxSize int64) error {
 	return nil
 }
 
-func (m *Manager) _dist(si *meta.Snode, s []*shard.Shard, order map[string]*shard.Shard, errCh chan error, wg cos.WG) {
+// sends CreationPhaseMetadata encoded by `encode`
+func (m *Manager) _dist(si *meta.Snode, encode func(*msgp.Writer) error, errCh chan error, wg cos.WG) {
 	var (
 		group = &errgroup.Group{}
 		r, w  = io.Pipe()
@@ -863,9 +1021,8 @@ func (m *Manager) _dist(si *meta.Snode, s []*shard.Shard, order map[string]*shar
 		var (
 			buf, slab = g.mem.AllocSize(serializationBufSize)
 			msgpw     = msgp.NewWriterBuf(w, buf)
-			md        = &CreationPhaseMetadata{Shards: s, SendOrder: order}
 		)
-		err := md.EncodeMsg(msgpw)
+		err := encode(msgpw)
 		if err == nil {
 			err = msgpw.Flush()
 		}
@@ -931,7 +1088,7 @@ func (es *extractShard) do() (err error) {
 	m := es.m
 	shardName := es.name
 	if es.isRange && m.Pars.InputExtension != "" {
-		ext, errV := archive.Mime("", es.name) // from filename
+		ext, errV := shard.Mime("", es.name) // from filename
 		if errV == nil {
 			if !archive.EqExt(ext, m.Pars.InputExtension) {
 				if cmn.Rom.FastV(4, cos.SmoduleDsort) {
@@ -16,6 +16,8 @@ const (
 	fmtErrInvalidAlg     = "invalid sorting algorithm (expecting one of: %+v)" // <--- supportedAlgorithms
 	fmtErrInvalidMaxSize = "invalid max shard size (%d) for usage with external key map"
 	fmtErrNegOutputSize  = "output shard size must be >= 0 (got %d)"
+	fmtErrNegOutputRecs  = "output shard records must be >= 0 (got %d)"
+	fmtErrRecordFormat   = "cannot reshard %q into %q (record-oriented formats reshard only into themselves)"
 	fmtErrOrderURL       = "failed to parse ekm file ('ekm_file') URL %q: %v"
 	fmtErrSeed           = "invalid seed %q (expecting integer value)"
 )
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
@@ -18,7 +19,6 @@ import (
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
-	"github.com/NVIDIA/aistore/ext/dsort/shard"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/sys"
@@ -47,6 +47,10 @@ func PstartHandler(w http.ResponseWriter, r *http.Request, parsc *ParsedReq) {
 		pars = parsc.pars
 	)
 	pars.TargetOrderSalt = []byte(cos.FormatNowStamp())
+	if pars.Algorithm.Kind == Shuffle && pars.Algorithm.Seed == "" {
+		// same seed for all targets and, when resumed, all runs (checkpointed with pars)
+		pars.Algorithm.Seed = strconv.FormatInt(time.Now().Unix(), 10)
+	}
 
 	// TODO: handle case when bucket was removed during dsort job - this should
 	// stop whole operation. Maybe some listeners as we have on smap change?
@@ -106,6 +110,43 @@ func PstartHandler(w http.ResponseWriter, r *http.Request, parsc *ParsedReq) {
 	w.Write(cos.UnsafeB(managerUUID))
 }
 
+// POST /v1/sort/resume
+// (compare w/ PstartHandler)
+func PresumeHandler(w http.ResponseWriter, r *http.Request) {
+	if !checkHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+	_, err := parseURL(w, r, 0, apc.URLPathdSortResume.L)
+	if err != nil {
+		return
+	}
+	var (
+		managerUUID = r.URL.Query().Get(apc.QparamUUID)
+		smap        = psi.Sowner().Get()
+	)
+	if managerUUID == "" {
+		cmn.WriteErrMsg(w, r, "[dsort] resume: missing job ID")
+		return
+	}
+
+	// phase 1: (re)initialize from checkpoint
+	path := apc.URLPathdSortResume.Join(managerUUID)
+	responses := bcast(http.MethodPost, path, nil, nil, smap)
+	if err := _handleResp(w, r, smap, managerUUID, responses); err != nil {
+		return
+	}
+
+	// phase 2: start
+	path = apc.URLPathdSortStart.Join(managerUUID)
+	responses = bcast(http.MethodPost, path, nil, nil, smap)
+	if err := _handleResp(w, r, smap, managerUUID, responses); err != nil {
+		return
+	}
+
+	w.Header().Set(cos.HdrContentLength, strconv.Itoa(len(managerUUID)))
+	w.Write(cos.UnsafeB(managerUUID))
+}
+
 func _handleResp(w http.ResponseWriter, r *http.Request, smap *meta.Smap, managerUUID string, responses []response) error {
 	for _, resp := range responses {
 		if resp.err == nil {
@@ -418,6 +459,10 @@ func TargetHandler(w http.ResponseWriter, r *http.Request) {
 		tmetricsHandler(w, r)
 	case apc.FinishedAck:
 		tfiniHandler(w, r)
+	case apc.Resume:
+		tresumeHandler(w, r)
+	case apc.Checkpoint:
+		tckptHandler(w, r)
 	default:
 		cmn.WriteErrMsg(w, r, "invalid path")
 	}
@@ -460,22 +505,106 @@ func tinitHandler(w http.ResponseWriter, r *http.Request) {
 		cmn.WriteErr(w, r, err)
 		return
 	}
-	if err = m.init(pars); err != nil {
+	_init(w, r, m, pars)
+}
+
+// (compare w/ tresumeHandler)
+func _init(w http.ResponseWriter, r *http.Request, m *Manager, pars *parsedReqSpec) {
+	if err := m.init(pars); err != nil {
 		cmn.WriteErr(w, r, err)
 	} else {
 		// setup xaction
 		debug.Assert(!pars.OutputBck.IsEmpty())
 		custom := &xreg.DsortArgs{BckFrom: meta.CloneBck(&pars.InputBck), BckTo: meta.CloneBck(&pars.OutputBck)}
-		rns := xreg.RenewDsort(managerUUID, custom)
+		rns := xreg.RenewDsort(m.ManagerUUID, custom)
 		debug.AssertNoErr(rns.Err)
 		xctn := rns.Entry.Get()
-		debug.Assert(xctn.ID() == managerUUID, xctn.ID()+" vs "+managerUUID)
+		debug.Assert(xctn.ID() == m.ManagerUUID, xctn.ID()+" vs "+m.ManagerUUID)
 
 		m.xctn = xctn.(*xaction)
 	}
 	m.unlock()
 }
 
+// /v1/sort/resume.
+// initialize dsort manager from the checkpoint of a previous (failed) run - see checkpoint.go
+func tresumeHandler(w http.ResponseWriter, r *http.Request) {
+	if !checkHTTPMethod(w, r, http.MethodPost) {
+		return
+	}
+	cs := fs.Cap()
+	if errCap := cs.Err(); errCap != nil {
+		cmn.WriteErr(w, r, errCap, http.StatusInsufficientStorage)
+		return
+	}
+	apiItems, err := parseURL(w, r, 1, apc.URLPathdSortResume.L)
+	if err != nil {
+		return
+	}
+
+	managerUUID := apiItems[0]
+	ckpt, err := loadCheckpoint(g.mg.db, managerUUID)
+	if err != nil {
+		if cos.IsErrNotFound(err) {
+			s := fmt.Sprintf("%s: [dsort] %s: no checkpoint to resume from", core.T, managerUUID)
+			cmn.WriteErrMsg(w, r, s, http.StatusNotFound)
+		} else {
+			cmn.WriteErr(w, r, err)
+		}
+		return
+	}
+	if err := ckpt.validate(managerUUID, core.T.Sowner().Get()); err != nil {
+		cmn.WriteErr(w, r, err)
+		return
+	}
+	// replace the previous run (fails if still in progress)
+	if err := g.mg.Remove(managerUUID); err != nil {
+		cmn.WriteErr(w, r, err)
+		return
+	}
+	m, err := g.mg.Add(managerUUID) // NOTE: returns manager locked iff err == nil
+	if err != nil {
+		cmn.WriteErr(w, r, err)
+		return
+	}
+	m.ckpt.prev = ckpt
+	_init(w, r, m, ckpt.Pars)
+}
+
+// /v1/sort/checkpoint.
+// A valid GET to this endpoint returns the names of the output shards created by this target.
+func tckptHandler(w http.ResponseWriter, r *http.Request) {
+	if !checkHTTPMethod(w, r, http.MethodGet) {
+		return
+	}
+	apiItems, err := parseURL(w, r, 1, apc.URLPathdSortCkpt.L)
+	if err != nil {
+		return
+	}
+
+	managerUUID := apiItems[0]
+	ckpt, err := loadCheckpoint(g.mg.db, managerUUID)
+	if err != nil {
+		if cos.IsErrNotFound(err) {
+			w.Write(cos.MustMarshal([]string{}))
+		} else {
+			cmn.WriteErr(w, r, err)
+		}
+		return
+	}
+	fqn, err := ckptFQN(ckpt.Pars, managerUUID, ckptCreated)
+	if err != nil {
+		cmn.WriteErr(w, r, err)
+		return
+	}
+	created := cos.NewStrSet()
+	if err := loadCreated(fqn, created); err != nil {
+		cmn.WriteErr(w, r, err)
+		return
+	}
+	w.Write(cos.MustMarshal(created.ToSlice()))
+}
+
 // /v1/sort/start.
 // There are three major phases to this function:
 //  1. extractLocalShards
@@ -572,6 +701,10 @@ func (mg *managerGroup) shardsHandler(w http.ResponseWriter, r *http.Request) {
 		cmn.WriteErr(w, r, err, http.StatusInternalServerError)
 		return
 	}
+	if tmpMetadata.Released > 0 {
+		// resuming: objects in the shards created by previous run(s) won't be loaded
+		m.decrementRef(tmpMetadata.Released)
+	}
 
 	if !m.inProgress() || m.aborted() {
 		cmn.WriteErrMsg(w, r, fmt.Sprintf("no %s process", apc.ActDsort))
@@ -632,13 +765,11 @@ func (mg *managerGroup) recordsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var (
-		buf, slab = g.mem.AllocSize(serializationBufSize)
-		records   = shard.NewRecords(int(d))
-	)
+	buf, slab := g.mem.AllocSize(serializationBufSize)
 	defer slab.Free(buf)
 
-	if err := records.DecodeMsg(msgp.NewReaderBuf(r.Body, buf)); err != nil {
+	records, err := m.spill.decode(msgp.NewReaderBuf(r.Body, buf), int(d))
+	if err != nil {
 		err = fmt.Errorf(cmn.FmtErrUnmarshal, apc.ActDsort, "records", "-", err)
 		cmn.WriteErr(w, r, err, http.StatusInternalServerError)
 		return
@@ -699,6 +830,7 @@ func tremoveHandler(w http.ResponseWriter, r *http.Request) {
 		cmn.WriteErr(w, r, err)
 		return
 	}
+	removeCheckpoint(g.mg.db, managerUUID, true /*with content*/)
 }
 
 func tlistHandler(w http.ResponseWriter, r *http.Request) {
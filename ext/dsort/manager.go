@@ -115,6 +115,8 @@ type (
 			mu sync.Mutex
 			m  map[string]struct{} // finished acks: tid -> ack
 		}
+		spill          spiller // external merge sort (see spill.go)
+		ckpt           ckpter  // resumable dsort (see checkpoint.go)
 		dsorter        dsorter
 		dsorterStarted sync.WaitGroup
 		callTimeout    time.Duration // max time to wait for another node to respond
@@ -179,6 +181,7 @@ func (m *Manager) init(pars *parsedReqSpec) error {
 
 	m.Pars = pars
 	m.Metrics = newMetrics(pars.Description)
+	m.spill.m = m
 	m.startShardCreation = make(chan struct{}, 1)
 
 	if err := m.setDsorter(); err != nil {
@@ -243,7 +246,7 @@ func (m *Manager) init(pars *parsedReqSpec) error {
 	m.state.cleanWait = sync.NewCond(&m.mu)
 
 	m.callTimeout = m.config.Dsort.CallTimeout.D()
-	return nil
+	return m.ckpt.init(m)
 }
 
 // TODO -- FIXME: create on demand and reuse streams across jobs
@@ -369,7 +372,16 @@ func (m *Manager) finalCleanup() {
 	// and we may have race between in-flight request and cleanup.
 	// Also, NOTE:
 	// recm.Cleanup => gmm.freeMemToOS => oom.FreeToOS to forcefully free memory to the OS
-	m.recm.Cleanup()
+	m.ckpt.close()
+	if m.ckpt.retain() {
+		m.recm.Release()
+	} else {
+		m.recm.Cleanup()
+	}
+	if !m.aborted() {
+		m.ckpt.finished()
+	}
+	m.spill.cleanup()
 
 	m.creationPhase.metadata.SendOrder = nil
 	m.creationPhase.metadata.Shards = nil
@@ -465,6 +477,14 @@ func (m *Manager) setRW() (err error) {
 	}
 
 	m.shardRW = shard.RWs[m.Pars.InputExtension]
+	if m.Pars.Algorithm.Kind == Content {
+		switch m.Pars.InputExtension {
+		case shard.ExtParquet:
+			m.shardRW = shard.NewParquetRW(m.Pars.Algorithm.Ext) // (sorting key column)
+		case shard.ExtArrow:
+			m.shardRW = shard.NewArrowRW(m.Pars.Algorithm.Ext) // ditto
+		}
+	}
 	if m.shardRW == nil {
 		debug.Assert(!m.Pars.DryRun, "dry-run in combination with _any_ shard extension is not supported")
 		debug.Assert(m.Pars.InputExtension == "", m.Pars.InputExtension)
@@ -191,6 +191,7 @@ func (mg *managerGroup) housekeep(int64) time.Duration {
 		if time.Since(m.Metrics.Extraction.End) > regularInterval {
 			key := path.Join(managersKey, m.ManagerUUID)
 			_ = mg.db.Delete(dsortCollection, key)
+			removeCheckpoint(mg.db, m.ManagerUUID, true /*with content*/)
 		}
 	}
 
@@ -14,6 +14,7 @@ type (
 	CreationPhaseMetadata struct {
 		Shards    []*shard.Shard          `msg:"shards"`
 		SendOrder map[string]*shard.Shard `msg:"send_order"`
+		Released  int64                   `msg:"released"` // (resumed job) objects in already created shards - see checkpoint.go
 	}
 
 	RemoteResponse struct {
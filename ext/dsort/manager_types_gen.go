@@ -100,6 +100,12 @@ func (z *CreationPhaseMetadata) DecodeMsg(dc *msgp.Reader) (err error) {
 				}
 				z.SendOrder[za0002] = za0003
 			}
+		case "released":
+			z.Released, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "Released")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -113,9 +119,9 @@ func (z *CreationPhaseMetadata) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *CreationPhaseMetadata) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 2
+	// map header, size 3
 	// write "shards"
-	err = en.Append(0x82, 0xa6, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73)
+	err = en.Append(0x83, 0xa6, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73)
 	if err != nil {
 		return
 	}
@@ -167,6 +173,16 @@ func (z *CreationPhaseMetadata) EncodeMsg(en *msgp.Writer) (err error) {
 			}
 		}
 	}
+	// write "released"
+	err = en.Append(0xa8, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.Released)
+	if err != nil {
+		err = msgp.WrapError(err, "Released")
+		return
+	}
 	return
 }
 
@@ -192,6 +208,7 @@ func (z *CreationPhaseMetadata) Msgsize() (s int) {
 			}
 		}
 	}
+	s += 9 + msgp.Int64Size
 	return
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/archive"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
 	"github.com/NVIDIA/aistore/fs"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -158,6 +159,55 @@ var _ = Describe("RequestSpec", func() {
 			Expect(pars.InputExtension).To(Equal(archive.ExtZip))
 		})
 
+		It("should parse spec with .parquet extension and output shard records", func() {
+			rs := RequestSpec{
+				InputBck:           cmn.Bck{Name: "test"},
+				InputExtension:     shard.ExtParquet,
+				InputFormat:        newInputFormat("prefix-{0010..0111}-suffix"),
+				OutputFormat:       "prefix-%06d-suffix",
+				OutputShardRecords: 1000,
+				Algorithm:          Algorithm{Kind: Content, Ext: ".price", ContentKeyType: shard.ContentKeyFloat},
+			}
+			pars, err := rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(pars.InputExtension).To(Equal(shard.ExtParquet))
+			Expect(pars.OutputExtension).To(Equal(shard.ExtParquet))
+			Expect(pars.OutputShardRecords).To(Equal(1000))
+		})
+
+		It("should fail to reshard .parquet into archive", func() {
+			rs := RequestSpec{
+				InputBck:        cmn.Bck{Name: "test"},
+				InputExtension:  shard.ExtParquet,
+				OutputExtension: archive.ExtTar,
+				InputFormat:     newInputFormat("prefix-{0010..0111}-suffix"),
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: "10KB",
+				Algorithm:       Algorithm{Kind: None},
+			}
+			_, err := rs.parse()
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should parse .arrow spec and fail to reshard it into .parquet", func() {
+			rs := RequestSpec{
+				InputBck:           cmn.Bck{Name: "test"},
+				InputFormat:        newInputFormat("prefix-{0010..0111}.arrow"),
+				OutputFormat:       "prefix-%06d.arrow",
+				OutputShardRecords: 1000,
+				Algorithm:          Algorithm{Kind: Content, Ext: ".label", ContentKeyType: shard.ContentKeyString},
+			}
+			pars, err := rs.parse()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pars.InputExtension).To(Equal(shard.ExtArrow))
+			Expect(pars.OutputExtension).To(Equal(shard.ExtArrow))
+
+			rs.OutputFormat = "prefix-%06d.parquet"
+			_, err = rs.parse()
+			Expect(err).Should(HaveOccurred())
+		})
+
 		It("should parse spec with %06d syntax", func() {
 			rs := RequestSpec{
 				InputBck:        cmn.Bck{Name: "test"},
@@ -13,7 +13,6 @@ import (
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
-	"github.com/NVIDIA/aistore/cmn/archive"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/ext/dsort/shard"
 )
@@ -42,6 +41,7 @@ type parsedReqSpec struct {
 	InputExtension      string                `json:"input_extension"`
 	OutputExtension     string                `json:"output_extension"`
 	OutputShardSize     int64                 `json:"output_shard_size,string"`
+	OutputShardRecords  int                   `json:"output_shard_records"`
 	Pit                 *parsedInputTemplate  `json:"pit"`
 	Pot                 *parsedOutputTemplate `json:"pot"`
 	Algorithm           *Algorithm            `json:"algorithm"`
@@ -121,7 +121,7 @@ func (rs *RequestSpec) parse() (*parsedReqSpec, error) {
 	if rs.InputFormat.Template != "" {
 		// template is not a filename but all we do here is
 		// checking the template's suffix for specific supported extensions
-		if ext, err := archive.Mime("", rs.InputFormat.Template); err == nil {
+		if ext, err := shard.Mime("", rs.InputFormat.Template); err == nil {
 			if rs.InputExtension != "" && rs.InputExtension != ext {
 				return nil, fmt.Errorf("input_extension: %q vs %q", rs.InputExtension, ext)
 			}
@@ -129,7 +129,7 @@ func (rs *RequestSpec) parse() (*parsedReqSpec, error) {
 		}
 	}
 	if rs.InputExtension != "" {
-		pars.InputExtension, err = archive.Mime(rs.InputExtension, "")
+		pars.InputExtension, err = shard.Mime(rs.InputExtension, "")
 		if err != nil {
 			return nil, specErr("input_extension", err)
 		}
@@ -143,6 +143,10 @@ func (rs *RequestSpec) parse() (*parsedReqSpec, error) {
 	if pars.OutputShardSize < 0 {
 		return nil, fmt.Errorf(fmtErrNegOutputSize, pars.OutputShardSize)
 	}
+	if rs.OutputShardRecords < 0 {
+		return nil, fmt.Errorf(fmtErrNegOutputRecs, rs.OutputShardRecords)
+	}
+	pars.OutputShardRecords = rs.OutputShardRecords
 	pars.Algorithm, err = parseAlgorithm(rs.Algorithm)
 	if err != nil {
 		return nil, specErr("algorithm", err)
@@ -157,14 +161,14 @@ func (rs *RequestSpec) parse() (*parsedReqSpec, error) {
 			return nil, err
 		}
 		if pars.Pot.Template.Count() > math.MaxInt32 {
-			// If the count is not defined the output shard size must be
-			if pars.OutputShardSize == 0 {
+			// If the count is not defined the output shard size (or number of records) must be
+			if pars.OutputShardSize == 0 && pars.OutputShardRecords == 0 {
 				return nil, errMissingOutputSize
 			}
 		}
 		if rs.OutputFormat != "" {
 			// (ditto)
-			if ext, err := archive.Mime("", rs.OutputFormat); err == nil {
+			if ext, err := shard.Mime("", rs.OutputFormat); err == nil {
 				if rs.OutputExtension != "" && rs.OutputExtension != ext {
 					return nil, fmt.Errorf("output_extension: %q vs %q", rs.OutputExtension, ext)
 				}
@@ -185,11 +189,17 @@ func (rs *RequestSpec) parse() (*parsedReqSpec, error) {
 	if rs.OutputExtension == "" {
 		pars.OutputExtension = pars.InputExtension // default
 	} else {
-		pars.OutputExtension, err = archive.Mime(rs.OutputExtension, "")
+		pars.OutputExtension, err = shard.Mime(rs.OutputExtension, "")
 		if err != nil {
 			return nil, specErr("output_extension", err)
 		}
 	}
+	// record-oriented formats (Parquet, Arrow) cannot be resharded into archives (and vice versa)
+	// or into each other
+	inRec, outRec := shard.IsRecordFormat(pars.InputExtension), shard.IsRecordFormat(pars.OutputExtension)
+	if inRec != outRec || (inRec && pars.InputExtension != pars.OutputExtension) {
+		return nil, fmt.Errorf(fmtErrRecordFormat, pars.InputExtension, pars.OutputExtension)
+	}
 
 	// mem & conc
 	if rs.MaxMemUsage == "" {
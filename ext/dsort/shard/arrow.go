@@ -0,0 +1,348 @@
+// Package shard provides Extract(shard), Create(shard), and associated methods
+// across all suppported archival formats (see cmn/archive/mime.go)
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package shard
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// Arrow IPC shards - same as Parquet (see parquet.go) except:
+// - input shards can be either in IPC file (random access) or streaming format;
+// - each record's content is a self-contained single-row IPC stream (schema included);
+// - output shards are written in IPC file format, in record batches of up to arrowBatchRows rows each.
+
+const ExtArrow = ".arrow"
+
+const arrowBatchRows = 64 * 1024 // max rows per output record batch
+
+type arrowRW struct {
+	keyExt string // Content algorithm's extension, if any (see parquet.go)
+}
+
+// interface guard
+var _ RW = (*arrowRW)(nil)
+
+var arrowMagic = []byte("ARROW1")
+
+/////////////
+// arrowRW //
+/////////////
+
+// keyExt: sorting key column when using `Content` algorithm (empty otherwise)
+func NewArrowRW(keyExt string) RW { return &arrowRW{keyExt: keyExt} }
+
+func (*arrowRW) IsCompressed() bool   { return false }
+func (*arrowRW) SupportsOffset() bool { return false }
+func (*arrowRW) MetadataSize() int64  { return 0 }
+
+func (arw *arrowRW) Extract(lom *core.LOM, r cos.ReadReaderAt, extractor RecordExtractor, toDisk bool) (int64, int, error) {
+	buf, slab := core.T.PageMM().Alloc()
+	args := extractRecordArgs{shardName: lom.ObjName, fileType: fs.ObjectType, buf: buf}
+	args.extractMethod = ExtractToMem
+	if toDisk {
+		args.extractMethod = ExtractToDisk
+	}
+	size, cnt, err := arw.extract(r, lom.Lsize(), extractor, &args)
+	slab.Free(buf)
+	if err != nil {
+		err = fmt.Errorf("%s: %w", lom.Cname(), err)
+	}
+	return size, cnt, err
+}
+
+// arrow record batch reader: ipc.FileReader or ipc.Reader
+type arrowReader interface {
+	Schema() *arrow.Schema
+	next() (arrow.Record, error) // io.EOF when done
+	close() error
+}
+
+type (
+	arrowFileReader struct {
+		*ipc.FileReader
+		i int
+	}
+	arrowStreamReader struct {
+		*ipc.Reader
+	}
+)
+
+func (fr *arrowFileReader) next() (arrow.Record, error) {
+	if fr.i >= fr.NumRecords() {
+		return nil, io.EOF
+	}
+	rec, err := fr.Record(fr.i)
+	fr.i++
+	return rec, err
+}
+
+func (fr *arrowFileReader) close() error { return fr.Close() }
+
+func (sr *arrowStreamReader) next() (arrow.Record, error) {
+	if sr.Next() {
+		return sr.Record(), nil
+	}
+	if err := sr.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func (sr *arrowStreamReader) close() error { sr.Release(); return nil }
+
+func newArrowReader(r io.ReaderAt, size int64) (arrowReader, error) {
+	var (
+		sr    = io.NewSectionReader(r, 0, size)
+		magic = make([]byte, len(arrowMagic))
+	)
+	if _, err := r.ReadAt(magic, 0); err == nil && bytes.Equal(magic, arrowMagic) {
+		fr, err := ipc.NewFileReader(sr)
+		if err != nil {
+			return nil, err
+		}
+		return &arrowFileReader{FileReader: fr}, nil
+	}
+	rr, err := ipc.NewReader(sr)
+	if err != nil {
+		return nil, err
+	}
+	return &arrowStreamReader{Reader: rr}, nil
+}
+
+func (arw *arrowRW) extract(r io.ReaderAt, size int64, extractor RecordExtractor, args *extractRecordArgs) (int64, int, error) {
+	ar, err := newArrowReader(r, size)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to open arrow shard: %w", err)
+	}
+	defer ar.close()
+
+	var (
+		schema         = ar.Schema()
+		keyPath        []int
+		extractedSize  int64
+		extractedCount int
+		rowIdx         int64
+		rowBuf         bytes.Buffer
+	)
+	if arw.keyExt != "" {
+		if keyPath, err = arrowKeyPath(schema, arw.keyExt[1:]); err != nil {
+			return 0, 0, err
+		}
+	}
+	for {
+		rec, err := ar.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return extractedSize, extractedCount, fmt.Errorf("failed to read record batch: %w", err)
+		}
+		for i := range rec.NumRows() {
+			name := fmt.Sprintf("%012d", rowIdx)
+			rowIdx++
+
+			// row => single-row IPC stream
+			rowBuf.Reset()
+			row := rec.NewSlice(i, i+1)
+			err := writeArrowRecord(&rowBuf, schema, row)
+			row.Release()
+			if err != nil {
+				return extractedSize, extractedCount, err
+			}
+			args.recordName = name + ExtArrow
+			args.r = cos.NewSizedReader(bytes.NewReader(rowBuf.Bytes()), int64(rowBuf.Len()))
+			size, err := extractor.RecordWithBuffer(args)
+			if err != nil {
+				return extractedSize, extractedCount, err
+			}
+			extractedSize += size
+			extractedCount++
+
+			// sorting key
+			if keyPath == nil {
+				continue
+			}
+			key := arrowKey(rec, keyPath, int(i))
+			args.recordName = name + arw.keyExt
+			args.r = cos.NewSizedReader(strings.NewReader(key), int64(len(key)))
+			if size, err = extractor.RecordWithBuffer(args); err != nil {
+				return extractedSize, extractedCount, err
+			}
+			extractedSize += size
+			extractedCount++
+		}
+	}
+	return extractedSize, extractedCount, nil
+}
+
+// Create writes all rows of all records into a single IPC file (shard)
+// in record batches of up to arrowBatchRows rows;
+// key objects (see parquet.go) are loaded (to release their content) and discarded.
+func (*arrowRW) Create(s *Shard, w io.Writer, loader ContentLoader) (int64, error) {
+	var (
+		pw     = &parquetW{w: w} // (counting writer)
+		writer *ipc.FileWriter
+		schema *arrow.Schema
+		rowBuf bytes.Buffer
+		batch  []arrow.Record
+	)
+	defer func() {
+		for _, rec := range batch {
+			rec.Release()
+		}
+	}()
+	for _, rec := range s.Records.All() {
+		for _, obj := range rec.Objects {
+			if obj.Extension != ExtArrow {
+				if _, err := loader.Load(io.Discard, rec, obj); err != nil {
+					return pw.n, err
+				}
+				continue
+			}
+			rowBuf.Reset()
+			if _, err := loader.Load(&rowBuf, rec, obj); err != nil {
+				return pw.n, err
+			}
+			rr, err := ipc.NewReader(bytes.NewReader(rowBuf.Bytes()))
+			if err != nil {
+				return pw.n, fmt.Errorf("record %q: %w", rec.Name, err)
+			}
+			if writer == nil {
+				schema = rr.Schema()
+				if writer, err = ipc.NewFileWriter(pw, ipc.WithSchema(schema)); err != nil {
+					rr.Release()
+					return pw.n, err
+				}
+			}
+			for rr.Next() {
+				row := rr.Record()
+				row.Retain()
+				batch = append(batch, row)
+			}
+			err = rr.Err()
+			rr.Release()
+			if err != nil {
+				return pw.n, fmt.Errorf("record %q: %w", rec.Name, err)
+			}
+			if len(batch) >= arrowBatchRows {
+				if err := writeArrowBatch(writer, schema, batch); err != nil {
+					return pw.n, err
+				}
+				batch = batch[:0]
+			}
+		}
+	}
+	if writer == nil {
+		return 0, errors.New("cannot create arrow shard " + s.Name + " with no rows")
+	}
+	if len(batch) > 0 {
+		if err := writeArrowBatch(writer, schema, batch); err != nil {
+			return pw.n, err
+		}
+	}
+	err := writer.Close()
+	return pw.n, err
+}
+
+func writeArrowRecord(w io.Writer, schema *arrow.Schema, rec arrow.Record) error {
+	writer := ipc.NewWriter(w, ipc.WithSchema(schema))
+	if err := writer.Write(rec); err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+// concatenate (single-row) records into a single batch and write it; releases the records
+func writeArrowBatch(writer *ipc.FileWriter, schema *arrow.Schema, recs []arrow.Record) error {
+	var (
+		cols  = make([]arrow.Array, schema.NumFields())
+		parts = make([]arrow.Array, len(recs))
+		nrows int64
+	)
+	defer func() {
+		for _, col := range cols {
+			if col != nil {
+				col.Release()
+			}
+		}
+		for _, rec := range recs {
+			rec.Release()
+		}
+	}()
+	for _, rec := range recs {
+		nrows += rec.NumRows()
+	}
+	for j := range cols {
+		for i, rec := range recs {
+			parts[i] = rec.Column(j)
+		}
+		col, err := array.Concatenate(parts, memory.DefaultAllocator)
+		if err != nil {
+			return fmt.Errorf("column %q: %w", schema.Field(j).Name, err)
+		}
+		cols[j] = col
+	}
+	batch := array.NewRecord(schema, cols, nrows)
+	err := writer.Write(batch)
+	batch.Release()
+	return err
+}
+
+// field indices of a (possibly nested, e.g. "meta.label") struct field
+func arrowKeyPath(schema *arrow.Schema, name string) ([]int, error) {
+	var (
+		path   []int
+		fields = schema.Fields()
+	)
+	for _, part := range strings.Split(name, ".") {
+		idx := -1
+		for i := range fields {
+			if fields[i].Name == part {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, fmt.Errorf("sorting key column %q not found in the schema %s", name, schema)
+		}
+		path = append(path, idx)
+		if st, ok := fields[idx].Type.(*arrow.StructType); ok {
+			fields = st.Fields()
+		} else {
+			fields = nil
+		}
+	}
+	return path, nil
+}
+
+// text representation of the (leaf) column value in a given row
+func arrowKey(rec arrow.Record, path []int, row int) string {
+	arr := rec.Column(path[0])
+	for _, idx := range path[1:] {
+		st, ok := arr.(*array.Struct)
+		if !ok {
+			return ""
+		}
+		arr = st.Field(idx)
+	}
+	if arr.IsNull(row) {
+		return ""
+	}
+	return arr.ValueStr(row)
+}
@@ -0,0 +1,268 @@
+// Package shard provides Extract(shard), Create(shard), and associated methods
+// across all suppported archival formats (see cmn/archive/mime.go)
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package shard
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/parquet-go/parquet-go"
+)
+
+// Parquet is a record-oriented (columnar) format rather than an archive:
+// - each row of each row group becomes a separate dsort record
+// - the record's content is a self-contained single-row Parquet file (schema included)
+// - to sort by column value (`Content` algorithm), the algorithm's extension
+//   names the column (e.g. ".price" or ".meta.label" for nested fields);
+//   in addition to its row, each record then carries a (text) key object
+//   with this extension
+// - all input shards must share the same schema; each output shard is a single
+//   Parquet file (with the schema of its first record) that stores its records'
+//   rows in order, in row groups of up to parquetRowGroupRows rows each
+// - the (plain) sorting key column must not contain nulls
+
+const ExtParquet = ".parquet"
+
+const (
+	parquetReadBatch    = 128       // rows
+	parquetRowGroupRows = 64 * 1024 // max rows per output row group
+)
+
+type (
+	parquetRW struct {
+		keyExt string // Content algorithm's extension, if any (see above)
+	}
+	// counts bytes written to the output shard
+	parquetW struct {
+		w io.Writer
+		n int64
+	}
+)
+
+// interface guard
+var _ RW = (*parquetRW)(nil)
+
+// Mime extends archive.Mime (see cmn/archive/mime.go) to include record-oriented formats.
+func Mime(mime, filename string) (string, error) {
+	for _, ext := range []string{ExtParquet, ExtArrow} {
+		if mime != "" {
+			if strings.Contains(mime, ext[1:]) {
+				return ext, nil
+			}
+		} else if strings.HasSuffix(filename, ext) {
+			return ext, nil
+		}
+	}
+	return archive.Mime(mime, filename)
+}
+
+func IsRecordFormat(ext string) bool { return ext == ExtParquet || ext == ExtArrow }
+
+///////////////
+// parquetRW //
+///////////////
+
+// keyExt: sorting key column when using `Content` algorithm (empty otherwise)
+func NewParquetRW(keyExt string) RW { return &parquetRW{keyExt: keyExt} }
+
+func (*parquetRW) IsCompressed() bool   { return true } // (column chunks are typically compressed)
+func (*parquetRW) SupportsOffset() bool { return false }
+func (*parquetRW) MetadataSize() int64  { return 0 }
+
+func (prw *parquetRW) Extract(lom *core.LOM, r cos.ReadReaderAt, extractor RecordExtractor, toDisk bool) (int64, int, error) {
+	buf, slab := core.T.PageMM().Alloc()
+	args := extractRecordArgs{shardName: lom.ObjName, fileType: fs.ObjectType, buf: buf}
+	args.extractMethod = ExtractToMem
+	if toDisk {
+		args.extractMethod = ExtractToDisk
+	}
+	size, cnt, err := prw.extract(r, lom.Lsize(), extractor, &args)
+	slab.Free(buf)
+	if err != nil {
+		err = fmt.Errorf("%s: %w", lom.Cname(), err)
+	}
+	return size, cnt, err
+}
+
+func (prw *parquetRW) extract(r io.ReaderAt, size int64, extractor RecordExtractor, args *extractRecordArgs) (int64, int, error) {
+	f, err := parquet.OpenFile(r, size)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to open parquet shard: %w", err)
+	}
+	var (
+		schema         = f.Schema()
+		keyIdx         = -1
+		extractedSize  int64
+		extractedCount int
+		rowIdx         int64
+		rowBuf         bytes.Buffer
+		rows           = make([]parquet.Row, parquetReadBatch)
+	)
+	if prw.keyExt != "" {
+		leaf, ok := schema.Lookup(strings.Split(prw.keyExt[1:], ".")...)
+		if !ok {
+			return 0, 0, fmt.Errorf("sorting key column %q not found in the schema %s", prw.keyExt[1:], schema)
+		}
+		keyIdx = leaf.ColumnIndex
+	}
+
+	for _, rg := range f.RowGroups() {
+		rr := rg.Rows()
+		for {
+			n, errR := rr.ReadRows(rows)
+			for i := range n {
+				name := fmt.Sprintf("%012d", rowIdx)
+				rowIdx++
+
+				// row => single-row parquet file
+				rowBuf.Reset()
+				if err := writeParquetRows(&rowBuf, schema, rows[i:i+1]); err != nil {
+					rr.Close()
+					return extractedSize, extractedCount, err
+				}
+				args.recordName = name + ExtParquet
+				args.r = cos.NewSizedReader(bytes.NewReader(rowBuf.Bytes()), int64(rowBuf.Len()))
+				size, err := extractor.RecordWithBuffer(args)
+				if err != nil {
+					rr.Close()
+					return extractedSize, extractedCount, err
+				}
+				extractedSize += size
+				extractedCount++
+
+				// sorting key
+				if keyIdx < 0 {
+					continue
+				}
+				key := parquetKey(rows[i], keyIdx)
+				args.recordName = name + prw.keyExt
+				args.r = cos.NewSizedReader(strings.NewReader(key), int64(len(key)))
+				if size, err = extractor.RecordWithBuffer(args); err != nil {
+					rr.Close()
+					return extractedSize, extractedCount, err
+				}
+				extractedSize += size
+				extractedCount++
+			}
+			if errR == io.EOF {
+				break
+			}
+			if errR != nil {
+				rr.Close()
+				return extractedSize, extractedCount, fmt.Errorf("failed to read rows: %w", errR)
+			}
+		}
+		rr.Close()
+	}
+	return extractedSize, extractedCount, nil
+}
+
+// Create writes all rows of all records into a single parquet file (shard)
+// in row groups of up to parquetRowGroupRows rows;
+// key objects (see above) are loaded (to release their content) and discarded.
+func (*parquetRW) Create(s *Shard, w io.Writer, loader ContentLoader) (int64, error) {
+	var (
+		pw     = &parquetW{w: w}
+		writer *parquet.Writer
+		rowBuf bytes.Buffer
+		rows   = make([]parquet.Row, parquetReadBatch)
+	)
+	for _, rec := range s.Records.All() {
+		for _, obj := range rec.Objects {
+			if obj.Extension != ExtParquet {
+				if _, err := loader.Load(io.Discard, rec, obj); err != nil {
+					return pw.n, err
+				}
+				continue
+			}
+			rowBuf.Reset()
+			if _, err := loader.Load(&rowBuf, rec, obj); err != nil {
+				return pw.n, err
+			}
+			f, err := parquet.OpenFile(bytes.NewReader(rowBuf.Bytes()), int64(rowBuf.Len()))
+			if err != nil {
+				return pw.n, fmt.Errorf("record %q: %w", rec.Name, err)
+			}
+			if writer == nil {
+				writer = parquet.NewWriter(pw, f.Schema(), parquet.MaxRowsPerRowGroup(parquetRowGroupRows))
+			}
+			if err := copyParquetRows(writer, f, rows); err != nil {
+				return pw.n, fmt.Errorf("record %q: %w", rec.Name, err)
+			}
+		}
+	}
+	if writer == nil {
+		return 0, errors.New("cannot create parquet shard " + s.Name + " with no rows")
+	}
+	err := writer.Close()
+	return pw.n, err
+}
+
+func writeParquetRows(w io.Writer, schema *parquet.Schema, rows []parquet.Row) error {
+	writer := parquet.NewWriter(w, schema)
+	if _, err := writer.WriteRows(rows); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+func copyParquetRows(writer *parquet.Writer, f *parquet.File, rows []parquet.Row) error {
+	for _, rg := range f.RowGroups() {
+		rr := rg.Rows()
+		for {
+			n, err := rr.ReadRows(rows)
+			if n > 0 {
+				if _, errW := writer.WriteRows(rows[:n]); errW != nil {
+					rr.Close()
+					return errW
+				}
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				rr.Close()
+				return err
+			}
+		}
+		rr.Close()
+	}
+	return nil
+}
+
+// text representation of the first non-null value in a given (leaf) column
+func parquetKey(row parquet.Row, columnIdx int) string {
+	for _, v := range row {
+		if v.Column() != columnIdx || v.IsNull() {
+			continue
+		}
+		switch v.Kind() {
+		case parquet.Double:
+			return strconv.FormatFloat(v.Double(), 'g', -1, 64)
+		default:
+			return v.String()
+		}
+	}
+	return ""
+}
+
+//////////////
+// parquetW //
+//////////////
+
+func (pw *parquetW) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.n += int64(n)
+	return n, err
+}
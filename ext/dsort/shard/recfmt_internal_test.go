@@ -0,0 +1,198 @@
+// Package shard provides Extract(shard), Create(shard), and associated methods
+// across all suppported archival formats (see cmn/archive/mime.go)
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package shard
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/parquet-go/parquet-go"
+)
+
+// in-memory RecordExtractor and ContentLoader
+type memRecords struct {
+	contents map[string][]byte // by record name (incl. extension)
+}
+
+func (mr *memRecords) RecordWithBuffer(args *extractRecordArgs) (int64, error) {
+	b, err := io.ReadAll(args.r)
+	if err != nil {
+		return 0, err
+	}
+	mr.contents[args.recordName] = b
+	return int64(len(b)), nil
+}
+
+func (mr *memRecords) Load(w io.Writer, rec *Record, obj *RecordObj) (int64, error) {
+	n, err := w.Write(mr.contents[rec.Name+obj.Extension])
+	return int64(n), err
+}
+
+// records sorted by their (float) keys, in decreasing order
+func (mr *memRecords) sorted(t *testing.T, ext, keyExt string) *Records {
+	var (
+		recs []*Record
+		keys = make(map[string]float64)
+	)
+	for name := range mr.contents {
+		if !strings.HasSuffix(name, ext) {
+			continue
+		}
+		base := strings.TrimSuffix(name, ext)
+		key, err := strconv.ParseFloat(string(mr.contents[base+keyExt]), 64)
+		tassert.CheckFatal(t, err)
+		keys[base] = key
+		recs = append(recs, &Record{Name: base, Objects: []*RecordObj{{Extension: ext}, {Extension: keyExt}}})
+	}
+	sort.Slice(recs, func(i, j int) bool { return keys[recs[i].Name] > keys[recs[j].Name] })
+	return NewRecordsFrom(recs)
+}
+
+var (
+	rtNames  = []string{"a", "b", "c", "d", "e", "f", "g"}
+	rtPrices = []float64{3.5, 1, 7.25, 0.5, 2, 10, 4}
+	rtSorted = []string{"f", "c", "g", "a", "e", "b", "d"} // by price, decreasing
+)
+
+type rtRow struct {
+	Name  string  `parquet:"name"`
+	Price float64 `parquet:"price"`
+}
+
+func TestParquetRoundTrip(t *testing.T) {
+	// input shard: two row groups
+	var in bytes.Buffer
+	pw := parquet.NewGenericWriter[rtRow](&in)
+	for i := range rtNames {
+		_, err := pw.Write([]rtRow{{Name: rtNames[i], Price: rtPrices[i]}})
+		tassert.CheckFatal(t, err)
+		if i == 3 {
+			tassert.CheckFatal(t, pw.Flush())
+		}
+	}
+	tassert.CheckFatal(t, pw.Close())
+
+	var (
+		prw = &parquetRW{keyExt: ".price"}
+		mr  = &memRecords{contents: make(map[string][]byte)}
+	)
+	_, cnt, err := prw.extract(bytes.NewReader(in.Bytes()), int64(in.Len()), mr, &extractRecordArgs{})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, cnt == 2*len(rtNames), "expected %d extracted records, got %d", 2*len(rtNames), cnt)
+
+	// output shard
+	var out bytes.Buffer
+	s := &Shard{Name: "out.parquet", Records: mr.sorted(t, ExtParquet, ".price")}
+	n, err := prw.Create(s, &out, mr)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, n == int64(out.Len()), "expected %d bytes written, got %d", out.Len(), n)
+
+	f, err := parquet.OpenFile(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, f.NumRows() == int64(len(rtNames)), "expected %d rows, got %d", len(rtNames), f.NumRows())
+	tassert.Errorf(t, len(f.RowGroups()) == 1, "expected a single row group, got %d", len(f.RowGroups()))
+
+	rows, err := parquet.Read[rtRow](bytes.NewReader(out.Bytes()), int64(out.Len()))
+	tassert.CheckFatal(t, err)
+	for i, row := range rows {
+		tassert.Errorf(t, row.Name == rtSorted[i], "row %d: expected %q, got %q", i, rtSorted[i], row.Name)
+	}
+}
+
+func TestArrowRoundTrip(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "name", Type: arrow.BinaryTypes.String},
+		{Name: "meta", Type: arrow.StructOf(arrow.Field{Name: "price", Type: arrow.PrimitiveTypes.Float64})},
+	}, nil)
+
+	// input shards: IPC file and stream formats, two record batches each
+	for _, format := range []string{"file", "stream"} {
+		t.Run(format, func(t *testing.T) {
+			var (
+				in bytes.Buffer
+				w  interface {
+					Write(arrow.Record) error
+					Close() error
+				}
+			)
+			if format == "file" {
+				fw, err := ipc.NewFileWriter(&in, ipc.WithSchema(schema))
+				tassert.CheckFatal(t, err)
+				w = fw
+			} else {
+				w = ipc.NewWriter(&in, ipc.WithSchema(schema))
+			}
+			for _, span := range [][2]int{{0, 4}, {4, len(rtNames)}} {
+				rec := arrowTestBatch(schema, span[0], span[1])
+				tassert.CheckFatal(t, w.Write(rec))
+				rec.Release()
+			}
+			tassert.CheckFatal(t, w.Close())
+
+			var (
+				arw = &arrowRW{keyExt: ".meta.price"}
+				mr  = &memRecords{contents: make(map[string][]byte)}
+			)
+			_, cnt, err := arw.extract(bytes.NewReader(in.Bytes()), int64(in.Len()), mr, &extractRecordArgs{})
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, cnt == 2*len(rtNames), "expected %d extracted records, got %d", 2*len(rtNames), cnt)
+
+			// output shard (IPC file)
+			var out bytes.Buffer
+			s := &Shard{Name: "out.arrow", Records: mr.sorted(t, ExtArrow, ".meta.price")}
+			n, err := arw.Create(s, &out, mr)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, n == int64(out.Len()), "expected %d bytes written, got %d", out.Len(), n)
+
+			fr, err := ipc.NewFileReader(bytes.NewReader(out.Bytes()))
+			tassert.CheckFatal(t, err)
+			defer fr.Close()
+			tassert.Fatalf(t, fr.NumRecords() == 1, "expected a single record batch, got %d", fr.NumRecords())
+			rec, err := fr.Record(0)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, rec.NumRows() == int64(len(rtNames)), "expected %d rows, got %d", len(rtNames), rec.NumRows())
+			names := rec.Column(0).(*array.String)
+			for i := range rtSorted {
+				tassert.Errorf(t, names.Value(i) == rtSorted[i], "row %d: expected %q, got %q", i, rtSorted[i], names.Value(i))
+			}
+		})
+	}
+}
+
+func TestRecordFormatMime(t *testing.T) {
+	for name, ext := range map[string]string{"a.parquet": ExtParquet, "b.arrow": ExtArrow, "c.tar": ".tar"} {
+		mime, err := Mime("", filepath.Join("dir", name))
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, mime == ext, "%s: expected %q, got %q", name, ext, mime)
+	}
+	tassert.Errorf(t, IsRecordFormat(ExtArrow) && IsRecordFormat(ExtParquet) && !IsRecordFormat(".tar"), "IsRecordFormat")
+}
+
+func arrowTestBatch(schema *arrow.Schema, from, to int) arrow.Record {
+	b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer b.Release()
+	var (
+		nb = b.Field(0).(*array.StringBuilder)
+		sb = b.Field(1).(*array.StructBuilder)
+		pb = sb.FieldBuilder(0).(*array.Float64Builder)
+	)
+	for i := from; i < to; i++ {
+		nb.Append(rtNames[i])
+		sb.Append(true)
+		pb.Append(rtPrices[i])
+	}
+	return b.NewRecord()
+}
@@ -302,8 +302,9 @@ func (recm *RecordManager) FreeMem(fullContentPath, newStoreType string, value a
 		return
 	}
 	obj := record.Objects[idx]
-
-	debug.Assert(obj.StoreType == SGLStoreType, obj.StoreType+" vs "+SGLStoreType) // only SGLs are supported
+	if obj.StoreType != SGLStoreType {
+		return // (e.g., concurrently freed by memory watcher and dsort checkpoint)
+	}
 
 	switch newStoreType {
 	case OffsetStoreType:
@@ -311,7 +312,7 @@ func (recm *RecordManager) FreeMem(fullContentPath, newStoreType string, value a
 		obj.ContentPath = shardName
 		obj.MetadataSize = recm.extractCreator.MetadataSize()
 	case DiskStoreType:
-		diskPath := recm.FullContentPath(obj)
+		diskPath := recm.FullContentPath(&RecordObj{ContentPath: obj.ContentPath, StoreType: DiskStoreType})
 		// No matter what the outcome we should store `path` in
 		// `extractionPaths` to make sure that all files, even incomplete ones,
 		// are deleted (if the file will not exist this is not much of a
@@ -342,7 +343,6 @@ func (recm *RecordManager) ExtractionPaths() *sync.Map {
 }
 
 func (recm *RecordManager) Cleanup() {
-	recm.Records.Drain()
 	recm.extractionPaths.Range(func(k, _ any) bool {
 		if err := fs.RemoveAll(k.(string)); err != nil {
 			nlog.Errorf("could not remove extraction path (%v) from previous run, err: %v", k, err)
@@ -351,6 +351,13 @@ func (recm *RecordManager) Cleanup() {
 		return true
 	})
 	recm.extractionPaths = nil
+	recm.Release()
+}
+
+// Release is Cleanup that keeps extracted content on disk
+// (so that the latter could be reused when resuming dsort job)
+func (recm *RecordManager) Release() {
+	recm.Records.Drain()
 	recm.contents.Range(func(k, v any) bool {
 		if sgl, ok := v.(*memsys.SGL); ok {
 			sgl.Free()
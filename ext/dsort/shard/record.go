@@ -209,6 +209,11 @@ func (r *Records) All() []*Record {
 	return r.arr
 }
 
+// NewRecordsFrom wraps already merged (and sorted) records - no dedup, no lookups.
+func NewRecordsFrom(arr []*Record) *Records {
+	return &Records{arr: arr}
+}
+
 func (r *Records) Slice(start, end int) *Records {
 	return &Records{
 		arr: r.arr[start:end],
@@ -222,11 +227,16 @@ func (r *Records) Len() int {
 func (r *Records) Swap(i, j int) { r.arr[i], r.arr[j] = r.arr[j], r.arr[i] }
 
 func (r *Records) Less(i, j int, keyType string) (bool, error) {
-	lhs, rhs := r.arr[i].Key, r.arr[j].Key
+	return LessKeys(r.arr[i], r.arr[j], keyType)
+}
+
+// LessKeys compares two records by their keys (see also: Records.Less)
+func LessKeys(lrec, rrec *Record, keyType string) (bool, error) {
+	lhs, rhs := lrec.Key, rrec.Key
 	if lhs == nil {
-		return false, errors.Errorf("key is missing for %q", r.arr[i].Name)
+		return false, errors.Errorf("key is missing for %q", lrec.Name)
 	} else if rhs == nil {
-		return false, errors.Errorf("key is missing for %q", r.arr[j].Name)
+		return false, errors.Errorf("key is missing for %q", rrec.Name)
 	}
 
 	switch keyType {
@@ -257,7 +267,7 @@ func (r *Records) Less(i, j int, keyType string) (bool, error) {
 		return slhs < srhs, nil
 	}
 
-	debug.Assertf(false, "lhs: %v, rhs: %v, arr[i]: %v, arr[j]: %v", lhs, rhs, r.arr[i], r.arr[j])
+	debug.Assertf(false, "lhs: %v, rhs: %v, lrec: %v, rrec: %v", lhs, rhs, lrec, rrec)
 	return false, nil
 }
 
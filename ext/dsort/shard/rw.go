@@ -29,6 +29,8 @@ var (
 		archive.ExtTarGz:  &tgzRW{archive.ExtTarGz},
 		archive.ExtTarLz4: &tlz4RW{archive.ExtTarLz4},
 		archive.ExtZip:    &zipRW{archive.ExtZip},
+		ExtParquet:        &parquetRW{}, // see also: NewParquetRW
+		ExtArrow:          &arrowRW{},   // see also: NewArrowRW
 	}
 )
 
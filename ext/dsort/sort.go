@@ -6,8 +6,10 @@ package dsort
 
 import (
 	"math/rand/v2"
+	"slices"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -30,21 +32,30 @@ func (s *alphaByKey) Len() int      { return s.records.Len() }
 func (s *alphaByKey) Swap(i, j int) { s.records.Swap(i, j) }
 
 func (s *alphaByKey) Less(i, j int) bool {
-	var (
-		err  error
-		less bool
-	)
-	if s.decreasing {
-		less, err = s.records.Less(j, i, s.keyType)
-	} else {
-		less, err = s.records.Less(i, j, s.keyType)
-	}
+	all := s.records.All()
+	less, err := lessRecords(all[i], all[j], s.keyType, s.decreasing)
 	if err != nil {
 		s.err = err
 	}
 	return less
 }
 
+// compares records by their keys and, if equal, by names - the resulting order
+// must not depend on the order in which the records were received (see skipCreated)
+func lessRecords(lrec, rrec *shard.Record, keyType string, decreasing bool) (bool, error) {
+	if decreasing {
+		lrec, rrec = rrec, lrec
+	}
+	less, err := shard.LessKeys(lrec, rrec, keyType)
+	if less || err != nil {
+		return less, err
+	}
+	if more, _ := shard.LessKeys(rrec, lrec, keyType); more {
+		return false, nil
+	}
+	return lrec.Name < rrec.Name, nil
+}
+
 // sorts records by each Record.Key in the order determined by the `alg` algorithm.
 func sortRecords(r *shard.Records, alg *Algorithm) (err error) {
 	switch alg.Kind {
@@ -60,13 +71,15 @@ func sortRecords(r *shard.Records, alg *Algorithm) (err error) {
 			debug.AssertNoErr(err)
 		}
 		rnd = rand.New(rand.NewPCG(uint64(seed), 0))
+		// start from the same order regardless of the order of receiving
+		slices.SortFunc(r.All(), func(a, b *shard.Record) int { return strings.Compare(a.Name, b.Name) })
 		for i := range r.Len() { // https://en.wikipedia.org/wiki/Fisher%E2%80%93Yates_shuffle
 			j := rnd.IntN(i + 1)
 			r.Swap(i, j)
 		}
 	default:
 		keys := &alphaByKey{records: r, decreasing: alg.Decreasing, keyType: alg.ContentKeyType}
-		sort.Sort(keys)
+		sort.Stable(keys)
 		err = keys.err
 	}
 	return
@@ -87,6 +87,32 @@ var _ = Describe("SortRecords", func() {
 		Expect(fm).To(Equal(expected))
 	})
 
+	It("should shuffle records the same way regardless of their initial order", func() {
+		alg := &Algorithm{Kind: Shuffle, Seed: "1010102", ContentKeyType: shard.ContentKeyString}
+		fm1 := createRecords("abc", "def", "ghi", "klm")
+		fm2 := createRecords("ghi", "klm", "def", "abc")
+		Expect(sortRecords(fm1, alg)).To(Succeed())
+		Expect(sortRecords(fm2, alg)).To(Succeed())
+		Expect(fm2).To(Equal(fm1))
+	})
+
+	It("should sort records with equal keys by name", func() {
+		withNames := func(names ...string) *shard.Records {
+			records := shard.NewRecords(len(names))
+			for _, name := range names {
+				records.Insert(&shard.Record{Key: "key", Name: name})
+			}
+			return records
+		}
+		fm := withNames("c", "a", "b")
+		Expect(sortRecords(fm, &Algorithm{ContentKeyType: shard.ContentKeyString})).To(Succeed())
+		Expect(fm).To(Equal(withNames("a", "b", "c")))
+
+		fm = withNames("b", "c", "a")
+		Expect(sortRecords(fm, &Algorithm{Decreasing: true, ContentKeyType: shard.ContentKeyString})).To(Succeed())
+		Expect(fm).To(Equal(withNames("c", "b", "a")))
+	})
+
 	It("should return error when some keys are missing", func() {
 		fm := createRecords("def", "abc")
 		fm.All()[0].Key = nil
@@ -0,0 +1,592 @@
+// Package dsort provides APIs for distributed archive file shuffling.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"container/heap"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/ext/dsort/ct"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/sys"
+	"github.com/pkg/errors"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// External merge sort.
+//
+// When the (estimated) size of the records that a target accumulates during
+// record distribution (its own, received, merged) exceeds the memory budget
+// (see spiller.initBudget), the records get sorted and spilled to local mountpaths as so-called runs - sequences of
+// msgp-encoded shard.Record. Runs:
+// - travel to the next target in the distribution order as part of
+//   the regular (msgp-encoded) shard.Records - see spiller.encode;
+// - on the final target, get k-way merged (see runMerger) to generate output
+//   shards on the fly; the resulting shard metadata is, again, spilled - one file
+//   per target - and streamed to the respective target (see phase3Spilled).
+//
+// Note that ordering file (EKM) and "memory" dsorter (which requires per-target send order)
+// both need all records in memory to generate output shards and, therefore, do not support
+// spilling: such a job keeps all its records in memory (and warns when they don't fit).
+
+const (
+	spillCheckRecs = 64 * 1024 // check memory pressure every so many received records
+	minSpillBudget = 64 * cos.MiB
+)
+
+type (
+	spillRun struct {
+		fqn string
+		cnt int
+	}
+	spiller struct {
+		m      *Manager
+		merger *runMerger
+		runs   []*spillRun
+		fqns   []string // all spill files (cleanup)
+		seq    atomic.Int64
+		budget uint64 // see initBudget
+		once   sync.Once
+		warned atomic.Bool
+		mu     sync.Mutex
+	}
+
+	// reads back a single run
+	runReader struct {
+		fh   *os.File
+		dc   *msgp.Reader
+		cur  *shard.Record
+		slab *memsys.Slab
+		buf  []byte
+		idx  int // (tie-breaker)
+		left int
+	}
+	// k-way merge of all (sorted) runs, in the order determined by the algorithm
+	runMerger struct {
+		alg  *Algorithm
+		rnd  *rand.Rand // (shuffle)
+		err  error
+		rrs  []*runReader
+		left int
+	}
+
+	// spilled output shard metadata destined to a given target
+	shardsW struct {
+		fh       *os.File
+		w        *msgp.Writer
+		slab     *memsys.Slab
+		fqn      string
+		buf      []byte
+		cnt      int
+		released int64 // see CreationPhaseMetadata.Released
+	}
+)
+
+// interface guard
+var _ heap.Interface = (*runMerger)(nil)
+
+/////////////
+// spiller //
+/////////////
+
+// memory budget for the records (metadata) that the target keeps during distribution:
+// what remains within max_mem_usage when the distribution starts, but no less than half
+// the headroom above it - extraction alone may take up to max_mem_usage (see memoryWatcher)
+func (s *spiller) initBudget() {
+	var mem sys.MemStat
+	if err := mem.Get(); err != nil {
+		s.budget = math.MaxUint64
+		return
+	}
+	maxMem := calcMaxMemoryUsage(s.m.Pars.MaxMemUsage, &mem)
+	s.budget = max((mem.Total-maxMem)/2, minSpillBudget)
+	if mem.ActualUsed < maxMem {
+		s.budget = max(s.budget, maxMem-mem.ActualUsed)
+	}
+}
+
+// whether the (estimated) size of the records exceeds the budget
+func (s *spiller) pressure(records *shard.Records) bool {
+	s.once.Do(s.initBudget)
+	n := records.Len()
+	if n == 0 {
+		return false
+	}
+	return uint64(n)*records.RecordMemorySize() > s.budget
+}
+
+// returns what prevents the job from spilling (see above), if anything
+func (s *spiller) unsupported() string {
+	switch {
+	case s.m.Pars.EKMFileURL != "":
+		return "ordering file (ekm_file)"
+	case s.m.dsorter.name() == MemType:
+		return "dsorter " + MemType
+	default:
+		return ""
+	}
+}
+
+// (once) the records do not fit in memory but cannot be spilled either - keep going
+func (s *spiller) warn(what string) {
+	if !s.warned.CAS(false, true) {
+		return
+	}
+	msg := fmt.Sprintf("records exceed memory limit (max_mem_usage %s) and cannot be spilled to disk when using %s",
+		s.m.Pars.MaxMemUsage, what)
+	nlog.Warningf("%s: [dsort] %s: %s", core.T, s.m.ManagerUUID, msg)
+	_ = s.m.react(cmn.WarnReaction, msg)
+}
+
+func (s *spiller) spilled() bool {
+	s.mu.Lock()
+	n := len(s.runs)
+	s.mu.Unlock()
+	return n > 0
+}
+
+func (s *spiller) merging() bool { return s.merger != nil }
+
+func (s *spiller) count() (cnt int) {
+	s.mu.Lock()
+	for _, run := range s.runs {
+		cnt += run.cnt
+	}
+	s.mu.Unlock()
+	return
+}
+
+func (s *spiller) newFQN(tag string) (string, error) {
+	name := path.Join(s.m.ManagerUUID, tag+"-"+strconv.FormatInt(s.seq.Inc(), 10))
+	c, err := core.NewCTFromBO(&s.m.Pars.InputBck, name, nil)
+	if err != nil {
+		return "", err
+	}
+	fqn := c.Make(ct.DsortFileType)
+	s.mu.Lock()
+	s.fqns = append(s.fqns, fqn)
+	s.mu.Unlock()
+	return fqn, nil
+}
+
+// sorts and spills all given records as a new run; drains the records
+func (s *spiller) spill(records *shard.Records) error {
+	if records.Len() == 0 {
+		return nil
+	}
+	if err := sortRecords(records, s.m.Pars.Algorithm); err != nil {
+		return err
+	}
+	fqn, err := s.newFQN("run")
+	if err != nil {
+		return err
+	}
+	fh, err := cos.CreateFile(fqn)
+	if err != nil {
+		return err
+	}
+	var (
+		buf, slab = g.mem.AllocSize(serializationBufSize)
+		w         = msgp.NewWriterBuf(fh, buf)
+		cnt       = records.Len()
+	)
+	for _, r := range records.All() {
+		if err = r.EncodeMsg(w); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	slab.Free(buf)
+	cos.Close(fh)
+	if err != nil {
+		return fmt.Errorf("failed to spill %d records: %w", cnt, err)
+	}
+
+	records.Drain()
+	s.mu.Lock()
+	s.runs = append(s.runs, &spillRun{fqn: fqn, cnt: cnt})
+	s.mu.Unlock()
+
+	if cmn.Rom.FastV(4, cos.SmoduleDsort) {
+		nlog.Infof("%s: [dsort] %s spilled %d records => %s", core.T, s.m.ManagerUUID, cnt, fqn)
+	}
+	return nil
+}
+
+// spills the target's (merged) records under memory pressure
+func (s *spiller) maybe() error {
+	if !s.pressure(s.m.recm.Records) {
+		return nil
+	}
+	if what := s.unsupported(); what != "" {
+		s.warn(what)
+		return nil
+	}
+	records := s.m.recm.Records
+	s.m.recm.Records = shard.NewRecords(records.Len())
+	return s.spill(records)
+}
+
+// encodes in-memory records followed by all spilled runs - the same wire format
+// as shard.Records.EncodeMsg
+func (s *spiller) encode(w *msgp.Writer, records *shard.Records) error {
+	if !s.spilled() {
+		return records.EncodeMsg(w)
+	}
+	if err := w.WriteMapHeader(1); err != nil {
+		return err
+	}
+	if err := w.WriteString("a"); err != nil {
+		return err
+	}
+	if err := w.WriteArrayHeader(uint32(records.Len() + s.count())); err != nil {
+		return err
+	}
+	for _, r := range records.All() {
+		if err := r.EncodeMsg(w); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	runs := s.runs
+	s.runs = nil
+	s.mu.Unlock()
+	for _, run := range runs {
+		fh, err := os.Open(run.fqn)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, fh)
+		cos.Close(fh)
+		if err != nil {
+			return err
+		}
+		s.remove(run.fqn)
+	}
+	return nil
+}
+
+// decodes records received from another target (see encode), spilling
+// as it goes under memory pressure
+func (s *spiller) decode(dc *msgp.Reader, hint int) (*shard.Records, error) {
+	records := shard.NewRecords(hint)
+	sz, err := dc.ReadMapHeader()
+	if err != nil {
+		return nil, err
+	}
+	for ; sz > 0; sz-- {
+		key, err := dc.ReadMapKeyPtr()
+		if err != nil {
+			return nil, err
+		}
+		if string(key) != "a" {
+			if err := dc.Skip(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		n, err := dc.ReadArrayHeader()
+		if err != nil {
+			return nil, err
+		}
+		for i := range n {
+			r := &shard.Record{}
+			if err := r.DecodeMsg(dc); err != nil {
+				return nil, err
+			}
+			records.Insert(r)
+			if (i+1)%spillCheckRecs == 0 && s.pressure(records) {
+				if what := s.unsupported(); what != "" {
+					s.warn(what)
+					continue
+				}
+				if err := s.spill(records); err != nil {
+					return nil, err
+				}
+				records = shard.NewRecords(hint)
+			}
+		}
+	}
+	return records, nil
+}
+
+// final target: spill the remaining records (if any) and start merging all runs
+func (s *spiller) merge(records *shard.Records) (err error) {
+	if err = s.spill(records); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	runs := s.runs
+	s.runs = nil
+	s.mu.Unlock()
+
+	s.merger, err = newRunMerger(runs, s.m.Pars.Algorithm)
+	if err == nil {
+		nlog.Infof("%s: [dsort] %s merging %d spilled runs (%d records)", core.T, s.m.ManagerUUID, len(runs), s.merger.left)
+	}
+	return err
+}
+
+func (s *spiller) newShardsW() (*shardsW, error) {
+	fqn, err := s.newFQN("shards")
+	if err != nil {
+		return nil, err
+	}
+	fh, err := cos.CreateFile(fqn)
+	if err != nil {
+		return nil, err
+	}
+	sw := &shardsW{fh: fh, fqn: fqn}
+	sw.buf, sw.slab = g.mem.AllocSize(serializationBufSize)
+	sw.w = msgp.NewWriterBuf(fh, sw.buf)
+	return sw, nil
+}
+
+func (s *spiller) closeMerger() {
+	if s.merger != nil {
+		s.merger.close()
+		s.merger = nil
+	}
+}
+
+func (s *spiller) remove(fqn string) {
+	if err := cos.RemoveFile(fqn); err != nil {
+		nlog.Errorln(err)
+	}
+}
+
+func (s *spiller) cleanup() {
+	s.closeMerger()
+	s.mu.Lock()
+	fqns := s.fqns
+	s.fqns, s.runs = nil, nil
+	s.mu.Unlock()
+	for _, fqn := range fqns {
+		s.remove(fqn)
+	}
+}
+
+///////////////
+// runReader //
+///////////////
+
+func (rr *runReader) open(run *spillRun) (err error) {
+	if rr.fh, err = os.Open(run.fqn); err != nil {
+		return err
+	}
+	rr.buf, rr.slab = g.mem.Alloc()
+	rr.dc = msgp.NewReaderBuf(rr.fh, rr.buf)
+	rr.left = run.cnt
+	return rr.advance()
+}
+
+func (rr *runReader) advance() error {
+	if rr.left == 0 {
+		rr.cur = nil
+		return nil
+	}
+	rr.cur = &shard.Record{}
+	if err := rr.cur.DecodeMsg(rr.dc); err != nil {
+		return errors.Wrapf(err, "failed to read spilled run %s", rr.fh.Name())
+	}
+	rr.left--
+	return nil
+}
+
+func (rr *runReader) close() {
+	if rr.fh != nil {
+		cos.Close(rr.fh)
+		rr.slab.Free(rr.buf)
+		rr.fh = nil
+	}
+}
+
+///////////////
+// runMerger //
+///////////////
+
+func newRunMerger(runs []*spillRun, alg *Algorithm) (*runMerger, error) {
+	mg := &runMerger{alg: alg, rrs: make([]*runReader, 0, len(runs))}
+	for i, run := range runs {
+		rr := &runReader{idx: i}
+		if err := rr.open(run); err != nil {
+			rr.close()
+			mg.close()
+			return nil, err
+		}
+		mg.rrs = append(mg.rrs, rr)
+		mg.left += run.cnt
+	}
+	switch alg.Kind {
+	case None:
+	case Shuffle:
+		seed := time.Now().Unix()
+		if alg.Seed != "" {
+			var err error
+			seed, err = strconv.ParseInt(alg.Seed, 10, 64)
+			debug.AssertNoErr(err)
+		}
+		mg.rnd = rand.New(rand.NewPCG(uint64(seed), 1))
+	default:
+		heap.Init(mg)
+		if mg.err != nil {
+			mg.close()
+			return nil, mg.err
+		}
+	}
+	return mg, nil
+}
+
+func (mg *runMerger) Len() int      { return len(mg.rrs) }
+func (mg *runMerger) Swap(i, j int) { mg.rrs[i], mg.rrs[j] = mg.rrs[j], mg.rrs[i] }
+func (mg *runMerger) Push(x any)    { mg.rrs = append(mg.rrs, x.(*runReader)) }
+
+func (mg *runMerger) Pop() any {
+	n := len(mg.rrs) - 1
+	rr := mg.rrs[n]
+	mg.rrs = mg.rrs[:n]
+	return rr
+}
+
+func (mg *runMerger) Less(i, j int) bool {
+	less, err := lessRecords(mg.rrs[i].cur, mg.rrs[j].cur, mg.alg.ContentKeyType, mg.alg.Decreasing)
+	if err != nil {
+		mg.err = err
+		return false
+	}
+	return less
+}
+
+// returns the next record in order or nil when done
+func (mg *runMerger) next() (*shard.Record, error) {
+	if mg.err != nil {
+		return nil, mg.err
+	}
+	if mg.left == 0 {
+		return nil, nil
+	}
+	var (
+		rr  *runReader
+		pos int
+	)
+	switch mg.alg.Kind {
+	case None: // concatenate
+		for mg.rrs[0].cur == nil {
+			mg.rrs[0].close()
+			mg.rrs = mg.rrs[1:]
+		}
+		rr = mg.rrs[0]
+	case Shuffle: // pick a run with probability proportional to its remaining size
+		k := mg.rnd.IntN(mg.left)
+		for pos, rr = range mg.rrs {
+			if n := rr.left + 1; k < n { // (+1 for the current)
+				break
+			}
+			k -= rr.left + 1
+		}
+	default:
+		rr = mg.rrs[0]
+	}
+
+	r := rr.cur
+	if err := rr.advance(); err != nil {
+		mg.err = err
+		return nil, err
+	}
+	mg.left--
+
+	switch {
+	case mg.alg.Kind == Shuffle:
+		if rr.cur == nil {
+			rr.close()
+			mg.rrs = append(mg.rrs[:pos], mg.rrs[pos+1:]...)
+		}
+	case mg.alg.Kind == None:
+	case rr.cur == nil:
+		heap.Pop(mg)
+		rr.close()
+	default:
+		heap.Fix(mg, 0)
+	}
+	return r, mg.err
+}
+
+func (mg *runMerger) close() {
+	for _, rr := range mg.rrs {
+		rr.close()
+	}
+	mg.rrs = nil
+}
+
+/////////////
+// shardsW //
+/////////////
+
+func (sw *shardsW) write(s *shard.Shard) error {
+	sw.cnt++
+	return s.EncodeMsg(sw.w)
+}
+
+func (sw *shardsW) close() (err error) {
+	if sw.fh == nil {
+		return nil
+	}
+	err = sw.w.Flush()
+	cos.Close(sw.fh)
+	sw.slab.Free(sw.buf)
+	sw.fh = nil
+	return err
+}
+
+// streams spilled shards as CreationPhaseMetadata (see manager_types.go)
+func (sw *shardsW) encode(w *msgp.Writer) error {
+	if err := w.WriteMapHeader(3); err != nil {
+		return err
+	}
+	if err := w.WriteString("shards"); err != nil {
+		return err
+	}
+	if err := w.WriteArrayHeader(uint32(sw.cnt)); err != nil {
+		return err
+	}
+	if sw.cnt > 0 {
+		fh, err := os.Open(sw.fqn)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, fh)
+		cos.Close(fh)
+		if err != nil {
+			return err
+		}
+	}
+	if err := w.WriteString("send_order"); err != nil {
+		return err
+	}
+	if err := w.WriteMapHeader(0); err != nil {
+		return err
+	}
+	if err := w.WriteString("released"); err != nil {
+		return err
+	}
+	return w.WriteInt64(sw.released)
+}
@@ -0,0 +1,151 @@
+// Package dsort provides distributed massively parallel resharding for very large datasets.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/ext/dsort/shard"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/tinylib/msgp/msgp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MergeRuns", func() {
+	var dir string
+
+	BeforeEach(func() {
+		if g.mem == nil {
+			g.mem = memsys.PageMM()
+		}
+		dir = GinkgoT().TempDir()
+	})
+
+	writeRun := func(name string, keys ...any) *spillRun {
+		fqn := filepath.Join(dir, name)
+		fh, err := os.Create(fqn)
+		Expect(err).NotTo(HaveOccurred())
+		w := msgp.NewWriter(fh)
+		for _, r := range createRecords(keys...).All() {
+			Expect(r.EncodeMsg(w)).To(Succeed())
+		}
+		Expect(w.Flush()).To(Succeed())
+		Expect(fh.Close()).To(Succeed())
+		return &spillRun{fqn: fqn, cnt: len(keys)}
+	}
+
+	mergeAll := func(alg *Algorithm, runs ...*spillRun) []any {
+		mg, err := newRunMerger(runs, alg)
+		Expect(err).NotTo(HaveOccurred())
+		defer mg.close()
+		keys := make([]any, 0)
+		for {
+			r, err := mg.next()
+			Expect(err).NotTo(HaveOccurred())
+			if r == nil {
+				return keys
+			}
+			keys = append(keys, r.Key)
+		}
+	}
+
+	It("should merge sorted runs ascending", func() {
+		alg := &Algorithm{ContentKeyType: shard.ContentKeyString}
+		keys := mergeAll(alg,
+			writeRun("r1", "abc", "def", "xyz"),
+			writeRun("r2", "bcd"),
+			writeRun("r3", "aaa", "efg"),
+		)
+		Expect(keys).To(Equal([]any{"aaa", "abc", "bcd", "def", "efg", "xyz"}))
+	})
+
+	It("should merge sorted runs descending", func() {
+		alg := &Algorithm{Decreasing: true, ContentKeyType: shard.ContentKeyInt}
+		keys := mergeAll(alg,
+			writeRun("r1", int64(30), int64(10)),
+			writeRun("r2", int64(40), int64(20), int64(5)),
+		)
+		Expect(keys).To(Equal([]any{int64(40), int64(30), int64(20), int64(10), int64(5)}))
+	})
+
+	It("should concatenate runs when none algorithm specified", func() {
+		alg := &Algorithm{Kind: None, ContentKeyType: shard.ContentKeyString}
+		keys := mergeAll(alg, writeRun("r1", "def", "abc"), writeRun("r2", "xyz"))
+		Expect(keys).To(Equal([]any{"def", "abc", "xyz"}))
+	})
+
+	It("should shuffle runs reproducibly when same seed specified", func() {
+		alg := &Algorithm{Kind: Shuffle, Seed: "1010102", ContentKeyType: shard.ContentKeyString}
+		keys1 := mergeAll(alg, writeRun("r1", "a", "b", "c"), writeRun("r2", "d", "e"))
+		keys2 := mergeAll(alg, writeRun("r3", "a", "b", "c"), writeRun("r4", "d", "e"))
+		Expect(keys1).To(HaveLen(5))
+		Expect(keys1).To(ConsistOf("a", "b", "c", "d", "e"))
+		Expect(keys1).To(Equal(keys2))
+	})
+})
+
+var _ = Describe("Spiller", func() {
+	DescribeTable("should not spill when using ordering file or memory dsorter",
+		func(ekm string, ds dsorter, unsupported bool) {
+			m := &Manager{Pars: &parsedReqSpec{EKMFileURL: ekm}}
+			m.dsorter = ds
+			m.spill.m = m
+			Expect(m.spill.unsupported() != "").To(Equal(unsupported))
+		},
+		Entry("general dsorter", "", &dsorterGeneral{}, false),
+		Entry("memory dsorter", "", &dsorterMem{}, true),
+		Entry("ordering file", "http://ekm", &dsorterGeneral{}, true),
+	)
+
+	It("should spill when the size of records exceeds the budget", func() {
+		var s spiller
+		s.once.Do(func() {}) // (budget set below)
+		records := createRecords("abc", "def", "ghi")
+		size := uint64(records.Len()) * records.RecordMemorySize()
+
+		s.budget = size
+		Expect(s.pressure(records)).To(BeFalse())
+		Expect(s.pressure(shard.NewRecords(0))).To(BeFalse())
+		s.budget = size - 1
+		Expect(s.pressure(records)).To(BeTrue())
+	})
+})
+
+var _ = Describe("GenShards", func() {
+	genShards := func(maxSize int64, maxRecs int, keys ...any) (counts []int) {
+		pt, err := cos.ParseBashTemplate("shard-{0..9}")
+		Expect(err).NotTo(HaveOccurred())
+		m := &Manager{Pars: &parsedReqSpec{Pot: &parsedOutputTemplate{Template: pt}, OutputExtension: ".tar"}}
+		m.Pars.OutputShardRecords = maxRecs
+
+		all := createRecords(keys...).All()
+		next := func() (*shard.Record, error) {
+			if len(all) == 0 {
+				return nil, nil
+			}
+			r := all[0]
+			all = all[1:]
+			return r, nil
+		}
+		err = m.genShards(maxSize, next, func(s *shard.Shard) error {
+			counts = append(counts, s.Records.Len())
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return counts
+	}
+
+	It("should create one shard per record when size is not limited", func() {
+		Expect(genShards(0, 0, "a", "b", "c")).To(Equal([]int{1, 1, 1}))
+	})
+
+	It("should limit the number of records per shard", func() {
+		Expect(genShards(0, 2, "a", "b", "c")).To(Equal([]int{2, 1}))
+	})
+})
@@ -35,6 +35,8 @@ const (
 	WorkfileAppend       = "append"         // APPEND to object (as file)
 	WorkfileAppendToArch = "append-to-arch" // APPEND to existing archive
 	WorkfileCreateArch   = "create-arch"    // CREATE multi-object archive
+	WorkfileCompose      = "compose"        // compose object from (ranges of) other objects
+	WorkfilePatch        = "patch"          // in-place object patch (received prior to applying)
 )
 
 type ParsedFQN struct {
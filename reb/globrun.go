@@ -21,6 +21,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/cmn/prob"
 	"github.com/NVIDIA/aistore/core"
@@ -108,6 +109,7 @@ type (
 		smap   *meta.Smap
 		config *cmn.Config
 		xreb   *xs.Rebalance
+		tstats cos.StatsUpdater
 		bck    *meta.Bck // advanced usage, limited scope
 		apaths fs.MPI
 		logHdr string
@@ -115,6 +117,11 @@ type (
 		id     int64
 		ecUsed bool
 	}
+	// (object-sent callback's context)
+	sentArg struct {
+		lom     *core.LOM
+		started int64 // mono.NanoTime
+	}
 )
 
 var stages = map[uint32]string{
@@ -223,6 +230,7 @@ func (reb *Reb) RunRebalance(smap *meta.Smap, extArgs *ExtArgs) {
 			id:     extArgs.NID, // == newRMD.Version
 			smap:   smap,
 			config: cmn.GCO.Get(),
+			tstats: extArgs.Tstats,
 			bck:    extArgs.Bck,    // advanced usage
 			prefix: extArgs.Prefix, // ditto
 			logHdr: logHdr,
@@ -753,8 +761,11 @@ func (rj *rebJogger) walkBck(bck *meta.Bck) bool {
 
 // send completion
 func (rj *rebJogger) objSentCallback(hdr *transport.ObjHdr, _ io.ReadCloser, arg any, err error) {
+	sa, ok := arg.(*sentArg)
+	debug.Assert(ok)
 	if err == nil {
 		rj.xreb.OutObjsAdd(1, hdr.ObjAttrs.Size) // NOTE: double-counts retransmissions
+		rj.rargs.tstats.Add(core.RebSendLatencyHist, mono.SinceNano(sa.started))
 		return
 	}
 
@@ -767,9 +778,7 @@ func (rj *rebJogger) objSentCallback(hdr *transport.ObjHdr, _ io.ReadCloser, arg
 			rj.xreb.Abort(err)
 			nlog.Errorln("stream term-ed: [", err, rj.xreb.Name(), "]")
 		default:
-			lom, ok := arg.(*core.LOM)
-			debug.Assert(ok)
-			nlog.Errorf("%s: %s failed to send %s: %v (%T)", core.T, rj.xreb.Name(), lom, err, err) // abort???
+			nlog.Errorf("%s: %s failed to send %s: %v (%T)", core.T, rj.xreb.Name(), sa.lom, err, err) // abort???
 		}
 	}
 }
@@ -888,6 +897,6 @@ func (rj *rebJogger) doSend(lom *core.LOM, tsi *meta.Snode, roc cos.ReadOpenClos
 	o.Hdr.ObjName = lom.ObjName
 	o.Hdr.Opaque = opaque
 	o.Hdr.ObjAttrs.CopyFrom(lom.ObjAttrs(), false /*skip cksum*/)
-	o.Callback, o.CmplArg = rj.objSentCallback, lom
+	o.Callback, o.CmplArg = rj.objSentCallback, &sentArg{lom: lom, started: mono.NanoTime()}
 	return rj.m.dm.Send(o, roc, tsi)
 }
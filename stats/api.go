@@ -7,6 +7,7 @@ package stats
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -36,6 +37,11 @@ const (
 
 	KindLatency    = "latency" // computed internally over 'periodic.stats_time' (milliseconds)
 	KindThroughput = "bw"      // ditto (MB/s)
+
+	// prometheus histogram (seconds); buckets: cmn.MetricsConf
+	// REST API: cumulative per-bucket counts (see HistBucketPrefix)
+
+	KindHistogram = "histogram"
 )
 
 // static labels
@@ -49,12 +55,15 @@ const (
 	VarlabXactKind  = "xkind"
 	VarlabXactID    = "xid"
 	VarlabMountpath = "mountpath"
+	VarlabUser      = "user"
+	VarlabNamespace = "namespace"
 )
 
 var (
 	BckVarlabs     = []string{VarlabBucket}
 	BckXactVarlabs = []string{VarlabBucket, VarlabXactKind, VarlabXactID}
 	MpathVarlabs   = []string{VarlabMountpath}
+	UsageVarlabs   = []string{VarlabUser, VarlabNamespace}
 )
 
 type (
@@ -196,6 +205,37 @@ func LatencyToCounter(latName string) string {
 	return ""
 }
 
+// histogram bucket counts reported via REST API, e.g.:
+// "get.ns.hist.le.5000000" - number of GETs that took 5ms or less;
+// "get.ns.hist.le.inf" - total number of GETs
+func HistBucketPrefix(name string) string { return name + ".le." }
+
+const HistBucketInf = "inf"
+
+// histogram name to its percentile (KindLatency) name, e.g.: ("get.ns.hist", 99) => "get.p99.ns"
+// (percentiles are computed by the clients - see cmd/cli)
+func HistToPercentile(name string, pct int) string {
+	debug.Assert(strings.HasSuffix(name, ".ns.hist"), name)
+	return strings.TrimSuffix(name, ".ns.hist") + ".p" + strconv.Itoa(pct) + ".ns"
+}
+
+// the reverse of the above: "get.p99.ns" => ("get.ns.hist", 99)
+func PercentileToHist(name string) (hist string, pct int) {
+	base, ok := strings.CutSuffix(name, ".ns")
+	if !ok {
+		return "", 0
+	}
+	i := strings.LastIndexByte(base, '.')
+	if i < 0 || !strings.HasPrefix(base[i+1:], "p") {
+		return "", 0
+	}
+	pct, err := strconv.Atoi(base[i+2:])
+	if err != nil || pct <= 0 || pct >= 100 {
+		return "", 0
+	}
+	return base[:i] + ".ns.hist", pct
+}
+
 func SizeToThroughput(name, kind string) string {
 	if kind != KindSize {
 		return ""
@@ -38,6 +38,7 @@ import (
 // "*.ns"   - KindLatency, KindTotal (nanoseconds)
 // "*.size" - KindSize (bytes)
 // "*.bps"  - KindThroughput, KindComputedThroughput
+// "*.ns.hist" - KindHistogram (nanoseconds; prometheus: seconds)
 //
 // all error counters must have "err_" prefix (see `errPrefix`)
 
@@ -134,6 +135,14 @@ type (
 		Value int64 `json:"v,string"`
 	}
 	copyTracker map[string]copyValue // aggregated every statsTime interval
+
+	// KindHistogram: (non-cumulative) per-bucket counts for the REST API,
+	// in addition to prometheus histogram
+	histCounts struct {
+		bounds []int64  // bucket upper bounds (nanoseconds), ascending
+		names  []string // respective REST API names, including the last "+Inf" one
+		counts []int64  // len(bounds) + 1
+	}
 )
 
 // common part: Prunner and Trunner, both
@@ -713,6 +722,62 @@ func (s *coreStats) get(name string) int64 {
 	return ratomic.LoadInt64(&v.Value)
 }
 
+////////////////
+// histCounts //
+////////////////
+
+func newHistCounts(name string, bounds []time.Duration) *histCounts {
+	h := &histCounts{
+		bounds: make([]int64, len(bounds)),
+		names:  make([]string, len(bounds)+1),
+		counts: make([]int64, len(bounds)+1),
+	}
+	prefix := HistBucketPrefix(name)
+	for i, b := range bounds {
+		h.bounds[i] = b.Nanoseconds()
+		h.names[i] = prefix + strconv.FormatInt(h.bounds[i], 10)
+	}
+	h.names[len(bounds)] = prefix + HistBucketInf
+	return h
+}
+
+// (validated - see cmn.MetricsConf)
+func histBounds(config *cmn.Config) []time.Duration {
+	bounds, err := config.Metrics.LatencyBounds()
+	debug.AssertNoErr(err)
+	return bounds
+}
+
+func (h *histCounts) observe(ns int64) {
+	i := sort.Search(len(h.bounds), func(i int) bool { return ns <= h.bounds[i] })
+	ratomic.AddInt64(&h.counts[i], 1)
+}
+
+// cumulative (prometheus style) counts: number of observations less than or equal the bucket's bound
+// NOTE: not reporting empty histograms
+func (h *histCounts) copyCumulative(ctracker copyTracker) {
+	var (
+		cnt    int64
+		counts = make([]int64, len(h.counts))
+	)
+	for i := range h.counts {
+		cnt += ratomic.LoadInt64(&h.counts[i])
+		counts[i] = cnt
+	}
+	if cnt == 0 {
+		return
+	}
+	for i, name := range h.names {
+		ctracker[name] = copyValue{counts[i]}
+	}
+}
+
+func (h *histCounts) reset() {
+	for i := range h.counts {
+		ratomic.StoreInt64(&h.counts[i], 0)
+	}
+}
+
 ///////////////
 // copyValue //
 ///////////////
@@ -13,6 +13,7 @@ import (
 	ratomic "sync/atomic"
 	"time"
 
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core/meta"
@@ -24,6 +25,7 @@ import (
 type (
 	statsValue struct {
 		iadd       iadd
+		hist       *histCounts
 		kind       string // enum { KindCounter, ..., KindSpecial }
 		Value      int64  `json:"v,string"`
 		numSamples int64  // (average latency over stats_time)
@@ -85,6 +87,8 @@ func (s *coreStats) copyT(out copyTracker, diskLowUtil ...int64) bool {
 			if throughput := ratomic.SwapInt64(&v.Value, 0); throughput > 0 {
 				out[name] = copyValue{throughput}
 			}
+		case KindHistogram:
+			// not logging (prometheus and REST API only)
 		case KindCounter, KindSize, KindTotal:
 			var (
 				val     = ratomic.LoadInt64(&v.Value)
@@ -130,6 +134,8 @@ func (s *coreStats) copyCumulative(ctracker copyTracker) {
 			// as statsValue.cumulative _is_ the total size (aka, KindSize)
 			n := name[:len(name)-3] + "size"
 			ctracker[n] = val
+		case KindHistogram:
+			v.hist.copyCumulative(ctracker)
 		case KindCounter, KindSize, KindTotal:
 			if val := ratomic.LoadInt64(&v.Value); val > 0 {
 				ctracker[name] = copyValue{val}
@@ -161,6 +167,8 @@ func (s *coreStats) reset(errorsOnly bool) {
 			ratomic.StoreInt64(&v.cumulative, 0)
 		case KindCounter, KindSize, KindComputedThroughput, KindGauge, KindTotal:
 			ratomic.StoreInt64(&v.Value, 0)
+		case KindHistogram:
+			v.hist.reset()
 		default: // KindSpecial - do nothing
 		}
 	}
@@ -199,6 +207,9 @@ func (r *runner) reg(snode *meta.Snode, name, kind string, extra *Extra) {
 		case KindThroughput, KindComputedThroughput:
 			debug.Assert(strings.HasSuffix(name, ".bps"), name)
 			metricName = strings.TrimSuffix(name, ".bps") + "_mbps"
+		case KindHistogram:
+			debug.Assert(strings.HasSuffix(name, ".ns.hist"), name)
+			metricName = strings.TrimSuffix(name, ".ns.hist") + "_latency_seconds"
 		default:
 			metricName = name
 		}
@@ -233,6 +244,29 @@ func (r *runner) reg(snode *meta.Snode, name, kind string, extra *Extra) {
 		// ditto (v3.26)
 		v.iadd = throughput{}
 
+	case KindHistogram:
+		var (
+			config  = cmn.GCO.Get()
+			bounds  = histBounds(config)
+			buckets = make([]float64, len(bounds))
+		)
+		for i, b := range bounds {
+			buckets[i] = b.Seconds()
+		}
+		v.hist = newHistCounts(name, bounds)
+		opts := prometheus.HistogramOpts{Namespace: "ais", Subsystem: snode.Type(), Name: metricName, Help: help,
+			ConstLabels: constLabs, Buckets: buckets}
+		// per-bucket breakdown is optional (and, when enabled, the bucket is the only variable label)
+		if len(extra.VarLabs) > 0 && config.Metrics.PerBucket {
+			metric := prometheus.NewHistogramVec(opts, BckVarlabs)
+			v.iadd = histogramVec{metric}
+			promRegistry.MustRegister(metric)
+		} else {
+			metric := prometheus.NewHistogram(opts)
+			v.iadd = histogram{metric}
+			promRegistry.MustRegister(metric)
+		}
+
 	default:
 		opts := prometheus.GaugeOpts{Namespace: "ais", Subsystem: snode.Type(), Name: metricName, Help: help, ConstLabels: constLabs}
 		if len(extra.VarLabs) > 0 {
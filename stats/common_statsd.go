@@ -29,6 +29,7 @@ type (
 
 	// Stats are tracked via a map of stats names (key) and statsValue (values).
 	statsValue struct {
+		hist  *histCounts
 		kind  string // enum { KindCounter, ..., KindSpecial }
 		label struct {
 			comm string // common part of the metric label (as in: <prefix> . comm . <suffix>)
@@ -108,6 +109,8 @@ func (s *coreStats) add(name string, val int64) {
 		ratomic.AddInt64(&v.cumulative, val)
 	case KindCounter, KindSize, KindTotal:
 		ratomic.AddInt64(&v.Value, val)
+	case KindHistogram:
+		v.hist.observe(val)
 	default:
 		debug.Assert(false, v.kind)
 	}
@@ -153,6 +156,8 @@ func (s *coreStats) copyT(out copyTracker, diskLowUtil ...int64) bool {
 				fv := roundMBs(throughput)
 				s.statsdC.AppMetric(metric{Type: statsd.Gauge, Name: v.label.stpr, Value: fv}, s.sgl)
 			}
+		case KindHistogram:
+			// not logging and not sending (REST API only)
 		case KindComputedThroughput:
 			if throughput := ratomic.SwapInt64(&v.Value, 0); throughput > 0 {
 				out[name] = copyValue{throughput}
@@ -223,6 +228,8 @@ func (s *coreStats) copyCumulative(ctracker copyTracker) {
 			// as statsValue.cumulative _is_ the total size (aka, KindSize)
 			n := name[:len(name)-3] + "size"
 			ctracker[n] = val
+		case KindHistogram:
+			v.hist.copyCumulative(ctracker)
 		case KindCounter, KindSize, KindTotal:
 			if val := ratomic.LoadInt64(&v.Value); val > 0 {
 				ctracker[name] = copyValue{val}
@@ -254,6 +261,8 @@ func (s *coreStats) reset(errorsOnly bool) {
 			ratomic.StoreInt64(&v.cumulative, 0)
 		case KindCounter, KindSize, KindComputedThroughput, KindGauge, KindTotal:
 			ratomic.StoreInt64(&v.Value, 0)
+		case KindHistogram:
+			v.hist.reset()
 		default: // KindSpecial - do nothing
 		}
 	}
@@ -293,6 +302,10 @@ func (r *runner) reg(snode *meta.Snode, name, kind string, _ *Extra) {
 		debug.Assert(strings.HasSuffix(name, ".bps"), name)
 		v.label.comm = strings.TrimSuffix(name, ".bps")
 		v.label.stpr = f("mbps")
+	case KindHistogram:
+		debug.Assert(strings.HasSuffix(name, ".ns.hist"), name)
+		v.label.comm = strings.TrimSuffix(name, ".ns.hist")
+		v.hist = newHistCounts(name, histBounds(cmn.GCO.Get()))
 	default:
 		debug.Assert(kind == KindGauge || kind == KindSpecial)
 		v.label.comm = name
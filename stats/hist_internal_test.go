@@ -0,0 +1,104 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistCounts(t *testing.T) {
+	const name = "get.ns.hist"
+	bounds := []time.Duration{time.Millisecond, 10 * time.Millisecond, time.Second}
+
+	h := newHistCounts(name, bounds)
+	expectedNames := []string{
+		"get.ns.hist.le.1000000",
+		"get.ns.hist.le.10000000",
+		"get.ns.hist.le.1000000000",
+		"get.ns.hist.le.inf",
+	}
+	for i, n := range expectedNames {
+		if h.names[i] != n {
+			t.Fatalf("bucket %d: expected name %q, got %q", i, n, h.names[i])
+		}
+	}
+
+	// bucket selection: upper bounds are inclusive
+	tests := []struct {
+		observed time.Duration
+		bucket   int
+	}{
+		{0, 0},
+		{time.Microsecond, 0},
+		{time.Millisecond, 0},
+		{time.Millisecond + 1, 1},
+		{10 * time.Millisecond, 1},
+		{time.Second, 2},
+		{time.Second + 1, 3},
+		{time.Hour, 3},
+	}
+	for _, test := range tests {
+		h.reset()
+		h.observe(test.observed.Nanoseconds())
+		for i, cnt := range h.counts {
+			expected := int64(0)
+			if i == test.bucket {
+				expected = 1
+			}
+			if cnt != expected {
+				t.Errorf("observed %v: bucket %d (%s) count %d, expected %d", test.observed, i, h.names[i], cnt, expected)
+			}
+		}
+	}
+
+	// cumulative copy
+	h.reset()
+	ctracker := make(copyTracker)
+	h.copyCumulative(ctracker)
+	if len(ctracker) != 0 {
+		t.Fatalf("expected empty histogram not to be reported, got %v", ctracker)
+	}
+	for _, d := range []time.Duration{time.Microsecond, 5 * time.Millisecond, 7 * time.Millisecond, time.Minute} {
+		h.observe(d.Nanoseconds())
+	}
+	h.copyCumulative(ctracker)
+	for i, expected := range []int64{1, 3, 3, 4} {
+		if v := ctracker[expectedNames[i]].Value; v != expected {
+			t.Errorf("%s: expected cumulative count %d, got %d", expectedNames[i], expected, v)
+		}
+	}
+}
+
+func TestHistToPercentile(t *testing.T) {
+	tests := []struct {
+		hist string
+		pct  int
+		name string
+	}{
+		{"get.ns.hist", 50, "get.p50.ns"},
+		{"get.ns.hist", 99, "get.p99.ns"},
+		{"put.ns.hist", 90, "put.p90.ns"},
+		{"aws.get.ns.hist", 99, "aws.get.p99.ns"},
+	}
+	for _, test := range tests {
+		name := HistToPercentile(test.hist, test.pct)
+		if name != test.name {
+			t.Errorf("HistToPercentile(%q, %d): expected %q, got %q", test.hist, test.pct, test.name, name)
+		}
+		hist, pct := PercentileToHist(name)
+		if hist != test.hist || pct != test.pct {
+			t.Errorf("PercentileToHist(%q): expected (%q, %d), got (%q, %d)", name, test.hist, test.pct, hist, pct)
+		}
+	}
+
+	// not a percentile
+	for _, name := range []string{"get.ns", "get.ns.total", "get.p.ns", "get.pxx.ns", "get.p0.ns", "get.p100.ns", "getp99.ns", "get.p99"} {
+		if hist, pct := PercentileToHist(name); hist != "" || pct != 0 {
+			t.Errorf("PercentileToHist(%q): expected none, got (%q, %d)", name, hist, pct)
+		}
+	}
+}
@@ -29,6 +29,9 @@ type (
 	counterVec struct{ *prometheus.CounterVec }
 	gauge      struct{ prometheus.Gauge }
 	gaugeVec   struct{ *prometheus.GaugeVec }
+
+	histogram    struct{ prometheus.Histogram }
+	histogramVec struct{ *prometheus.HistogramVec }
 )
 
 //
@@ -91,12 +94,28 @@ func (v gaugeVec) addWith(parent *statsValue, nv cos.NamedVal64) {
 	v.With(nv.VarLabs).Add(float64(nv.Value))
 }
 
+func (v histogram) add(parent *statsValue, val int64) {
+	parent.hist.observe(val)
+	v.Observe(float64(val) / float64(time.Second))
+}
+
+// (no per-bucket breakdown - see reg)
+func (v histogram) addWith(parent *statsValue, nv cos.NamedVal64) {
+	v.add(parent, nv.Value)
+}
+
+func (v histogramVec) addWith(parent *statsValue, nv cos.NamedVal64) {
+	parent.hist.observe(nv.Value)
+	v.WithLabelValues(nv.VarLabs[VarlabBucket]).Observe(float64(nv.Value) / float64(time.Second))
+}
+
 // illegal
 
 func (counter) addWith(*statsValue, cos.NamedVal64) { debug.Assert(false) }
 func (counterVec) add(*statsValue, int64)           { debug.Assert(false) }
 func (gauge) addWith(*statsValue, cos.NamedVal64)   { debug.Assert(false) }
 func (gaugeVec) add(*statsValue, int64)             { debug.Assert(false) }
+func (histogramVec) add(*statsValue, int64)         { debug.Assert(false) }
 
 // coreStats
 
@@ -18,6 +18,11 @@ import (
 
 const numProxyStats = 24 // approx. initial
 
+// proxy-only metrics
+const (
+	ErrRateLimitCount = errPrefix + "ratelim.n" // requests rejected with 429 (see cmn.RateLimitConf)
+)
+
 // NOTE: currently, proxy's stats == common and hardcoded
 
 type Prunner struct {
@@ -43,6 +48,12 @@ func (r *Prunner) Init(p core.Node) *atomic.Bool {
 	r.core.init(numProxyStats)
 
 	r.regCommon(p.Snode()) // common metrics
+	r.reg(p.Snode(), ErrRateLimitCount, KindCounter,
+		&Extra{
+			Help:    "total number of requests rejected with 429 (Too Many Requests) upon exceeding bucket or user rate limit",
+			VarLabs: BckVarlabs,
+		},
+	)
 
 	r.core.statsTime = cmn.GCO.Get().Periodic.StatsTime.D()
 	r.ctracker = make(copyTracker, numProxyStats)
@@ -28,6 +28,7 @@ import (
 // "*.ns"   - KindLatency, KindTotal (nanoseconds)
 // "*.size" - KindSize (bytes)
 // "*.bps"  - KindThroughput, KindComputedThroughput
+// "*.ns.hist" - KindHistogram (nanoseconds; prometheus: seconds)
 //
 // all error counters must have "err_" prefix (see `errPrefix`)
 
@@ -68,6 +69,36 @@ const (
 	HeadLatency        = "head.ns"
 	HeadLatencyTotal   = "head.ns.total"
 
+	// KindHistogram
+	GetLatencyHist     = "get.ns.hist"
+	GetColdLatencyHist = "get.cold.ns.hist" // end to end cold GET
+	PutLatencyHist     = "put.ns.hist"
+	ECSendLatencyHist  = core.ECSendLatencyHist  // sending replicas and slices
+	RebSendLatencyHist = core.RebSendLatencyHist // global rebalance
+
+	// usage accounting (feat.UsageAccounting)
+	UsageGetCount   = "usage.get.n"
+	UsageGetSize    = "usage.get.size"
+	UsagePutCount   = "usage.put.n"
+	UsagePutSize    = "usage.put.size"
+	UsageDelCount   = "usage.del.n"
+	UsageStoredSize = "usage.stored.size" // KindGauge
+
+	// batch GET (designated target)
+	GetBatchCount    = "getbatch.n"
+	GetBatchObjCount = "getbatch.obj.n"
+	GetBatchSize     = "getbatch.size"
+
+	// bucket-to-bucket replication (see cmn.ReplConf)
+	ReplCount    = "repl.n"
+	ReplSize     = "repl.size"
+	ReplDelCount = "repl.del.n"
+	ReplLag      = "repl.lag.ns" // KindGauge
+	ErrReplCount = errPrefix + "repl.n"
+
+	// event notifications (see cmn/events.go)
+	EventLostCount = "event.lost.n"
+
 	// Dsort
 	DsortCreationReqCount    = "dsort.creation.req.n"
 	DsortCreationRespCount   = "dsort.creation.resp.n"
@@ -328,6 +359,132 @@ func (r *Trunner) RegMetrics(snode *meta.Snode) {
 		},
 	)
 
+	// latency histograms (buckets and per-bucket breakdown: cmn.MetricsConf)
+	r.reg(snode, GetLatencyHist, KindHistogram,
+		&Extra{
+			Help:    "GET: latency distribution (seconds)",
+			VarLabs: BckVarlabs,
+		},
+	)
+	r.reg(snode, GetColdLatencyHist, KindHistogram,
+		&Extra{
+			Help:    "cold GET: end-to-end latency distribution (seconds), including reading from remote backend",
+			VarLabs: BckVarlabs,
+		},
+	)
+	r.reg(snode, PutLatencyHist, KindHistogram,
+		&Extra{
+			Help:    "PUT: latency distribution (seconds)",
+			VarLabs: BckVarlabs,
+		},
+	)
+	r.reg(snode, ECSendLatencyHist, KindHistogram,
+		&Extra{
+			Help: "erasure coding: distribution of the time (seconds) to send object replica or slice(s) to other targets",
+		},
+	)
+	r.reg(snode, RebSendLatencyHist, KindHistogram,
+		&Extra{
+			Help: "global rebalance: distribution of the time (seconds) to send object to its new location",
+		},
+	)
+
+	// usage accounting (user, namespace)
+	r.reg(snode, UsageGetCount, KindCounter,
+		&Extra{
+			Help:    "usage: total number of GET requests by user and bucket namespace",
+			VarLabs: UsageVarlabs,
+		},
+	)
+	r.reg(snode, UsageGetSize, KindSize,
+		&Extra{
+			Help:    "usage: total number of bytes read by user and bucket namespace",
+			VarLabs: UsageVarlabs,
+		},
+	)
+	r.reg(snode, UsagePutCount, KindCounter,
+		&Extra{
+			Help:    "usage: total number of PUT requests by user and bucket namespace",
+			VarLabs: UsageVarlabs,
+		},
+	)
+	r.reg(snode, UsagePutSize, KindSize,
+		&Extra{
+			Help:    "usage: total number of bytes written by user and bucket namespace",
+			VarLabs: UsageVarlabs,
+		},
+	)
+	r.reg(snode, UsageDelCount, KindCounter,
+		&Extra{
+			Help:    "usage: total number of DELETE requests by user and bucket namespace",
+			VarLabs: UsageVarlabs,
+		},
+	)
+	r.reg(snode, UsageStoredSize, KindGauge,
+		&Extra{
+			Help:    "usage: bytes written less bytes deleted by user and bucket namespace (approximation)",
+			StrName: "usage_stored_bytes",
+			VarLabs: UsageVarlabs,
+		},
+	)
+
+	// batch GET
+	r.reg(snode, GetBatchCount, KindCounter,
+		&Extra{
+			Help: "batch GET: total number of batch requests served by this (designated) target",
+		},
+	)
+	r.reg(snode, GetBatchObjCount, KindCounter,
+		&Extra{
+			Help: "batch GET: total number of entries (objects, archived files, and ranges) in all served batches",
+		},
+	)
+	r.reg(snode, GetBatchSize, KindSize,
+		&Extra{
+			Help: "batch GET: total cumulative size (bytes) of all entries in all served batches",
+		},
+	)
+
+	// replication
+	r.reg(snode, ReplCount, KindCounter,
+		&Extra{
+			Help:    "replication: number of objects replicated to the destination bucket",
+			VarLabs: BckVarlabs,
+		},
+	)
+	r.reg(snode, ReplSize, KindSize,
+		&Extra{
+			Help:    "replication: total cumulative size (bytes) of replicated objects",
+			VarLabs: BckVarlabs,
+		},
+	)
+	r.reg(snode, ReplDelCount, KindCounter,
+		&Extra{
+			Help:    "replication: number of replicated deletions",
+			VarLabs: BckVarlabs,
+		},
+	)
+	r.reg(snode, ReplLag, KindGauge,
+		&Extra{
+			Help:    "replication: age (nanoseconds) of the oldest pending (not yet replicated) write or delete",
+			VarLabs: BckVarlabs,
+		},
+	)
+	r.reg(snode, ErrReplCount, KindCounter,
+		&Extra{
+			Help:    "replication: number of failed attempts to replicate object or deletion",
+			VarLabs: BckVarlabs,
+		},
+	)
+
+	// event notifications
+	r.reg(snode, EventLostCount, KindCounter,
+		&Extra{
+			Help:    "events: number of unacknowledged events discarded upon exceeding max journal size (or failed to get journaled)",
+			VarLabs: BckVarlabs,
+		},
+	)
+
 	// bps
 	r.reg(snode, GetThroughput, KindThroughput,
 		&Extra{
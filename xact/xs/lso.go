@@ -397,7 +397,8 @@ func (r *LsoXact) nextPageR() (err error) {
 	// TODO -- FIXME: not counting/sizing (locally) present objects that are missing (deleted?) remotely
 	if r.walk.this {
 		nentries := allocLsoEntries()
-		page, err = npg.nextPageR(nentries, !r.walk.dontPopulate)
+		// (not populating remote object versions with in-cluster metadata)
+		page, err = npg.nextPageR(nentries, !r.walk.dontPopulate && !r.msg.IsFlagSet(apc.LsVersions))
 		if !r.walk.wor && !r.IsAborted() {
 			if err == nil {
 				// bcast page
@@ -558,7 +559,7 @@ func (r *LsoXact) shiftLastPage(token string) {
 func (r *LsoXact) doWalk(msg *apc.LsoMsg) {
 	r.walk.wi = newWalkInfo(msg, r.LomAdd)
 	opts := &fs.WalkBckOpts{
-		WalkOpts: fs.WalkOpts{CTs: []string{fs.ObjectType}, Callback: r.cb, Prefix: msg.Prefix, Sorted: true},
+		WalkOpts: fs.WalkOpts{CTs: []string{fs.ObjectType}, Callback: r.cb, Prefix: r.walk.wi.msg.Prefix, Sorted: true},
 	}
 	opts.WalkOpts.Bck.Copy(r.Bck().Bucket())
 	opts.ValidateCb = r.validateCb
@@ -605,19 +606,28 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 	if err != nil || entry == nil {
 		return err
 	}
-	msg := r.walk.wi.lsmsg()
+	var (
+		msg   = r.walk.wi.lsmsg()
+		shard = r.walk.wi.arch.shard
+	)
 	if entry.Name <= msg.StartAfter {
 		return nil
 	}
+	if shard != "" && entry.Name != shard {
+		return nil
+	}
 
-	select {
-	case r.walk.pageCh <- entry:
-		/* do nothing */
-	case <-r.walk.stopCh.Listen():
-		return errStopped
+	// when listing under archive-internal prefix - archived files only
+	if shard == "" {
+		select {
+		case r.walk.pageCh <- entry:
+			/* do nothing */
+		case <-r.walk.stopCh.Listen():
+			return errStopped
+		}
 	}
 
-	if !msg.IsFlagSet(apc.LsArchDir) {
+	if !msg.IsFlagSet(apc.LsArchDir) || entry.IsDir() {
 		return nil
 	}
 
@@ -638,6 +648,9 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 			Flags: entry.Flags | apc.EntryInArch,
 			Size:  archEntry.Size,
 		}
+		if !r.walk.wi.matchArch(e.Name, archEntry.Name) {
+			continue
+		}
 		select {
 		case r.walk.pageCh <- e:
 			/* do nothing */
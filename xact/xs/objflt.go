@@ -0,0 +1,140 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+)
+
+type (
+	// objflt: compiled apc.ObjFilter (source object selection for x-tcb and x-tco)
+	objflt struct {
+		msg          *apc.ObjFilter
+		re           *regexp.Regexp
+		newer, older int64 // time bounds (ns); zero when not specified
+	}
+	// dry-run: accumulates selected source object names (apc.DryRunResult)
+	dryrun struct {
+		res apc.DryRunResult
+		mu  sync.Mutex
+		on  bool
+	}
+)
+
+func newObjFlt(msg *apc.ObjFilter) (*objflt, error) {
+	if msg == nil {
+		return nil, nil
+	}
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+	flt := &objflt{msg: msg}
+	if msg.Regex != "" {
+		flt.re = regexp.MustCompile(msg.Regex) // validated
+	}
+	flt.newer, flt.older = msg.TimeBounds()
+	return flt, nil
+}
+
+func (flt *objflt) matchName(objName string) bool {
+	return flt.re == nil || flt.re.MatchString(objName)
+}
+
+func (flt *objflt) matchAttrs(oah cos.OAH) bool {
+	msg := flt.msg
+	if size := oah.Lsize(); (msg.MinSize > 0 && size < msg.MinSize) || (msg.MaxSize > 0 && size > msg.MaxSize) {
+		return false
+	}
+	if flt.newer != 0 || flt.older != 0 {
+		mtime := flt.mtime(oah)
+		if (flt.newer != 0 && mtime <= flt.newer) || (flt.older != 0 && mtime >= flt.older) {
+			return false
+		}
+	}
+	for k, v := range msg.CustomMD {
+		if val, ok := oah.GetCustomKey(k); !ok || val != v {
+			return false
+		}
+	}
+	return true
+}
+
+// last-modified (when provided by remote backend), otherwise access time
+func (*objflt) mtime(oah cos.OAH) int64 {
+	if s, ok := oah.GetCustomKey(cmn.LastModified); ok {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t.UnixNano()
+		}
+	}
+	return oah.AtimeUnix()
+}
+
+// NOTE: when attributes are required, lom gets loaded; objects that are not
+// present in the cluster are checked against their remote metadata
+func (flt *objflt) match(lom *core.LOM, loaded bool) bool {
+	if !flt.matchName(lom.ObjName) {
+		return false
+	}
+	if !flt.msg.NeedsAttrs() {
+		return true
+	}
+	if loaded {
+		return flt.matchAttrs(lom)
+	}
+	err := lom.Load(true /*cache it*/, false /*locked*/)
+	if err == nil {
+		return flt.matchAttrs(lom)
+	}
+	if !cos.IsNotExist(err, 0) || !lom.Bck().IsRemote() {
+		return false
+	}
+	oa, _, err := core.T.Backend(lom.Bck()).HeadObj(context.Background(), lom, nil /*origReq*/)
+	if err != nil {
+		return false
+	}
+	return flt.matchAttrs(oa)
+}
+
+////////////
+// dryrun //
+////////////
+
+func (d *dryrun) enable() {
+	d.mu.Lock()
+	d.on = true
+	d.mu.Unlock()
+}
+
+func (d *dryrun) add(objName string) {
+	d.mu.Lock()
+	d.on = true
+	if len(d.res.Names) < apc.MaxDryRunNames {
+		d.res.Names = append(d.res.Names, objName)
+	} else {
+		d.res.Truncated = true
+	}
+	d.mu.Unlock()
+}
+
+// nil unless dry-running
+func (d *dryrun) result() *apc.DryRunResult {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.on {
+		return nil
+	}
+	res := &apc.DryRunResult{Names: make([]string, len(d.res.Names)), Truncated: d.res.Truncated}
+	copy(res.Names, d.res.Names)
+	return res
+}
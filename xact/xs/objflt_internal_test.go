@@ -0,0 +1,97 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestObjFltMatch(t *testing.T) {
+	var (
+		now   = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+		hour  = now.Add(-time.Hour)
+		newer = now.Add(-2 * time.Hour).Format(time.RFC3339)
+		older = now.Add(-30 * time.Minute).Format(time.RFC3339)
+	)
+	tests := []struct {
+		name  string
+		flt   apc.ObjFilter
+		oname string
+		oa    cmn.ObjAttrs
+		match bool
+	}{
+		{"regex/match", apc.ObjFilter{Regex: `\.jpg$`}, "a/b.jpg", cmn.ObjAttrs{}, true},
+		{"regex/no-match", apc.ObjFilter{Regex: `\.jpg$`}, "a/b.png", cmn.ObjAttrs{}, false},
+		{"size/min", apc.ObjFilter{MinSize: 100}, "a", cmn.ObjAttrs{Size: 99}, false},
+		{"size/min-inclusive", apc.ObjFilter{MinSize: 100}, "a", cmn.ObjAttrs{Size: 100}, true},
+		{"size/max", apc.ObjFilter{MaxSize: 100}, "a", cmn.ObjAttrs{Size: 101}, false},
+		{"size/range", apc.ObjFilter{MinSize: 10, MaxSize: 100}, "a", cmn.ObjAttrs{Size: 50}, true},
+		{"time/atime-within", apc.ObjFilter{NewerThan: newer, OlderThan: older}, "a",
+			cmn.ObjAttrs{Atime: hour.UnixNano()}, true},
+		{"time/atime-too-old", apc.ObjFilter{NewerThan: newer}, "a",
+			cmn.ObjAttrs{Atime: now.Add(-3 * time.Hour).UnixNano()}, false},
+		{"time/atime-too-new", apc.ObjFilter{OlderThan: older}, "a",
+			cmn.ObjAttrs{Atime: now.UnixNano()}, false},
+		{"time/last-modified-takes-precedence", apc.ObjFilter{NewerThan: newer, OlderThan: older}, "a",
+			cmn.ObjAttrs{Atime: now.UnixNano(), CustomMD: cos.StrKVs{cmn.LastModified: hour.Format(time.RFC3339)}}, true},
+		{"custom-md/match", apc.ObjFilter{CustomMD: cos.StrKVs{"k1": "v1", "k2": "v2"}}, "a",
+			cmn.ObjAttrs{CustomMD: cos.StrKVs{"k1": "v1", "k2": "v2", "k3": "v3"}}, true},
+		{"custom-md/missing-key", apc.ObjFilter{CustomMD: cos.StrKVs{"k1": "v1", "k2": "v2"}}, "a",
+			cmn.ObjAttrs{CustomMD: cos.StrKVs{"k1": "v1"}}, false},
+		{"custom-md/value-mismatch", apc.ObjFilter{CustomMD: cos.StrKVs{"k1": "v1"}}, "a",
+			cmn.ObjAttrs{CustomMD: cos.StrKVs{"k1": "v2"}}, false},
+		{"all", apc.ObjFilter{Regex: "^a", MinSize: 1, CustomMD: cos.StrKVs{"k": "v"}}, "abc",
+			cmn.ObjAttrs{Size: 1, CustomMD: cos.StrKVs{"k": "v"}}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flt, err := newObjFlt(&test.flt)
+			tassert.CheckFatal(t, err)
+			match := flt.matchName(test.oname) && (!test.flt.NeedsAttrs() || flt.matchAttrs(&test.oa))
+			tassert.Errorf(t, match == test.match, "%s: expected match=%t, got %t", test.name, test.match, match)
+		})
+	}
+}
+
+func TestObjFltInvalid(t *testing.T) {
+	flt, err := newObjFlt(nil)
+	tassert.Errorf(t, flt == nil && err == nil, "nil filter: expected (nil, nil), got (%v, %v)", flt, err)
+
+	for _, msg := range []apc.ObjFilter{
+		{Regex: "a("},
+		{MinSize: -1},
+		{MinSize: 10, MaxSize: 1},
+		{NewerThan: "yesterday"},
+		{NewerThan: "2024-06-01T00:00:00Z", OlderThan: "2024-01-01T00:00:00Z"},
+	} {
+		_, err := newObjFlt(&msg)
+		tassert.Errorf(t, err != nil, "expected %+v to fail validation", msg)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	var d dryrun
+	tassert.Errorf(t, d.result() == nil, "expected no result when not dry-running")
+
+	d.enable()
+	res := d.result()
+	tassert.Fatalf(t, res != nil && len(res.Names) == 0 && !res.Truncated, "expected empty result, got %+v", res)
+
+	for i := range apc.MaxDryRunNames + 1 {
+		d.add("obj-" + strconv.Itoa(i))
+	}
+	res = d.result()
+	tassert.Errorf(t, len(res.Names) == apc.MaxDryRunNames && res.Truncated,
+		"expected %d names (truncated), got %d (%t)", apc.MaxDryRunNames, len(res.Names), res.Truncated)
+	tassert.Errorf(t, res.Names[0] == "obj-0", "expected obj-0, got %q", res.Names[0])
+}
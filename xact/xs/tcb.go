@@ -44,6 +44,8 @@ type (
 		dm     *bundle.DataMover
 		rxlast atomic.Int64 // finishing
 		xact.BckJog
+		flt      *objflt // optional source selection (apc.ObjFilter)
+		dry      dryrun  // selected names when dry-running
 		prune    prune
 		nam, str string
 		wg       sync.WaitGroup // starting up
@@ -82,8 +84,16 @@ func (p *tcbFactory) Start() error {
 		p.owt = cmn.OwtTransform
 	}
 
+	flt, err := newObjFlt(p.args.Msg.Filter)
+	if err != nil {
+		return err
+	}
 	smap := core.T.Sowner().Get()
 	p.xctn = newTCB(p, slab, config, smap)
+	p.xctn.flt = flt
+	if p.args.Msg.DryRun {
+		p.xctn.dry.enable()
+	}
 
 	// refcount OpcTxnDone; this target must ve active (ref: ignoreMaintenance)
 	if err := core.InMaintOrDecomm(smap, core.T.Snode(), p.xctn); err != nil {
@@ -271,6 +281,12 @@ func (r *XactTCB) do(lom *core.LOM, buf []byte) (err error) {
 		args   = r.p.args // TCBArgs
 		toName = args.Msg.ToName(lom.ObjName)
 	)
+	if r.flt != nil && !r.flt.match(lom, true /*loaded*/) {
+		return nil
+	}
+	if args.Msg.DryRun {
+		r.dry.add(lom.ObjName)
+	}
 	if cmn.Rom.FastV(5, cos.SmoduleXs) {
 		nlog.Infoln(r.Base.Name()+":", lom.Cname(), "=>", args.BckTo.Cname(toName))
 	}
@@ -376,5 +392,8 @@ func (r *XactTCB) Snap() (snap *core.Snap) {
 	snap.IdleX = r.IsIdle()
 	f, t := r.FromTo()
 	snap.SrcBck, snap.DstBck = f.Clone(), t.Clone()
+	if res := r.dry.result(); res != nil {
+		snap.Ext = res
+	}
 	return
 }
@@ -44,11 +44,13 @@ type (
 		workCh   chan *cmn.TCOMsg
 		chanFull atomic.Int64
 		streamingX
+		dry dryrun // selected names when dry-running
 		owt cmn.OWT
 	}
 	tcowi struct {
 		r   *XactTCObjs
 		msg *cmn.TCOMsg
+		flt *objflt // optional source selection (apc.ObjFilter)
 		// finishing
 		refc atomic.Int32
 	}
@@ -132,11 +134,17 @@ func (r *XactTCObjs) Snap() (snap *core.Snap) {
 	snap.IdleX = r.IsIdle()
 	f, t := r.FromTo()
 	snap.SrcBck, snap.DstBck = f.Clone(), t.Clone()
+	if res := r.dry.result(); res != nil {
+		snap.Ext = res
+	}
 	return
 }
 
 func (r *XactTCObjs) Begin(msg *cmn.TCOMsg) {
 	wi := &tcowi{r: r, msg: msg}
+	if msg.DryRun {
+		r.dry.enable()
+	}
 	r.pending.mtx.Lock()
 
 	r.pending.m[msg.TxnUUID] = wi
@@ -178,6 +186,10 @@ func (r *XactTCObjs) Run(wg *sync.WaitGroup) {
 
 			// run
 			var wg *sync.WaitGroup
+			if wi.flt, err = newObjFlt(msg.Filter); err != nil {
+				r.AddErr(err)
+				goto fin
+			}
 			if err = lrit.init(r, &msg.ListRange, r.Bck(), lrpWorkersDflt); err == nil {
 				// dynamic ctlmsg
 				{
@@ -322,6 +334,12 @@ func (r *XactTCObjs) _put(hdr *transport.ObjHdr, objReader io.Reader, lom *core.
 ///////////
 
 func (wi *tcowi) do(lom *core.LOM, lrit *lrit) {
+	if wi.flt != nil && !wi.flt.match(lom, false /*loaded*/) {
+		return
+	}
+	if wi.msg.DryRun {
+		wi.r.dry.add(lom.ObjName)
+	}
 	var (
 		objNameTo = wi.msg.ToName(lom.ObjName)
 		buf, slab = core.T.PageMM().Alloc()
@@ -11,6 +11,7 @@ import (
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/core"
@@ -29,8 +30,14 @@ type (
 		msg          *apc.LsoMsg
 		lomVisitedCb lomVisitedCb
 		markerDir    string
+		lastCP       string // last rolled-up common prefix (when listing with arbitrary delimiter)
 		wanted       cos.BitFlags
 		custom       cos.StrKVs
+		arch         struct {
+			match  func(filename string) bool // archived filename (apc.LsoMsg.ArchRegx)
+			shard  string                     // when the prefix crosses shard boundary (e.g. "A.tar/tutorials/" => "A.tar")
+			prefix string                     // the original prefix (to match archived filenames)
+		}
 	}
 )
 
@@ -55,9 +62,36 @@ func newWalkInfo(msg *apc.LsoMsg, lomVisitedCb lomVisitedCb) (wi *walkInfo) {
 	if msg.IsFlagSet(apc.LsVerChanged) {
 		wi.custom = make(cos.StrKVs)
 	}
+	if msg.IsFlagSet(apc.LsArchDir) {
+		wi.initArch(msg)
+	}
 	return
 }
 
+func (wi *walkInfo) initArch(msg *apc.LsoMsg) {
+	// list archived files under a given archive-internal prefix: walk (and match) only the shard itself
+	if shard, _, ok := archive.SplitPrefix(msg.Prefix); ok {
+		wi.arch.shard, wi.arch.prefix = shard, msg.Prefix
+		wmsg := *msg
+		wmsg.Prefix = shard
+		wi.msg = &wmsg
+	}
+	// (validated by proxy)
+	if msg.ArchRegx != "" {
+		var err error
+		wi.arch.match, err = archive.NewMatcher(msg.ArchRegx, msg.ArchMode)
+		debug.AssertNoErr(err)
+	}
+}
+
+// archived file (full name: "<shard>/<archived filename>")
+func (wi *walkInfo) matchArch(name, filename string) bool {
+	if wi.arch.shard != "" && !strings.HasPrefix(name, wi.arch.prefix) {
+		return false
+	}
+	return wi.arch.match == nil || wi.arch.match(filename)
+}
+
 func (wi *walkInfo) lsmsg() *apc.LsoMsg { return wi.msg }
 
 func (wi *walkInfo) processDir(fqn string) error {
@@ -96,11 +130,32 @@ func (wi *walkInfo) ls(lom *core.LOM, status uint16) (e *cmn.LsoEnt) {
 	if wi.msg.IsFlagSet(apc.LsNameOnly) {
 		return
 	}
+	if repl := &lom.Bprops().Replication; repl.Enabled {
+		if repl.IsReplicated(lom) {
+			e.Flags |= apc.EntryReplicated
+		} else {
+			e.Flags |= apc.EntryReplPending
+		}
+	}
 	wi.setWanted(e, lom)
 	wi.lomVisitedCb(lom)
 	return
 }
 
+// arbitrary delimiter: object name rolls up into common prefix that we return
+// (as a virtual directory) only once
+// - relying on the walk being sorted, and on the proxy to dedup across targets
+func (wi *walkInfo) rollup(cp string) *cmn.LsoEnt {
+	if cp == wi.lastCP || wi.msg.IsFlagSet(apc.LsNoDirs) {
+		return nil
+	}
+	wi.lastCP = cp
+	if wi.msg.ContinuationToken != "" && cmn.TokenGreaterEQ(wi.msg.ContinuationToken, cp) {
+		return nil // listed in one of the previous pages
+	}
+	return &cmn.LsoEnt{Name: cp, Flags: apc.EntryIsDir}
+}
+
 // NOTE: slow path if lom.Bck is remote
 func checkRemoteMD(lom *core.LOM, e *cmn.LsoEnt) {
 	res := lom.CheckRemoteMD(false /*locked*/, false /*sync*/, nil /*origReq*/)
@@ -141,6 +196,11 @@ func (wi *walkInfo) _cb(lom *core.LOM, fqn string) (*cmn.LsoEnt, error) {
 	if err != nil {
 		return nil, err
 	}
+	if local && wi.msg.IsDelimAny() {
+		if cp := cmn.CommonPrefix(lom.ObjName, wi.msg.Prefix, wi.msg.Delimiter); cp != "" {
+			return wi.rollup(cp), nil
+		}
+	}
 
 	status := uint16(apc.LocOK)
 	if !local {
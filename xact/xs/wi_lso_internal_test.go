@@ -0,0 +1,90 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestWalkInfoArch(t *testing.T) {
+	var (
+		shard = "dir/A.tar"
+		files = []string{ // (sorted - see archive.List)
+			"README", "images/a.jpg", "images/a.json", "images/b.jpg",
+			"tutorials/01.txt", "tutorials/02.jpg", "tutorials/deep/03.jpg",
+		}
+	)
+	// create shard
+	fqn := filepath.Join(t.TempDir(), "A.tar")
+	fh, err := os.Create(fqn)
+	tassert.CheckFatal(t, err)
+	aw := archive.NewWriter(archive.ExtTar, fh, nil, nil)
+	for _, name := range files {
+		tassert.CheckFatal(t, aw.Write(name, &cmn.ObjAttrs{Size: int64(len(name))}, strings.NewReader(name)))
+	}
+	aw.Fini()
+	tassert.CheckFatal(t, fh.Close())
+
+	list, err := archive.List(fqn)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(list) == len(files), "expected %d archived files, got %d", len(files), len(list))
+
+	tests := []struct {
+		name     string
+		msg      apc.LsoMsg
+		walk     string // prefix to walk
+		expected []string
+	}{
+		{"all", apc.LsoMsg{}, "", files},
+		{"prefix",
+			apc.LsoMsg{Prefix: shard + "/tutorials/"}, shard,
+			[]string{"tutorials/01.txt", "tutorials/02.jpg", "tutorials/deep/03.jpg"}},
+		{"regex",
+			apc.LsoMsg{ArchRegx: `^images/.*\.jpg$`}, "",
+			[]string{"images/a.jpg", "images/b.jpg"}},
+		{"prefix-and-regex",
+			apc.LsoMsg{Prefix: shard + "/tutorials/", ArchRegx: `\.jpg$`}, shard,
+			[]string{"tutorials/02.jpg", "tutorials/deep/03.jpg"}},
+		{"suffix",
+			apc.LsoMsg{ArchRegx: ".json", ArchMode: "suffix"}, "",
+			[]string{"images/a.json"}},
+		{"wdskey",
+			apc.LsoMsg{ArchRegx: "images/a", ArchMode: "wdskey"}, "",
+			[]string{"images/a.jpg", "images/a.json"}},
+		{"no-match",
+			apc.LsoMsg{Prefix: shard + "/images/", ArchRegx: "README", ArchMode: "substr"}, shard,
+			nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := test.msg
+			msg.SetFlag(apc.LsArchDir)
+			wi := &walkInfo{msg: &msg}
+			wi.initArch(&msg)
+			tassert.Errorf(t, wi.msg.Prefix == test.walk, "expected to walk %q, got %q", test.walk, wi.msg.Prefix)
+
+			var matched []string
+			for _, e := range list {
+				if wi.matchArch(path.Join(shard, e.Name), e.Name) {
+					matched = append(matched, e.Name)
+				}
+			}
+			tassert.Fatalf(t, len(matched) == len(test.expected), "expected %v, got %v", test.expected, matched)
+			for i := range matched {
+				tassert.Errorf(t, matched[i] == test.expected[i], "expected %v, got %v", test.expected, matched)
+			}
+		})
+	}
+}